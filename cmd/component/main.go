@@ -0,0 +1,93 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/leandroolgomes/golang-dependency-graph/componentgen"
+)
+
+const usage = `usage: component new [-dir dir] [-package name] [-force] <key> [dependency[:Type]...]
+
+Generates <key>.go and <key>_test.go in dir: the component type with
+Define, Start and Stop honouring the stop deadline, an accessor for each
+dependency asserting it to Type (component.Lifecycle by default) and a
+test isolating the component with componenttest.Isolate. For example:
+
+  component new -dir billing invoice_store db:*Database cache`
+
+// component scaffolds new components so they share one structure. It exits
+// with 1 when the files cannot be generated and 2 on usage errors
+func main() {
+	if len(os.Args) < 2 || os.Args[1] != "new" {
+		fmt.Fprintln(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	flags := flag.NewFlagSet("new", flag.ExitOnError)
+	dir := flags.String("dir", ".", "directory to write the files to")
+	pkg := flags.String("package", "", "package name, by default the name of dir")
+	force := flags.Bool("force", false, "overwrite existing files")
+	flags.Usage = func() { fmt.Fprintln(os.Stderr, usage) }
+	flags.Parse(os.Args[2:])
+	if flags.NArg() == 0 {
+		flags.Usage()
+		os.Exit(2)
+	}
+
+	if err := generate(*dir, *pkg, *force, flags.Arg(0), flags.Args()[1:]); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+// generate writes the files of one component
+func generate(dir, pkg string, force bool, key string, dependencies []string) error {
+	if pkg == "" {
+		abs, err := filepath.Abs(dir)
+		if err != nil {
+			return err
+		}
+		pkg = filepath.Base(abs)
+	}
+
+	spec := componentgen.Spec{Package: pkg, Key: key}
+	for _, arg := range dependencies {
+		dep, err := componentgen.ParseDependency(arg)
+		if err != nil {
+			return err
+		}
+		spec.Dependencies = append(spec.Dependencies, dep)
+	}
+
+	files, err := componentgen.Generate(spec)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	outputs := []struct {
+		name    string
+		content []byte
+	}{{files.Name, files.Source}, {files.TestName, files.Test}}
+	for _, output := range outputs {
+		path := filepath.Join(dir, output.name)
+		if _, err := os.Stat(path); err == nil && !force {
+			return fmt.Errorf("%s already exists, use -force to overwrite it", path)
+		} else if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+	}
+	for _, output := range outputs {
+		path := filepath.Join(dir, output.name)
+		if err := os.WriteFile(path, output.content, 0o644); err != nil {
+			return err
+		}
+		fmt.Println(path)
+	}
+	return nil
+}
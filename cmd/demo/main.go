@@ -1,51 +1,55 @@
 package main
 
 import (
+	"flag"
 	"fmt"
 	"os"
-	"os/signal"
-	"syscall"
+	"strings"
 
 	"github.com/leandroolgomes/golang-dependency-graph/component"
+	"github.com/leandroolgomes/golang-dependency-graph/componentconfig"
 	"github.com/leandroolgomes/golang-dependency-graph/examples"
+	"github.com/leandroolgomes/golang-dependency-graph/examples/app"
+	"github.com/leandroolgomes/golang-dependency-graph/runner"
 )
 
 func main() {
+	configPath := flag.String("config", "", "YAML or JSON file defining the components to wire")
+	profile := flag.String("profile", string(app.Dev), "settings profile of the example application: dev, test or prod")
+	flag.Parse()
 
-	config := component.Define("config", new(examples.Config))
-	appRoutes := component.Define("app_routes", new(examples.AppRoutes))
-	httpServer := component.Define("http_server", new(examples.HttpServer), appRoutes.Key(), config.Key())
-
-
-	components := map[string]*component.Component{
-		config.Key():     config,
-		appRoutes.Key():  appRoutes,
-		httpServer.Key(): httpServer,
+	var opts []component.Option
+	if strings.HasPrefix(os.Getenv("LANG"), "pt") {
+		opts = append(opts, component.WithCatalog(component.PortugueseMessages))
 	}
 
-
-	system := component.CreateSystem(components)
-
-	fmt.Println("Starting system...")
-	if err := system.Start(); err != nil {
-		fmt.Printf("Failed to start system: %v\n", err)
-		os.Exit(1)
+	if *configPath != "" {
+		system, err := registry().Load(*configPath, opts...)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		os.Exit(runner.Run(system))
 	}
-	fmt.Println("System started successfully")
-
 
-	sigChan := make(chan os.Signal, 1)
-	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
-
-
-	sig := <-sigChan
-	fmt.Printf("%s signal received, shutting down...\n", sig)
-
-
-	if err := system.Stop(); err != nil {
-		fmt.Printf("Error during system shutdown: %v\n", err)
+	application, err := app.New(app.Profile(*profile), opts...)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
 		os.Exit(1)
 	}
+	os.Exit(runner.Run(application.System))
+}
 
-	fmt.Println("System stopped successfully")
+// registry exposes the example components to -config definitions
+func registry() *componentconfig.Registry {
+	return componentconfig.NewRegistry().
+		Register("config", func(string, map[string]interface{}) (component.Lifecycle, error) {
+			return new(examples.Config), nil
+		}).
+		Register("app_routes", func(string, map[string]interface{}) (component.Lifecycle, error) {
+			return new(examples.AppRoutes), nil
+		}).
+		Register("http_server", func(string, map[string]interface{}) (component.Lifecycle, error) {
+			return new(examples.HttpServer), nil
+		})
 }
@@ -0,0 +1,25 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/leandroolgomes/golang-dependency-graph/component"
+	"github.com/leandroolgomes/golang-dependency-graph/examples"
+)
+
+func TestSystemYAMLMatchesExampleWiring(t *testing.T) {
+	loaded, err := registry().Load("system.yaml")
+	if err != nil {
+		t.Fatalf("Failed to load system.yaml: %v", err)
+	}
+
+	expected := component.CreateSystem(map[string]*component.Component{
+		"config":      component.Define("config", new(examples.Config)),
+		"app_routes":  component.Define("app_routes", new(examples.AppRoutes)),
+		"http_server": component.Define("http_server", new(examples.HttpServer), "app_routes", "config"),
+	})
+	if got, want := loaded.Topology(), expected.Topology(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected system.yaml to wire %+v, got %+v", want, got)
+	}
+}
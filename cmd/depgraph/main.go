@@ -0,0 +1,79 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/leandroolgomes/golang-dependency-graph/componentconfig"
+)
+
+const usage = `usage: depgraph <command> [-format dot|mermaid] <definition.yaml|.json>
+
+commands:
+  validate  check that every dependency is provided and the graph is acyclic
+  order     print the start order, one component per line
+  cycles    print the path of a cyclic dependency, failing when there is one
+  render    render the graph in DOT (default) or Mermaid`
+
+// depgraph checks componentconfig definitions without starting anything, so
+// CI can catch wiring mistakes before deploy. It exits with 1 when the
+// definition is invalid and 2 on usage errors
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Fprintln(os.Stderr, usage)
+		os.Exit(2)
+	}
+	command := os.Args[1]
+
+	flags := flag.NewFlagSet(command, flag.ExitOnError)
+	format := flags.String("format", "dot", "render format: dot or mermaid")
+	flags.Parse(os.Args[2:])
+	if flags.NArg() != 1 {
+		fmt.Fprintln(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	definition, err := componentconfig.ParseFile(flags.Arg(0))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	switch command {
+	case "validate":
+		err = definition.Validate()
+		if err == nil {
+			fmt.Printf("%d components, wiring is valid\n", len(definition.Components))
+		}
+	case "order":
+		var order []string
+		if order, err = definition.Order(); err == nil {
+			fmt.Println(strings.Join(order, "\n"))
+		}
+	case "cycles":
+		if path := definition.Cycle(); path != nil {
+			err = &componentconfig.CycleError{Path: path}
+		} else {
+			fmt.Println("no cycles")
+		}
+	case "render":
+		switch *format {
+		case "dot":
+			err = definition.WriteDOT(os.Stdout)
+		case "mermaid":
+			err = definition.WriteMermaid(os.Stdout)
+		default:
+			fmt.Fprintf(os.Stderr, "unknown format %q\n", *format)
+			os.Exit(2)
+		}
+	default:
+		fmt.Fprintf(os.Stderr, "unknown command %q\n%s\n", command, usage)
+		os.Exit(2)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
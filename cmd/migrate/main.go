@@ -0,0 +1,86 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/leandroolgomes/golang-dependency-graph/componentfix"
+)
+
+const usage = `usage: migrate [-w] [-l] <file.go|dir>...
+
+Rewrites component.CreateSystem(map[string]*component.Component{...}) call
+sites to component.NewBuilder(...).Add(...).MustBuild(). Directories are
+walked recursively, skipping vendor and testdata. Call sites that cannot be
+rewritten safely are reported on stderr`
+
+// migrate converts map-based system definitions to the builder API, in the
+// manner of gofmt: it prints rewritten sources unless -w or -l is given. It
+// exits with 1 when a file cannot be processed and 2 on usage errors
+func main() {
+	write := flag.Bool("w", false, "write the result to the source files")
+	list := flag.Bool("l", false, "list the files that would change")
+	flag.Usage = func() { fmt.Fprintln(os.Stderr, usage) }
+	flag.Parse()
+	if flag.NArg() == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	failed := false
+	for _, root := range flag.Args() {
+		err := filepath.WalkDir(root, func(path string, entry fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if entry.IsDir() {
+				if path != root && (entry.Name() == "vendor" || entry.Name() == "testdata" || strings.HasPrefix(entry.Name(), ".")) {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			if !strings.HasSuffix(path, ".go") {
+				return nil
+			}
+			return migrate(path, *write, *list)
+		})
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			failed = true
+		}
+	}
+	if failed {
+		os.Exit(1)
+	}
+}
+
+// migrate rewrites one file
+func migrate(path string, write, list bool) error {
+	src, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	out, changed, findings, err := componentfix.Rewrite(path, src)
+	for _, finding := range findings {
+		if !finding.Rewritten {
+			fmt.Fprintln(os.Stderr, finding)
+		}
+	}
+	if err != nil || !changed {
+		return err
+	}
+
+	switch {
+	case list:
+		fmt.Println(path)
+	case write:
+		return os.WriteFile(path, out, 0o644)
+	default:
+		os.Stdout.Write(out)
+	}
+	return nil
+}
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/leandroolgomes/golang-dependency-graph/component"
+)
+
+// replay reconstructs a boot/shutdown timeline from a JSON lines event log
+// written by component.EventRecorder. It reads stdin when no file is given.
+// With -format mermaid or plantuml it renders the last run as a sequence diagram
+func main() {
+	format := flag.String("format", "timeline", "output format: timeline, mermaid or plantuml")
+	flag.Parse()
+
+	var input io.Reader = os.Stdin
+	if flag.NArg() > 0 {
+		f, err := os.Open(flag.Arg(0))
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to open event log: %v\n", err)
+			os.Exit(1)
+		}
+		defer f.Close()
+		input = f
+	}
+
+	events, err := component.ReadEvents(input)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to read event log: %v\n", err)
+		os.Exit(1)
+	}
+
+	switch *format {
+	case "timeline":
+		err = component.Replay(events).Write(os.Stdout)
+	case "mermaid":
+		err = component.WriteMermaidSequence(os.Stdout, component.LastRun(events))
+	case "plantuml":
+		err = component.WritePlantUMLSequence(os.Stdout, component.LastRun(events))
+	default:
+		err = fmt.Errorf("unknown format %q", *format)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to write %s: %v\n", *format, err)
+		os.Exit(1)
+	}
+}
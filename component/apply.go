@@ -0,0 +1,240 @@
+package component
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Changeset is a batch of topology changes applied with System.Apply
+type Changeset struct {
+	// Add registers new components
+	Add []*Component
+
+	// Remove unregisters the components under these keys
+	Remove []string
+
+	// Swap replaces registered components with new definitions under the
+	// same keys
+	Swap []*Component
+}
+
+// ApplyReport describes what Apply did
+type ApplyReport struct {
+	Plan *RestartPlan
+
+	// Executed lists the steps that completed before a failure, or all
+	// steps on success
+	Executed []PlanStep
+
+	Err         error
+	RolledBack  bool
+	RollbackErr error
+}
+
+// Apply validates a changeset against the live system and executes the
+// minimal stop/start plan: removed and swapped components stop together
+// with their running dependents, then swapped, added and dependent
+// components start in the new dependency order. If any step fails the
+// system is rolled back to the previous topology. On a stopped system only
+// the topology is replaced
+func (s *System) Apply(changes Changeset) (*ApplyReport, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.applyLocked(changes)
+}
+
+// applyLocked applies a changeset; the caller must hold s.mu
+func (s *System) applyLocked(changes Changeset) (*ApplyReport, error) {
+	next, causes, err := s.nextTopology(changes)
+	if err != nil {
+		return nil, err
+	}
+
+	// Validate the resulting graph before touching anything
+	candidate := s.candidate(next)
+	nextOrder, err := candidate.validate()
+	if err != nil {
+		return nil, fmt.Errorf("invalid changeset: %w", err)
+	}
+
+	s.watchTransitions(next)
+	if !s.started.Load() {
+		s.components = next
+		s.providers = nil
+		s.graph.Store(s.declaredSnapshot())
+		return &ApplyReport{Plan: &RestartPlan{}}, s.saveDesired(s.desiredTopology())
+	}
+
+	// The runtime configuration keeps disabled components down
+	nextOrder, skipped := s.filterDisabled(candidate, nextOrder)
+
+	// Dependents are affected through the old graph for removals and swaps,
+	// and through the new one for prefix dependencies matching added keys
+	previous := s.components
+	previousOrder := append([]string(nil), s.startOrder...)
+	changed := make([]string, 0, len(causes))
+	for key := range causes {
+		changed = append(changed, key)
+	}
+	for _, affected := range []map[string]string{s.affectedBy(changed, previousOrder), candidate.affectedBy(changed, nextOrder)} {
+		for key, cause := range affected {
+			if _, set := causes[key]; !set {
+				causes[key] = cause
+			}
+		}
+	}
+
+	plan := &RestartPlan{}
+	for i := len(previousOrder) - 1; i >= 0; i-- {
+		name := previousOrder[i]
+		if cause, affected := causes[name]; affected && previous[name].IsStarted() {
+			plan.add(OpStop, name, cause, s.timings[name].stop)
+		}
+	}
+	for _, name := range nextOrder {
+		cause, affected := causes[name]
+		if !affected {
+			continue
+		}
+		// Completed one-shots do not run again for their dependencies' sake
+		if previous[name] == next[name] && next[name].IsCompleted() {
+			continue
+		}
+		plan.add(OpStart, name, cause, s.timings[name].start)
+	}
+
+	report := &ApplyReport{Plan: plan}
+	correlationID := newCorrelationID()
+	reason := ShutdownReason{Cause: ShutdownAPI, Detail: "apply"}
+
+	switched := false
+	for _, step := range plan.Steps {
+		if step.Op == OpStart && !switched {
+			// Switch to the new topology once everything affected stopped
+			s.switchTopology(next, previous)
+			switched = true
+		}
+
+		var stepErr error
+		if step.Op == OpStop {
+			if stepErr = s.stopComponent(s.components[step.Key], correlationID, reason); stepErr != nil {
+				stepErr = &ComponentError{Key: step.Key, Op: OpStop, Err: stepErr}
+			}
+		} else {
+			stepErr = s.startComponent(s.components[step.Key], correlationID)
+		}
+		if stepErr != nil {
+			report.Err = correlate(correlationID, stepErr)
+			break
+		}
+		report.Executed = append(report.Executed, step)
+	}
+	if report.Err == nil {
+		if !switched {
+			s.switchTopology(next, previous)
+		}
+		s.startOrder = s.runningOrder(nextOrder)
+		s.skipped = skipped
+		s.publishGraph(nextOrder)
+		if err := s.saveDesired(s.desiredTopology()); err != nil {
+			report.Err = err
+			return report, err
+		}
+		return report, nil
+	}
+
+	report.RolledBack = true
+	report.RollbackErr = s.rollback(report.Executed, previous, previousOrder, correlationID)
+	return report, report.Err
+}
+
+// nextTopology returns the components after applying the changeset and the
+// cause of each direct change; the caller must hold s.mu
+func (s *System) nextTopology(changes Changeset) (map[string]*Component, map[string]string, error) {
+	next := make(map[string]*Component, len(s.components))
+	for key, component := range s.components {
+		next[key] = component
+	}
+	causes := make(map[string]string)
+
+	for _, key := range changes.Remove {
+		if _, exists := next[key]; !exists {
+			return nil, nil, fmt.Errorf("cannot remove component %s: not found", key)
+		}
+		delete(next, key)
+		causes[key] = "removed"
+	}
+	for _, component := range changes.Swap {
+		if _, exists := next[component.key]; !exists || causes[component.key] != "" {
+			return nil, nil, fmt.Errorf("cannot swap component %s: not registered", component.key)
+		}
+		next[component.key] = component
+		causes[component.key] = "swapped"
+	}
+	for _, component := range changes.Add {
+		if _, exists := next[component.key]; exists {
+			return nil, nil, fmt.Errorf("cannot add component %s: already registered", component.key)
+		}
+		next[component.key] = component
+		causes[component.key] = "added"
+	}
+	return next, causes, nil
+}
+
+// switchTopology installs a component map, dropping the context entries of
+// components absent from it; the caller must hold s.mu
+func (s *System) switchTopology(components, from map[string]*Component) {
+	for key, component := range from {
+		if components[key] != component {
+			delete(s.context, key)
+			for _, provided := range component.GetProvides() {
+				delete(s.context, provided)
+			}
+		}
+	}
+	s.components = components
+	s.buildProviders()
+}
+
+// runningOrder filters an order down to the components that are running or
+// completed, the order Stop unwinds; the caller must hold s.mu
+func (s *System) runningOrder(order []string) []string {
+	var running []string
+	for _, name := range order {
+		if component := s.components[name]; component.IsStarted() || component.IsCompleted() {
+			running = append(running, name)
+		}
+	}
+	return running
+}
+
+// rollback undoes the executed steps of a failed Apply: components started
+// by it stop, the previous topology is restored and the components it
+// stopped start again; the caller must hold s.mu
+func (s *System) rollback(executed []PlanStep, previous map[string]*Component, previousOrder []string, correlationID string) error {
+	var errs []error
+	reason := ShutdownReason{Cause: ShutdownAPI, Detail: "apply rollback"}
+
+	stopped := make(map[string]bool)
+	for i := len(executed) - 1; i >= 0; i-- {
+		step := executed[i]
+		if step.Op == OpStop {
+			stopped[step.Key] = true
+			continue
+		}
+		if err := s.stopComponent(s.components[step.Key], correlationID, reason); err != nil {
+			errs = append(errs, &ComponentError{Key: step.Key, Op: OpStop, Err: err})
+		}
+	}
+
+	s.switchTopology(previous, s.components)
+	for _, name := range previousOrder {
+		if stopped[name] {
+			if err := s.startComponent(previous[name], correlationID); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+	s.startOrder = s.runningOrder(previousOrder)
+	return errors.Join(errs...)
+}
@@ -0,0 +1,155 @@
+package component
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func applySystem(t *testing.T) (*System, map[string]*MockComponent) {
+	t.Helper()
+	silenceTestStdout(t)
+
+	mocks := map[string]*MockComponent{"db": {}, "cache": {}, "api": {}}
+	system := CreateSystem(map[string]*Component{
+		"db":    Define("db", mocks["db"]),
+		"cache": Define("cache", mocks["cache"]),
+		"api":   Define("api", mocks["api"], "db"),
+	})
+	if err := system.Start(); err != nil {
+		t.Fatalf("Failed to start system: %v", err)
+	}
+	return system, mocks
+}
+
+func TestApplySwap(t *testing.T) {
+	system, mocks := applySystem(t)
+	defer system.Stop()
+
+	replacement := &MockComponent{}
+	report, err := system.Apply(Changeset{
+		Swap: []*Component{Define("db", replacement)},
+		Add:  []*Component{Define("worker", &MockComponent{}, "db")},
+	})
+	if err != nil {
+		t.Fatalf("Failed to apply: %v", err)
+	}
+
+	if got := planSteps(report.Plan); got != "stop api, stop db, start db, start api, start worker" {
+		t.Errorf("Unexpected plan %s", got)
+	}
+	if !mocks["db"].StopCalled || !replacement.StartCalled || mocks["cache"].StopCalled {
+		t.Error("Expected only db and its dependents to restart")
+	}
+	if system.GetContext()["db"] != replacement {
+		t.Error("Expected the context to hold the new db")
+	}
+	if order := system.EffectiveOrder(); len(order) != 4 || order[len(order)-1] != "worker" {
+		t.Errorf("Expected the new component in the effective order, got %v", order)
+	}
+}
+
+func TestApplyRemove(t *testing.T) {
+	system, mocks := applySystem(t)
+	defer system.Stop()
+
+	if _, err := system.Apply(Changeset{Remove: []string{"db"}}); err == nil {
+		t.Fatal("Expected removing a dependency of a remaining component to fail")
+	}
+	if mocks["db"].StopCalled {
+		t.Error("Expected an invalid changeset not to stop anything")
+	}
+
+	if _, err := system.Apply(Changeset{Remove: []string{"api", "db"}}); err != nil {
+		t.Fatalf("Failed to apply: %v", err)
+	}
+	if _, exists := system.GetContext()["db"]; exists {
+		t.Error("Expected removed components to leave the context")
+	}
+	if order := system.EffectiveOrder(); len(order) != 1 || order[0] != "cache" {
+		t.Errorf("Expected only cache running, got %v", order)
+	}
+}
+
+func TestApplyRollback(t *testing.T) {
+	system, mocks := applySystem(t)
+	defer system.Stop()
+
+	broken := &MockComponent{StartError: errors.New("bad config")}
+	report, err := system.Apply(Changeset{Swap: []*Component{Define("db", broken)}})
+	if err == nil || !report.RolledBack || report.RollbackErr != nil {
+		t.Fatalf("Expected a clean rollback, got err=%v report=%+v", err, report)
+	}
+	if len(report.Executed) != 2 {
+		t.Errorf("Expected the two stops to have executed, got %+v", report.Executed)
+	}
+
+	if system.GetContext()["db"] != mocks["db"] {
+		t.Error("Expected the original db restored")
+	}
+	for _, key := range []string{"db", "api"} {
+		if state := system.components[key].State(); state != StateStarted {
+			t.Errorf("Expected %s running again, got %s", key, state)
+		}
+	}
+}
+
+func TestApplyConcurrentWithReaders(t *testing.T) {
+	system := CreateSystem(map[string]*Component{
+		"db":  Define("db", &MockComponent{}).WithTags("storage"),
+		"api": Define("api", &MockComponent{}, "db").WithTags("edge"),
+	})
+	if err := system.Start(); err != nil {
+		t.Fatalf("Failed to start system: %v", err)
+	}
+	defer system.Stop()
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for range 50 {
+			if _, err := system.Apply(Changeset{Swap: []*Component{Define("db", &MockComponent{}).WithTags("storage")}}); err != nil {
+				t.Errorf("Failed to swap db: %v", err)
+				return
+			}
+		}
+	}()
+
+	// Readers such as the tag middleware never wait for Apply
+	for {
+		select {
+		case <-done:
+			if tagged := system.ComponentsWithTag("storage"); len(tagged) != 1 {
+				t.Errorf("Expected db tagged storage, got %v", tagged)
+			}
+			return
+		default:
+			system.ComponentsWithTag("storage")
+			system.Component("db")
+			system.Capabilities("db")
+			system.Quarantined()
+			system.OneShots()
+		}
+	}
+}
+
+func TestApplyKeepsStrictChecks(t *testing.T) {
+	silenceTestStdout(t)
+	system := CreateSystem(map[string]*Component{
+		"db": Define("db", &MockComponent{}),
+	}, WithStrict())
+	if err := system.Start(); err != nil {
+		t.Fatalf("Failed to start system: %v", err)
+	}
+	defer system.Stop()
+
+	_, err := system.Apply(Changeset{Add: []*Component{
+		Define("cache", &MockComponent{}, "db").Requires(CapabilityStateSaver),
+	}})
+	if err == nil || !strings.Contains(err.Error(), "capability state_saver required") {
+		t.Errorf("Expected Apply to enforce strict capabilities, got %v", err)
+	}
+	if _, exists := system.Component("cache"); exists {
+		t.Error("Expected the rejected component not to be registered")
+	}
+}
@@ -0,0 +1,106 @@
+package component
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+// ErrUnexpectedResult is matched by every ResultTypeError
+var ErrUnexpectedResult = errors.New("unexpected component result")
+
+// ResultTypeError reports a context entry whose result does not have the
+// expected type. Got is nil when the key holds no result
+type ResultTypeError struct {
+	Want reflect.Type
+	Got  reflect.Type
+}
+
+func (e *ResultTypeError) Error() string {
+	if e.Got == nil {
+		return fmt.Sprintf("no result, expected %s", e.Want)
+	}
+	return fmt.Sprintf("result is %s, expected %s", e.Got, e.Want)
+}
+
+func (e *ResultTypeError) Is(target error) bool {
+	return target == ErrUnexpectedResult
+}
+
+// WithProvides declares the type each context key must hold once started,
+// as AssertProvides would check it. Start fails with every mismatch joined
+// after all components started, before the system counts as started
+func WithProvides(prototypes map[string]any) Option {
+	return func(s *System) {
+		if s.provides == nil {
+			s.provides = make(map[string]any, len(prototypes))
+		}
+		for key, prototype := range prototypes {
+			s.provides[key] = prototype
+		}
+	}
+}
+
+// AssertProvides checks that the result stored under a component or
+// provided key is assignable to the type of prototype, catching a
+// component returning the wrong thing at boot rather than at first use. A
+// pointer to an interface, such as (*io.Reader)(nil), expects a result
+// implementing that interface, and a nil prototype any result at all.
+// Mismatches are reported as a ComponentError wrapping a ResultTypeError
+func (s *System) AssertProvides(key string, prototype any) error {
+	s.shared.Lock()
+	defer s.shared.Unlock()
+	return s.assertProvides(key, prototype)
+}
+
+// assertProvides checks one context entry; the caller must hold s.shared
+func (s *System) assertProvides(key string, prototype any) error {
+	want := expectedType(prototype)
+	result, ok := s.context[key]
+	if !ok || result == nil {
+		return &ComponentError{Key: key, Op: OpValidate, Err: &ResultTypeError{Want: want}}
+	}
+	if got := reflect.TypeOf(result); !got.AssignableTo(want) {
+		return &ComponentError{Key: key, Op: OpValidate, Err: &ResultTypeError{Want: want, Got: got}}
+	}
+	return nil
+}
+
+// checkProvides checks every type declared with WithProvides, in key
+// order, except for the components the runtime configuration left out
+func (s *System) checkProvides() error {
+	keys := make([]string, 0, len(s.provides))
+	for key := range s.provides {
+		if !s.skipped[key] {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	s.shared.Lock()
+	defer s.shared.Unlock()
+
+	var errs []error
+	for _, key := range keys {
+		if err := s.assertProvides(key, s.provides[key]); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return joinErrors(errs)
+}
+
+// expectedType returns the type a prototype stands for: the interface a
+// pointer to an interface points to, the prototype's own type, or any for
+// a nil prototype
+func expectedType(prototype any) reflect.Type {
+	t := reflect.TypeOf(prototype)
+	switch {
+	case t == nil:
+		return reflect.TypeOf((*any)(nil)).Elem()
+	case t.Kind() == reflect.Pointer && t.Elem().Kind() == reflect.Interface:
+		return t.Elem()
+	default:
+		return t
+	}
+}
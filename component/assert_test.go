@@ -0,0 +1,86 @@
+package component
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// Store is implemented by the results the assertions expect
+type Store interface {
+	Get(key string) string
+}
+
+// MemoryStore is a Store component
+type MemoryStore struct {
+	MockComponent
+}
+
+func (m *MemoryStore) Start(ctx Context) (Lifecycle, error) {
+	return m, nil
+}
+
+func (m *MemoryStore) Get(key string) string {
+	return key
+}
+
+func (m *MemoryStore) Provided() map[string]Lifecycle {
+	return map[string]Lifecycle{"cache": m}
+}
+
+func TestAssertProvides(t *testing.T) {
+	system := CreateSystem(map[string]*Component{
+		"store": Define("store", &MemoryStore{}).Provides("cache"),
+		"api":   Define("api", &MockComponent{}, "store"),
+	})
+	if err := system.Start(); err != nil {
+		t.Fatalf("Failed to start system: %v", err)
+	}
+	defer system.Stop()
+
+	for _, tc := range []struct {
+		key       string
+		prototype any
+		want      string
+	}{
+		{key: "store", prototype: (*MemoryStore)(nil)},
+		{key: "cache", prototype: (*Store)(nil)},
+		{key: "api", prototype: nil},
+		{key: "api", prototype: (*Store)(nil), want: "result is *component.MockComponent, expected component.Store for component api"},
+		{key: "store", prototype: (*fmt.Stringer)(nil), want: "result is *component.MemoryStore, expected fmt.Stringer"},
+		{key: "queue", prototype: (*MockComponent)(nil), want: "no result, expected *component.MockComponent for component queue"},
+	} {
+		err := system.AssertProvides(tc.key, tc.prototype)
+		switch {
+		case tc.want == "" && err != nil:
+			t.Errorf("Expected %s to provide %T, got %v", tc.key, tc.prototype, err)
+		case tc.want != "" && (err == nil || !strings.Contains(err.Error(), tc.want) || !errors.Is(err, ErrUnexpectedResult)):
+			t.Errorf("Expected %q for %s, got %v", tc.want, tc.key, err)
+		}
+	}
+}
+
+func TestWithProvides(t *testing.T) {
+	system := CreateSystem(map[string]*Component{
+		"store": Define("store", &MemoryStore{}),
+		"api":   Define("api", &MockComponent{}, "store"),
+	}, WithProvides(map[string]any{
+		"store": (*Store)(nil),
+		"api":   (*Store)(nil),
+		"db":    (*MemoryStore)(nil),
+	}))
+
+	err := system.Start()
+	for _, want := range []string{"expected component.Store for component api", "no result, expected *component.MemoryStore for component db"} {
+		if err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("Expected %q in %v", want, err)
+		}
+	}
+	if system.IsStarted() {
+		t.Error("Expected the mismatch to fail the start")
+	}
+	if err := system.Stop(); err != nil {
+		t.Errorf("Expected the started components to unwind, got %v", err)
+	}
+}
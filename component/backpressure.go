@@ -0,0 +1,153 @@
+package component
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// BackpressureContextKey holds the component's Backpressure in its Context
+const BackpressureContextKey = ReservedPrefix + "backpressure"
+
+// EventBackpressure is emitted when a component holding a Backpressure is
+// told to stop producing
+const EventBackpressure EventType = "backpressure"
+
+// Backpressure tells a producer component, such as an HTTP ingress or a
+// poller, that the components downstream of it are about to stop. It is
+// signaled once the system starts stopping, before any component stops, or
+// when one of the component's dependencies is stopped on its own. A Start
+// gets a fresh Backpressure, so a restarted producer may produce again
+type Backpressure struct {
+	done   chan struct{}
+	once   sync.Once
+	reason atomic.Pointer[string]
+	used   atomic.Bool
+}
+
+func newBackpressure() *Backpressure {
+	return &Backpressure{done: make(chan struct{})}
+}
+
+// Done is closed when the producer should stop producing
+func (b *Backpressure) Done() <-chan struct{} {
+	return b.done
+}
+
+// Signaled reports whether the producer should stop producing
+func (b *Backpressure) Signaled() bool {
+	select {
+	case <-b.done:
+		return true
+	default:
+		return false
+	}
+}
+
+// Reason describes why the producer was signaled, or is empty
+func (b *Backpressure) Reason() string {
+	if reason := b.reason.Load(); reason != nil {
+		return *reason
+	}
+	return ""
+}
+
+// signal closes Done, reporting whether this call did so
+func (b *Backpressure) signal(reason string) bool {
+	signaled := false
+	b.once.Do(func() {
+		b.reason.Store(&reason)
+		close(b.done)
+		signaled = true
+	})
+	return signaled
+}
+
+func (b *Backpressure) Start(ctx Context) (Lifecycle, error) {
+	return b, nil
+}
+
+func (b *Backpressure) Stop(ctx Context) error {
+	return nil
+}
+
+// Backpressure returns the shutdown signal of the component the context was
+// built for. Outside a System it is never signaled
+func (ctx Context) Backpressure() *Backpressure {
+	backpressure, ok := ctx[BackpressureContextKey].(*Backpressure)
+	if !ok {
+		return newBackpressure()
+	}
+	backpressure.used.Store(true)
+	return backpressure
+}
+
+// WithBackpressureGrace waits d between signaling producers and stopping the
+// first component, when at least one component asked for its Backpressure
+func WithBackpressureGrace(d time.Duration) Option {
+	return func(s *System) {
+		s.backpressureGrace = d
+	}
+}
+
+// newBackpressureFor creates the Backpressure of a component's next Start;
+// the caller must hold s.shared
+func (s *System) newBackpressureFor(component *Component) *Backpressure {
+	if s.backpressure == nil {
+		s.backpressure = make(map[string]*Backpressure)
+	}
+	backpressure := newBackpressure()
+	s.backpressure[component.key] = backpressure
+	return backpressure
+}
+
+// signalBackpressure signals the given components, emitting an event for
+// each one that asked for its Backpressure. It reports whether any did
+func (s *System) signalBackpressure(keys []string, detail string, correlationID string, reason ShutdownReason) bool {
+	s.shared.Lock()
+	var signaled []string
+	for _, key := range keys {
+		backpressure, ok := s.backpressure[key]
+		if ok && backpressure.signal(detail) && backpressure.used.Load() {
+			signaled = append(signaled, key)
+		}
+	}
+	s.shared.Unlock()
+
+	for _, key := range signaled {
+		event := componentEvent(EventBackpressure, s.components[key], correlationID)
+		event.Reason = &reason
+		s.emit(event)
+	}
+	return len(signaled) > 0
+}
+
+// applyBackpressure signals every producer before the system stops
+func (s *System) applyBackpressure(correlationID string, reason ShutdownReason) {
+	if s.signalBackpressure(s.sortedKeys(), "system stopping", correlationID, reason) && s.backpressureGrace > 0 {
+		time.Sleep(s.backpressureGrace)
+	}
+}
+
+// applyDependentBackpressure signals the started components that depend on
+// a component about to stop, directly or transitively
+func (s *System) applyDependentBackpressure(component *Component, correlationID string, reason ShutdownReason) {
+	affected := map[string]bool{component.key: true}
+	var dependents []string
+	for _, name := range s.startedOrder() {
+		for _, dep := range s.resolvedDependencies(s.components[name]) {
+			if affected[dep] && !affected[name] {
+				affected[name] = true
+				dependents = append(dependents, name)
+			}
+		}
+	}
+	s.signalBackpressure(dependents, "dependency "+component.key+" stopping", correlationID, reason)
+}
+
+// startedOrder returns a copy of the effective start order
+func (s *System) startedOrder() []string {
+	s.shared.Lock()
+	defer s.shared.Unlock()
+	return append([]string(nil), s.startOrder...)
+}
@@ -0,0 +1,75 @@
+package component
+
+import (
+	"testing"
+)
+
+// ProducerComponent keeps the Backpressure handed to it at Start
+type ProducerComponent struct {
+	MockComponent
+	Backpressure *Backpressure
+}
+
+func (p *ProducerComponent) Start(ctx Context) (Lifecycle, error) {
+	p.Backpressure = ctx.Backpressure()
+	return p.MockComponent.Start(ctx)
+}
+
+// ConsumerComponent records whether its producer was signaled before it stopped
+type ConsumerComponent struct {
+	MockComponent
+	Producer       *ProducerComponent
+	ProducerHalted bool
+}
+
+func (c *ConsumerComponent) Stop(ctx Context) error {
+	c.ProducerHalted = c.Producer.Backpressure.Signaled()
+	return c.MockComponent.Stop(ctx)
+}
+
+func TestBackpressureSignaledBeforeConsumersStop(t *testing.T) {
+	producer := &ProducerComponent{}
+	consumer := &ConsumerComponent{Producer: producer}
+	var signaled []string
+	system := CreateSystem(map[string]*Component{
+		"queue":  Define("queue", consumer),
+		"poller": Define("poller", producer, "queue"),
+		"cache":  Define("cache", &MockComponent{}),
+	}, WithEventListener(func(event Event) {
+		if event.Type == EventBackpressure {
+			signaled = append(signaled, event.Component)
+		}
+	}))
+
+	if err := system.Start(); err != nil {
+		t.Fatalf("Expected start to succeed, got %v", err)
+	}
+	first := producer.Backpressure
+	if first.Signaled() {
+		t.Fatal("Expected backpressure not to be signaled while running")
+	}
+
+	if err := system.Restart(); err != nil {
+		t.Fatalf("Expected restart to succeed, got %v", err)
+	}
+	if !consumer.ProducerHalted {
+		t.Error("Expected producer to be signaled before its consumer stopped")
+	}
+	if first.Reason() != "system stopping" {
+		t.Errorf("Expected system stopping reason, got %q", first.Reason())
+	}
+	if producer.Backpressure == first || producer.Backpressure.Signaled() {
+		t.Error("Expected restarted producer to get a fresh backpressure")
+	}
+	// Only components that asked for their backpressure are reported
+	if len(signaled) != 1 || signaled[0] != "poller" {
+		t.Errorf("Expected one backpressure event for poller, got %v", signaled)
+	}
+	system.Stop()
+}
+
+func TestBackpressureOutsideSystem(t *testing.T) {
+	if (Context{}).Backpressure().Signaled() {
+		t.Error("Expected backpressure outside a system never to be signaled")
+	}
+}
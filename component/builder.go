@@ -0,0 +1,76 @@
+package component
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Builder assembles a system from components and options, keying every
+// component by its own Key so the map key and the component cannot
+// disagree:
+//
+//	system, err := component.NewBuilder(component.WithParallelStart(4)).
+//		Add(component.Define("db", db), component.Define("api", api, "db")).
+//		Build()
+//
+// CreateSystem remains for map-based definitions; cmd/migrate rewrites
+// them to the builder
+type Builder struct {
+	components []*Component
+	options    []Option
+}
+
+// NewSystem creates a system from components keyed by their own Key,
+// rejecting nil components and keys given twice. Use NewBuilder to pass
+// options as well
+func NewSystem(components ...*Component) (*System, error) {
+	return NewBuilder().Add(components...).Build()
+}
+
+// NewBuilder creates a builder applying opts to the system it builds
+func NewBuilder(opts ...Option) *Builder {
+	return &Builder{options: opts}
+}
+
+// Add adds components to the system
+func (b *Builder) Add(components ...*Component) *Builder {
+	b.components = append(b.components, components...)
+	return b
+}
+
+// With adds options to the system
+func (b *Builder) With(opts ...Option) *Builder {
+	b.options = append(b.options, opts...)
+	return b
+}
+
+// Build creates the system, reporting nil components and components added
+// twice under the same key
+func (b *Builder) Build() (*System, error) {
+	components := make(map[string]*Component, len(b.components))
+	var errs []error
+	for i, c := range b.components {
+		if c == nil {
+			errs = append(errs, fmt.Errorf("component %d is nil", i))
+			continue
+		}
+		if _, exists := components[c.key]; exists {
+			errs = append(errs, &ComponentError{Key: c.key, Op: OpValidate, Err: errors.New("component added twice")})
+			continue
+		}
+		components[c.key] = c
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	return CreateSystem(components, b.options...), nil
+}
+
+// MustBuild is like Build but panics if the components are invalid
+func (b *Builder) MustBuild() *System {
+	system, err := b.Build()
+	if err != nil {
+		panic(err)
+	}
+	return system
+}
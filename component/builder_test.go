@@ -0,0 +1,59 @@
+package component
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestBuilder(t *testing.T) {
+	silenceTestStdout(t)
+
+	system, err := NewBuilder(WithParallelStart(2)).
+		Add(Define("db", &MockComponent{})).
+		Add(Define("api", &MockComponent{}, "db")).
+		Build()
+	if err != nil {
+		t.Fatalf("Failed to build system: %v", err)
+	}
+	if err := system.Start(); err != nil {
+		t.Fatalf("Failed to start system: %v", err)
+	}
+	defer system.Stop()
+	if order := system.Report().Order; len(order) != 2 || order[0] != "db" || !system.Report().Parallel {
+		t.Errorf("Expected both components started with the builder options, got %+v", system.Report())
+	}
+}
+
+func TestBuilderRejectsDuplicates(t *testing.T) {
+	_, err := NewBuilder().Add(Define("db", &MockComponent{}), Define("db", &MockComponent{}), nil).Build()
+	if err == nil || !strings.Contains(err.Error(), "component added twice for component db") || !strings.Contains(err.Error(), "component 2 is nil") {
+		t.Errorf("Expected the duplicate and nil components reported, got %v", err)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected MustBuild to panic")
+		}
+	}()
+	NewBuilder().Add(Define("db", &MockComponent{}), Define("db", &MockComponent{})).MustBuild()
+}
+
+func TestNewSystem(t *testing.T) {
+	silenceTestStdout(t)
+
+	system, err := NewSystem(Define("db", &MockComponent{}), Define("api", &MockComponent{}, "db"))
+	if err != nil {
+		t.Fatalf("Failed to create system: %v", err)
+	}
+	if err := system.Start(); err != nil {
+		t.Fatalf("Failed to start system: %v", err)
+	}
+	defer system.Stop()
+	if api, ok := system.Component("api"); !ok || api.State() != StateStarted {
+		t.Errorf("Expected api started under its own key, got %v", api)
+	}
+
+	if _, err := NewSystem(Define("db", &MockComponent{}), Define("db", &MockComponent{})); err == nil {
+		t.Error("Expected a duplicate key to be rejected")
+	}
+}
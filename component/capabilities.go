@@ -0,0 +1,72 @@
+package component
+
+// Capability names an optional interface a component implements
+type Capability string
+
+const (
+	CapabilityDependencyHook    Capability = "dependency_hook"
+	CapabilityStateSaver        Capability = "state_saver"
+	CapabilityStateLoader       Capability = "state_loader"
+	CapabilityReasonStopper     Capability = "reason_stopper"
+	CapabilityDependencyWatcher Capability = "dependency_watcher"
+	CapabilityPrefetcher        Capability = "prefetcher"
+	CapabilityMultiProvider     Capability = "multi_provider"
+	CapabilityHealthChecker     Capability = "health_checker"
+	CapabilityReadinessChecker  Capability = "readiness_checker"
+	CapabilityLivenessChecker   Capability = "liveness_checker"
+)
+
+// capabilityChecks reports, in order, whether a Lifecycle implements each
+// capability
+var capabilityChecks = []struct {
+	capability Capability
+	implements func(Lifecycle) bool
+}{
+	{CapabilityDependencyHook, func(l Lifecycle) bool { _, ok := l.(DependencyHook); return ok }},
+	{CapabilityStateSaver, func(l Lifecycle) bool { _, ok := l.(StateSaver); return ok }},
+	{CapabilityStateLoader, func(l Lifecycle) bool { _, ok := l.(StateLoader); return ok }},
+	{CapabilityReasonStopper, func(l Lifecycle) bool { _, ok := l.(ReasonStopper); return ok }},
+	{CapabilityDependencyWatcher, func(l Lifecycle) bool { _, ok := l.(DependencyWatcher); return ok }},
+	{CapabilityPrefetcher, func(l Lifecycle) bool { _, ok := l.(Prefetcher); return ok }},
+	{CapabilityMultiProvider, func(l Lifecycle) bool { _, ok := l.(MultiProvider); return ok }},
+	{CapabilityHealthChecker, func(l Lifecycle) bool { _, ok := l.(HealthChecker); return ok }},
+	{CapabilityReadinessChecker, func(l Lifecycle) bool { _, ok := l.(ReadinessChecker); return ok }},
+	{CapabilityLivenessChecker, func(l Lifecycle) bool { _, ok := l.(LivenessChecker); return ok }},
+}
+
+// Capabilities reports which optional interfaces the component under key
+// implements, checking its instance and, once started, its Start result,
+// so generic tooling can adapt its controls per component
+func (s *System) Capabilities(key string) ([]Capability, bool) {
+	component, exists := s.snapshot().components[key]
+	if !exists {
+		return nil, false
+	}
+
+	targets := []Lifecycle{component.instance}
+	if result := component.Result(); result != nil && result != component.instance {
+		targets = append(targets, result)
+	}
+
+	var capabilities []Capability
+	for _, check := range capabilityChecks {
+		for _, target := range targets {
+			if check.implements(target) {
+				capabilities = append(capabilities, check.capability)
+				break
+			}
+		}
+	}
+	return capabilities, true
+}
+
+// HasCapability reports whether the component under key implements the capability
+func (s *System) HasCapability(key string, capability Capability) bool {
+	capabilities, _ := s.Capabilities(key)
+	for _, c := range capabilities {
+		if c == capability {
+			return true
+		}
+	}
+	return false
+}
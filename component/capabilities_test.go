@@ -0,0 +1,59 @@
+package component
+
+import (
+	"testing"
+)
+
+// CapableComponent implements several optional interfaces
+type CapableComponent struct {
+	MockComponent
+}
+
+func (c *CapableComponent) SaveState() ([]byte, error) {
+	return nil, nil
+}
+
+func (c *CapableComponent) StopWithReason(ctx Context, reason ShutdownReason) error {
+	return nil
+}
+
+// ConnectionFactory returns a multi-provider from Start without being one
+type ConnectionFactory struct {
+	MockComponent
+}
+
+func (f *ConnectionFactory) Start(ctx Context) (Lifecycle, error) {
+	return (&Connections{}).Start(ctx)
+}
+
+func TestCapabilities(t *testing.T) {
+	silenceTestStdout(t)
+
+	system := CreateSystem(map[string]*Component{
+		"cache": Define("cache", &CapableComponent{}),
+		"db":    Define("db", &MockComponent{}),
+		"pool":  Define("pool", &ConnectionFactory{}).Provides("db.read"),
+	})
+
+	capabilities, ok := system.Capabilities("cache")
+	if !ok || len(capabilities) != 2 || capabilities[0] != CapabilityStateSaver || capabilities[1] != CapabilityReasonStopper {
+		t.Errorf("Expected state saver and reason stopper, got %v", capabilities)
+	}
+	if capabilities, _ := system.Capabilities("db"); len(capabilities) != 0 {
+		t.Errorf("Expected no capabilities, got %v", capabilities)
+	}
+	if _, ok := system.Capabilities("missing"); ok {
+		t.Error("Expected an unknown component to be reported")
+	}
+
+	if system.HasCapability("pool", CapabilityMultiProvider) {
+		t.Error("Expected the factory itself not to be a multi-provider")
+	}
+
+	if err := system.Start(); err != nil {
+		t.Fatalf("Failed to start system: %v", err)
+	}
+	if !system.HasCapability("pool", CapabilityMultiProvider) {
+		t.Error("Expected the start result to be checked too")
+	}
+}
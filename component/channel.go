@@ -0,0 +1,78 @@
+package component
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// ErrChannelClosed is returned by Channel.Send once the channel stopped
+var ErrChannelClosed = errors.New("channel is closed")
+
+// Channel is a typed channel owned by the system instead of by the
+// components using it. Registered with Define, it depends on its consumers
+// while producers depend on it, so on Stop every producer stops first, then
+// the channel closes and its consumers drain what was sent before stopping
+// themselves. Consumers hold the Channel directly since they start before it;
+// producers get it from their Context
+type Channel[T any] struct {
+	key    string
+	ch     chan T
+	closed bool
+	mu     sync.RWMutex
+}
+
+// NewChannel creates a channel buffering size values
+func NewChannel[T any](key string, size int) *Channel[T] {
+	return &Channel[T]{key: key, ch: make(chan T, size), closed: true}
+}
+
+// Define registers the channel as a component consumed by the given components
+func (c *Channel[T]) Define(consumers ...string) *Component {
+	return Define(c.key, c, consumers...)
+}
+
+// Start opens the channel for producers
+func (c *Channel[T]) Start(ctx Context) (Lifecycle, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.closed = false
+	return c, nil
+}
+
+// Stop closes the channel once the sends in progress complete, preparing a
+// new one for the consumers of the next start
+func (c *Channel[T]) Stop(ctx Context) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !c.closed {
+		c.closed = true
+		close(c.ch)
+		c.ch = make(chan T, cap(c.ch))
+	}
+	return nil
+}
+
+// Send delivers a value, blocking while the buffer is full. It returns
+// ErrChannelClosed instead of panicking once the channel stopped
+func (c *Channel[T]) Send(ctx context.Context, value T) error {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if c.closed {
+		return ErrChannelClosed
+	}
+	select {
+	case c.ch <- value:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Receive returns the channel consumers read from; it is closed, after the
+// values sent so far, when the channel stops
+func (c *Channel[T]) Receive() <-chan T {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.ch
+}
@@ -0,0 +1,86 @@
+package component
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// OrderProducer sends Orders through the channel from its Context
+type OrderProducer struct {
+	MockComponent
+	Orders  []int
+	channel *Channel[int]
+}
+
+func (p *OrderProducer) Start(ctx Context) (Lifecycle, error) {
+	p.channel, _ = DependencyAs[*Channel[int]](ctx, "orders")
+	for _, order := range p.Orders {
+		if err := p.channel.Send(context.Background(), order); err != nil {
+			return nil, err
+		}
+	}
+	return p.MockComponent.Start(ctx)
+}
+
+// OrderConsumer drains the channel in the background until it is closed
+type OrderConsumer struct {
+	MockComponent
+	Channel  *Channel[int]
+	Received []int
+	done     chan struct{}
+}
+
+func (c *OrderConsumer) Start(ctx Context) (Lifecycle, error) {
+	c.done = make(chan struct{})
+	in := c.Channel.Receive()
+	go func() {
+		defer close(c.done)
+		for order := range in {
+			c.Received = append(c.Received, order)
+		}
+	}()
+	return c.MockComponent.Start(ctx)
+}
+
+func (c *OrderConsumer) Stop(ctx Context) error {
+	<-c.done
+	return c.MockComponent.Stop(ctx)
+}
+
+func TestChannelDrainsBeforeConsumerStops(t *testing.T) {
+	silenceTestStdout(t)
+
+	orders := NewChannel[int]("orders", 1)
+	producer := &OrderProducer{Orders: []int{1, 2, 3}}
+	consumer := &OrderConsumer{Channel: orders}
+	system := CreateSystem(map[string]*Component{
+		"orders":   orders.Define("consumer"),
+		"consumer": Define("consumer", consumer),
+		"producer": Define("producer", producer, "orders"),
+	})
+
+	if err := system.Start(); err != nil {
+		t.Fatalf("Failed to start system: %v", err)
+	}
+	if err := system.Stop(); err != nil {
+		t.Fatalf("Expected stop to succeed, got %v", err)
+	}
+	if !reflect.DeepEqual(consumer.Received, []int{1, 2, 3}) {
+		t.Errorf("Expected every order drained, got %v", consumer.Received)
+	}
+	if err := producer.channel.Send(context.Background(), 4); !errors.Is(err, ErrChannelClosed) {
+		t.Errorf("Expected send after stop to fail with ErrChannelClosed, got %v", err)
+	}
+
+	// A restart gets a fresh channel
+	consumer.Received = nil
+	if err := system.Start(); err != nil {
+		t.Fatalf("Failed to restart system: %v", err)
+	}
+	system.Stop()
+	if !reflect.DeepEqual(consumer.Received, []int{1, 2, 3}) {
+		t.Errorf("Expected orders drained after restart, got %v", consumer.Received)
+	}
+}
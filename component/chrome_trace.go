@@ -0,0 +1,103 @@
+package component
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+)
+
+// FormatChromeTrace is the encoder format of ChromeTraceEncoder
+const FormatChromeTrace = "chrome_trace"
+
+// ChromeTraceEncoder writes a StartupReport in the Chrome trace_event
+// format, to be loaded in chrome://tracing or Perfetto. The whole start is
+// one slice on the first row; component starts are slices on the rows
+// below, those overlapping in a parallel start on separate rows
+type ChromeTraceEncoder struct{}
+
+// chromeTrace is the JSON object format of a trace_event file
+type chromeTrace struct {
+	TraceEvents     []chromeTraceEvent `json:"traceEvents"`
+	DisplayTimeUnit string             `json:"displayTimeUnit"`
+}
+
+// chromeTraceEvent is a complete ("X") event, timestamps in microseconds
+type chromeTraceEvent struct {
+	Name      string            `json:"name"`
+	Category  string            `json:"cat"`
+	Phase     string            `json:"ph"`
+	Timestamp float64           `json:"ts"`
+	Duration  float64           `json:"dur"`
+	PID       int               `json:"pid"`
+	TID       int               `json:"tid"`
+	Args      map[string]string `json:"args,omitempty"`
+}
+
+func (ChromeTraceEncoder) Encode(w io.Writer, document interface{}) error {
+	report, ok := document.(StartupReport)
+	if !ok {
+		return fmt.Errorf("%w: %T", ErrUnsupportedDocument, document)
+	}
+
+	trace := chromeTrace{DisplayTimeUnit: "ms", TraceEvents: []chromeTraceEvent{{
+		Name:      "system start",
+		Category:  "system",
+		Phase:     "X",
+		Timestamp: microseconds(0),
+		Duration:  microseconds(report.Duration),
+		PID:       1,
+		TID:       0,
+		Args:      map[string]string{"correlation_id": report.CorrelationID},
+	}}}
+	if report.Error != "" {
+		trace.TraceEvents[0].Args["error"] = report.Error
+	}
+
+	// rows holds when the last slice of each component row ends
+	var rows []time.Duration
+	for _, c := range report.Components {
+		row := len(rows)
+		for i, end := range rows {
+			if end <= c.Offset {
+				row = i
+				break
+			}
+		}
+		if row == len(rows) {
+			rows = append(rows, 0)
+		}
+		rows[row] = c.Offset + c.Duration
+
+		event := chromeTraceEvent{
+			Name:      c.Key,
+			Category:  "component",
+			Phase:     "X",
+			Timestamp: microseconds(c.Offset),
+			Duration:  microseconds(c.Duration),
+			PID:       1,
+			TID:       row + 1,
+			Args:      map[string]string{"batch": fmt.Sprint(c.Batch)},
+		}
+		if c.Error != "" {
+			event.Args["error"] = c.Error
+		}
+		trace.TraceEvents = append(trace.TraceEvents, event)
+	}
+	return json.NewEncoder(w).Encode(trace)
+}
+
+func (ChromeTraceEncoder) ContentType() string {
+	return "application/json"
+}
+
+// ExportChromeTrace writes the boot timeline of the last Start in the
+// Chrome trace_event format
+func (s *System) ExportChromeTrace(w io.Writer) error {
+	return ChromeTraceEncoder{}.Encode(w, s.Report())
+}
+
+// microseconds converts a duration to trace_event timestamp units
+func microseconds(d time.Duration) float64 {
+	return float64(d) / float64(time.Microsecond)
+}
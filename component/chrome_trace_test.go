@@ -0,0 +1,74 @@
+package component
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestChromeTraceEncoder(t *testing.T) {
+	report := StartupReport{
+		CorrelationID: "abc",
+		Duration:      10 * time.Millisecond,
+		Parallel:      true,
+		Components: []ComponentStartup{
+			{Key: "db", Offset: 0, Duration: 4 * time.Millisecond},
+			{Key: "cache", Offset: time.Millisecond, Duration: 2 * time.Millisecond},
+			{Key: "api", Offset: 4 * time.Millisecond, Duration: 5 * time.Millisecond, Batch: 1, Error: "port in use"},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := (ChromeTraceEncoder{}).Encode(&buf, report); err != nil {
+		t.Fatalf("Failed to encode: %v", err)
+	}
+	var trace chromeTrace
+	if err := json.Unmarshal(buf.Bytes(), &trace); err != nil {
+		t.Fatalf("Expected a trace_event document, got %v: %s", err, buf.String())
+	}
+	if len(trace.TraceEvents) != 4 || trace.TraceEvents[0].Name != "system start" || trace.TraceEvents[0].Duration != 10000 {
+		t.Fatalf("Expected the system slice first, got %+v", trace.TraceEvents)
+	}
+
+	rows := make(map[string]int)
+	for _, event := range trace.TraceEvents[1:] {
+		if event.Phase != "X" || event.PID != 1 {
+			t.Errorf("Expected complete events of one process, got %+v", event)
+		}
+		rows[event.Name] = event.TID
+	}
+	if rows["db"] != 1 || rows["cache"] != 2 || rows["api"] != 1 {
+		t.Errorf("Expected overlapping starts on separate rows and api reusing the first, got %v", rows)
+	}
+	if api := trace.TraceEvents[3]; api.Timestamp != 4000 || api.Duration != 5000 || api.Args["error"] != "port in use" {
+		t.Errorf("Expected api at 4ms for 5ms with its error, got %+v", api)
+	}
+
+	if err := (ChromeTraceEncoder{}).Encode(&buf, GraphExport{}); !errors.Is(err, ErrUnsupportedDocument) {
+		t.Errorf("Expected ErrUnsupportedDocument, got %v", err)
+	}
+}
+
+func TestExportChromeTrace(t *testing.T) {
+	silenceTestStdout(t)
+
+	system := CreateSystem(map[string]*Component{
+		"db":  Define("db", &MockComponent{}),
+		"api": Define("api", &MockComponent{}, "db"),
+	})
+	if err := system.Start(); err != nil {
+		t.Fatalf("Failed to start system: %v", err)
+	}
+	defer system.Stop()
+
+	var buf bytes.Buffer
+	if err := system.ExportChromeTrace(&buf); err != nil {
+		t.Fatalf("Failed to export: %v", err)
+	}
+	var trace chromeTrace
+	if err := json.Unmarshal(buf.Bytes(), &trace); err != nil || len(trace.TraceEvents) != 3 {
+		t.Errorf("Expected the system and two component slices, got %v: %s", err, buf.String())
+	}
+}
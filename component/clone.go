@@ -0,0 +1,61 @@
+package component
+
+import "reflect"
+
+// Cloner is an optional interface for instances that copy themselves for a
+// cloned system, e.g. a server switching to port zero or a store choosing
+// a directory under the clone's namespace
+type Cloner interface {
+	Clone(namespace string) Lifecycle
+}
+
+// Clone returns an unstarted copy of the system definition: the same
+// components, dependencies, params, tags and options, with none of the
+// runtime state. Each instance is replaced by its Clone when it implements
+// Cloner, and otherwise by a shallow copy of the struct it points to, so
+// parallel tests can each start their own system without repeating the
+// wiring. opts are applied after the options of the original system
+func (s *System) Clone(namespace string, opts ...Option) *System {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	components := make(map[string]*Component, len(s.components))
+	for key, component := range s.components {
+		components[key] = component.redefine(key, cloneInstance(component.instance, namespace))
+	}
+	return CreateSystem(components, append(append([]Option(nil), s.options...), opts...)...)
+}
+
+// cloneInstance copies an instance for a cloned system
+func cloneInstance(instance Lifecycle, namespace string) Lifecycle {
+	if cloner, ok := instance.(Cloner); ok {
+		return cloner.Clone(namespace)
+	}
+
+	value := reflect.ValueOf(instance)
+	if value.Kind() != reflect.Pointer || value.IsNil() || value.Elem().Kind() != reflect.Struct {
+		return instance
+	}
+	copied := reflect.New(value.Elem().Type())
+	copied.Elem().Set(value.Elem())
+	return copied.Interface().(Lifecycle)
+}
+
+// redefine copies the definition of a component under key with instance,
+// leaving its runtime state behind
+func (c *Component) redefine(key string, instance Lifecycle) *Component {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	copied := Define(key, instance, append([]string(nil), c.dependencies...)...)
+	copied.provides = append([]string(nil), c.provides...)
+	copied.params = c.params
+	copied.tags = append([]string(nil), c.tags...)
+	copied.metadata = c.metadata
+	copied.oneShot = c.oneShot
+	copied.requires = append([]Capability(nil), c.requires...)
+	copied.retry = c.retry
+	copied.hooks = append([]func(ctx Context) error(nil), c.hooks...)
+	copied.aliases = c.aliases
+	return copied
+}
@@ -0,0 +1,68 @@
+package component
+
+import (
+	"sync"
+	"testing"
+)
+
+// ListenerComponent picks its address per clone
+type ListenerComponent struct {
+	MockComponent
+	Addr string
+}
+
+func (l *ListenerComponent) Clone(namespace string) Lifecycle {
+	return &ListenerComponent{Addr: namespace + ":0"}
+}
+
+func TestCloneCopiesDefinition(t *testing.T) {
+	db := &MockComponent{Key: "db"}
+	listener := &ListenerComponent{Addr: ":8080"}
+	var hooked int
+	var mu sync.Mutex
+	original := CreateSystem(map[string]*Component{
+		"db": Define("db", db).WithTags("storage").WithParams(poolSettings{Size: 4}),
+		"api": Define("api", listener, "db").AfterDependenciesStarted(func(ctx Context) error {
+			mu.Lock()
+			hooked++
+			mu.Unlock()
+			return nil
+		}),
+	}, WithParallelStart(2))
+
+	var wg sync.WaitGroup
+	clones := make([]*System, 3)
+	for i := range clones {
+		clones[i] = original.Clone("test" + string(rune('a'+i)))
+		wg.Add(1)
+		go func(system *System) {
+			defer wg.Done()
+			if err := system.Start(); err != nil {
+				t.Errorf("Failed to start clone: %v", err)
+			}
+		}(clones[i])
+	}
+	wg.Wait()
+
+	if db.StartCalled || original.IsStarted() {
+		t.Error("Expected the original system to stay untouched")
+	}
+	if hooked != len(clones) {
+		t.Errorf("Expected the dependency hook to run once per clone, ran %d times", hooked)
+	}
+
+	api, _ := clones[1].Component("api")
+	if addr := api.Instance().(*ListenerComponent).Addr; addr != "testb:0" {
+		t.Errorf("Expected the Cloner to get the namespace, got %s", addr)
+	}
+	clonedDB, _ := clones[0].Component("db")
+	if clonedDB.Instance() == Lifecycle(db) || !clonedDB.Instance().(*MockComponent).StartCalled {
+		t.Error("Expected the clone to start its own copy of db")
+	}
+	if settings, ok := clonedDB.GetParams().(poolSettings); !ok || settings.Size != 4 || clonedDB.GetTags()[0] != "storage" {
+		t.Errorf("Expected params and tags to be copied, got %v %v", clonedDB.GetParams(), clonedDB.GetTags())
+	}
+	if clones[0].parallelStart != 2 {
+		t.Error("Expected the clone to keep the original options")
+	}
+}
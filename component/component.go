@@ -2,17 +2,37 @@ package component
 
 import (
 	"fmt"
+	"hash/fnv"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
 // Component defines a component with its dependencies
 type Component struct {
 	key          string
+	id           string
 	instance     Lifecycle
 	dependencies []string
-	result       interface{}
-	started      bool
+	provides     []string
+	params       interface{}
+	tags         []string
+	metadata     Metadata
+	oneShot      bool
+	stopFailures int
+	requires     []Capability
+	retry        startRetry
+	result       atomic.Pointer[Lifecycle]
+	state        atomic.Int32
+	since        atomic.Int64
+	startedAt    atomic.Int64
+	stoppedAt    atomic.Int64
+	watcher      atomic.Pointer[transitionListeners]
+	lastErr      atomic.Pointer[error]
+	hooks        []func(ctx Context) error
+	aliases      map[string]string
+	ctx          Context
+	startedWith  Context
 	mu           sync.Mutex
 }
 
@@ -20,9 +40,9 @@ type Component struct {
 func Define(key string, instance Lifecycle, dependencies ...string) *Component {
 	return &Component{
 		key:          key,
+		id:           componentID(key),
 		instance:     instance,
 		dependencies: dependencies,
-		started:      false,
 	}
 }
 
@@ -30,56 +50,158 @@ func (c *Component) Key() string {
 	return c.key
 }
 
+// ID returns a stable identifier derived from the component key, identical
+// across restarts and processes
+func (c *Component) ID() string {
+	return c.id
+}
+
+// componentID hashes a component key into its stable identifier
+func componentID(key string) string {
+	h := fnv.New64a()
+	h.Write([]byte(key))
+	return fmt.Sprintf("%016x", h.Sum64())
+}
+
+// logFields identifies the component and lifecycle operation in log lines
+func (c *Component) logFields(correlationID string, keysAndValues ...interface{}) []interface{} {
+	fields := []interface{}{"id", c.id}
+	if correlationID != "" {
+		fields = append(fields, "correlation_id", correlationID)
+	}
+	return append(fields, keysAndValues...)
+}
+
+// AfterDependenciesStarted registers a hook called after the component's
+// dependencies are started and before its own Start, without requiring the
+// instance to implement DependencyHook
+func (c *Component) AfterDependenciesStarted(hook func(ctx Context) error) *Component {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.hooks = append(c.hooks, hook)
+	return c
+}
+
+// runDependencyHooks runs the registered hooks followed by the instance hook
+func (c *Component) runDependencyHooks(ctx Context) error {
+	for _, hook := range c.hooks {
+		if err := hook(ctx); err != nil {
+			return err
+		}
+	}
+	if hook, ok := c.instance.(DependencyHook); ok {
+		return hook.AfterDependenciesStarted(ctx)
+	}
+	return nil
+}
+
+// operation carries the settings of the lifecycle operation a component
+// takes part in
+type operation struct {
+	correlationID string
+	reason        ShutdownReason
+	catalog       Catalog
+	logger        Logger
+}
+
+// standalone is the operation used when a component is driven directly
+var standalone = operation{reason: ShutdownReason{Cause: ShutdownAPI}, catalog: DefaultMessages, logger: DefaultLogger}
+
 // Start initializes the component
 func (c *Component) Start(ctx Context) (Lifecycle, error) {
+	return c.start(ctx, standalone)
+}
+
+// start initializes the component as part of a system operation
+func (c *Component) start(ctx Context, op operation) (Lifecycle, error) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
-	if c.started {
-		return c.instance, nil
+	if c.IsStarted() {
+		return c.Result(), nil
+	}
+
+	if err := c.transition(StateStarting); err != nil {
+		return nil, err
+	}
+	if err := c.callDependencyHooks(ctx); err != nil {
+		c.transition(StateFailed)
+		return nil, fmt.Errorf("dependency hook failed: %w", err)
 	}
 
+	op.logger.Debug("Starting component "+c.key, c.logFields(op.correlationID)...)
 	startTime := time.Now()
-	result, err := c.instance.Start(ctx)
+	result, err := c.callStart(ctx)
 	elapsedTime := time.Since(startTime)
-	
-	fmt.Printf("Component %s started successfully in %v\n", c.key, elapsedTime)
+
 	if err != nil {
+		op.logger.Error(op.catalog.Message(MsgComponentStartFailed, c.key, elapsedTime), c.logFields(op.correlationID, "error", err)...)
+		c.transition(StateFailed)
 		return nil, fmt.Errorf("failed to start component: %w", err)
 	}
+	op.logger.Info(op.catalog.Message(MsgComponentStarted, c.key, elapsedTime), c.logFields(op.correlationID)...)
 
-	c.result = result
-	c.started = true
+	c.result.Store(&result)
+	if c.oneShot {
+		c.transition(StateCompleted)
+	} else {
+		c.transition(StateStarted)
+	}
 	return result, nil
 }
 
 // Stop shuts down the component
 func (c *Component) Stop(ctx Context) error {
+	return c.stop(ctx, standalone)
+}
+
+// stop shuts down the component as part of a system operation
+func (c *Component) stop(ctx Context, op operation) error {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
-	if !c.started {
+	if !c.IsStarted() {
 		return nil
 	}
 
-	err := c.instance.Stop(ctx)
-	fmt.Printf("Component %s stopped successfully\n", c.key)
-	if err != nil {
+	if err := c.transition(StateStopping); err != nil {
+		return err
+	}
+	if err := c.callStop(ctx, op.reason); err != nil {
+		op.logger.Error(op.catalog.Message(MsgComponentStopFailed, c.key), c.logFields(op.correlationID, "error", err)...)
+		c.transition(StateFailed)
 		return fmt.Errorf("failed to stop component: %w", err)
 	}
 
-	c.started = false
+	op.logger.Info(op.catalog.Message(MsgComponentStopped, c.key), c.logFields(op.correlationID)...)
+	c.result.Store(nil)
+	c.transition(StateStopped)
 	return nil
 }
 
-// IsStarted checks if component is started
+// IsStarted checks if component is started, including while degraded
 func (c *Component) IsStarted() bool {
-	c.mu.Lock()
-	defer c.mu.Unlock()
-	return c.started
+	state := c.State()
+	return state == StateStarted || state == StateDegraded
 }
 
-// GetDependencies returns component dependencies
+// GetDependencies returns component dependencies. They are fixed by Define,
+// so it does not wait for an in-flight Start or Stop of the component
 func (c *Component) GetDependencies() []string {
-	return c.dependencies
+	return append([]string(nil), c.dependencies...)
+}
+
+// Instance returns the Lifecycle the component was defined with
+func (c *Component) Instance() Lifecycle {
+	return c.instance
+}
+
+// Result returns what the component's Start returned, or nil if it has not
+// started successfully or has stopped since. Like State it never blocks on
+// an in-flight Start
+func (c *Component) Result() Lifecycle {
+	if result := c.result.Load(); result != nil {
+		return *result
+	}
+	return nil
 }
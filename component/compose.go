@@ -0,0 +1,150 @@
+package component
+
+import (
+	"fmt"
+	"strings"
+)
+
+// NamespaceSeparator joins a module namespace and a component key
+const NamespaceSeparator = "/"
+
+// ExportFunc is the convention for packages contributing components to a
+// modular monolith: each package exposes `func Export() []*component.Component`
+// declaring its components under local keys
+type ExportFunc func() []*Component
+
+// Module is the set of components exported by one package, composed under
+// a namespace
+type Module struct {
+	Namespace  string
+	Components []*Component
+}
+
+// Namespace binds the components exported by a package to a namespace, e.g.
+// component.Namespace("billing", billing.Export)
+func Namespace(namespace string, export ExportFunc) Module {
+	return Module{Namespace: namespace, Components: export()}
+}
+
+// Compose builds the component map of several modules. Keys are prefixed
+// with the module namespace, as are dependencies and provided keys naming a
+// key of the same module; other dependencies refer to components of other
+// modules by qualified key, e.g. "shared/db". A component still finds its
+// dependencies under their local keys in its Context. Duplicate keys within
+// a module or across modules are reported
+func Compose(modules ...Module) (map[string]*Component, error) {
+	components := make(map[string]*Component)
+	origin := make(map[string]string)
+
+	for _, module := range modules {
+		if strings.HasPrefix(module.Namespace, ReservedPrefix) {
+			return nil, fmt.Errorf("module namespace %s uses the reserved prefix %s", module.Namespace, ReservedPrefix)
+		}
+
+		local := make(map[string]bool)
+		for _, c := range module.Components {
+			if local[c.key] {
+				return nil, fmt.Errorf("module %s exports component %s twice", module.Namespace, c.key)
+			}
+			local[c.key] = true
+			for _, provided := range c.GetProvides() {
+				local[provided] = true
+			}
+		}
+
+		for _, c := range module.Components {
+			namespaced := c.namespaced(module.Namespace, local)
+			if previous, exists := origin[namespaced.key]; exists {
+				return nil, fmt.Errorf("component %s of module %s collides with module %s", namespaced.key, module.Namespace, previous)
+			}
+			origin[namespaced.key] = module.Namespace
+			components[namespaced.key] = namespaced
+		}
+	}
+	return components, nil
+}
+
+// qualify prefixes a key with a namespace
+func qualify(namespace, key string) string {
+	if namespace == "" {
+		return key
+	}
+	return namespace + NamespaceSeparator + key
+}
+
+// namespaced copies a component under its qualified key, rewriting the
+// dependencies and provided keys local to its module
+func (c *Component) namespaced(namespace string, local map[string]bool) *Component {
+	aliases := make(map[string]string)
+	var dependencies []string
+	for _, dep := range c.GetDependencies() {
+		if isLocal(dep, local) {
+			qualified := qualify(namespace, dep)
+			if qualified != dep {
+				aliases[dep] = qualified
+			}
+			dep = qualified
+		}
+		dependencies = append(dependencies, dep)
+	}
+
+	var provides []string
+	for _, provided := range c.GetProvides() {
+		provides = append(provides, qualify(namespace, provided))
+	}
+
+	copied := c.redefine(qualify(namespace, c.key), c.instance)
+	copied.dependencies = dependencies
+	copied.provides = provides
+	copied.aliases = nil
+	if len(aliases) > 0 {
+		copied.aliases = aliases
+	}
+	return copied
+}
+
+// isLocal reports whether a dependency names a key of the module, or for a
+// prefix dependency, matches one
+func isLocal(dep string, local map[string]bool) bool {
+	if !isPattern(dep) {
+		return local[dep]
+	}
+	for key := range local {
+		if matchesPattern(dep, key) {
+			return true
+		}
+	}
+	return false
+}
+
+// qualifiedKey returns the qualified key a composed component reads under a
+// local key, or the key itself
+func qualifiedKey(aliases map[string]string, key string) string {
+	if qualified, ok := aliases[key]; ok {
+		return qualified
+	}
+	for local, qualified := range aliases {
+		if isPattern(local) && matchesPattern(local, key) {
+			return strings.TrimSuffix(qualified, Wildcard) + strings.TrimPrefix(key, strings.TrimSuffix(local, Wildcard))
+		}
+	}
+	return key
+}
+
+// aliasContext adds the dependencies of a composed component under their
+// local keys, including those selected by a local prefix dependency
+func aliasContext(component *Component, deps []string, ctx Context) {
+	for local, qualified := range component.aliases {
+		if !isPattern(local) {
+			ctx[local] = ctx[qualified]
+			continue
+		}
+		localPrefix := strings.TrimSuffix(local, Wildcard)
+		qualifiedPrefix := strings.TrimSuffix(qualified, Wildcard)
+		for _, dep := range deps {
+			if strings.HasPrefix(dep, qualifiedPrefix) {
+				ctx[localPrefix+strings.TrimPrefix(dep, qualifiedPrefix)] = ctx[dep]
+			}
+		}
+	}
+}
@@ -0,0 +1,90 @@
+package component
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+)
+
+// LookupComponent records whether its dependencies were found under local keys
+type LookupComponent struct {
+	MockComponent
+	Wants []string
+	Found []string
+}
+
+func (l *LookupComponent) Start(ctx Context) (Lifecycle, error) {
+	for _, key := range l.Wants {
+		if ctx.Get(key) != nil {
+			l.Found = append(l.Found, key)
+		}
+	}
+	return l.MockComponent.Start(ctx)
+}
+
+func billingExport(api *LookupComponent) ExportFunc {
+	return func() []*Component {
+		return []*Component{
+			Define("db", &MockComponent{}),
+			Define("handlers/invoice", &MockComponent{}),
+			Define("api", api, "db", "handlers/*", "shared/config"),
+		}
+	}
+}
+
+func TestComposeNamespacesModules(t *testing.T) {
+	silenceTestStdout(t)
+
+	api := &LookupComponent{Wants: []string{"db", "handlers/invoice", "shared/config"}}
+	components, err := Compose(
+		Module{Namespace: "shared", Components: []*Component{Define("config", &MockComponent{})}},
+		Namespace("billing", billingExport(api)),
+	)
+	if err != nil {
+		t.Fatalf("Expected compose to succeed, got %v", err)
+	}
+
+	var keys []string
+	for key := range components {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	if !reflect.DeepEqual(keys, []string{"billing/api", "billing/db", "billing/handlers/invoice", "shared/config"}) {
+		t.Errorf("Expected namespaced keys, got %v", keys)
+	}
+	deps := components["billing/api"].GetDependencies()
+	if !reflect.DeepEqual(deps, []string{"billing/db", "billing/handlers/*", "shared/config"}) {
+		t.Errorf("Expected local dependencies qualified, got %v", deps)
+	}
+
+	system := CreateSystem(components, WithRuntimeTracing())
+	if err := system.Start(); err != nil {
+		t.Fatalf("Failed to start composed system: %v", err)
+	}
+	defer system.Stop()
+	if !reflect.DeepEqual(api.Found, api.Wants) {
+		t.Errorf("Expected dependencies under local keys, found %v", api.Found)
+	}
+	if report := system.TraceReport(); len(report.Undeclared) != 0 {
+		t.Errorf("Expected local reads to match qualified declarations, got %v", report)
+	}
+}
+
+func TestComposeReportsCollisions(t *testing.T) {
+	_, err := Compose(
+		Module{Namespace: "billing", Components: []*Component{Define("db", &MockComponent{})}},
+		Module{Components: []*Component{Define("billing/db", &MockComponent{})}},
+	)
+	if err == nil || !strings.Contains(err.Error(), "collides with module billing") {
+		t.Errorf("Expected collision across modules, got %v", err)
+	}
+
+	_, err = Compose(Module{Namespace: "billing", Components: []*Component{
+		Define("db", &MockComponent{}),
+		Define("db", &MockComponent{}),
+	}})
+	if err == nil || !strings.Contains(err.Error(), "exports component db twice") {
+		t.Errorf("Expected duplicate within a module, got %v", err)
+	}
+}
@@ -0,0 +1,127 @@
+package component
+
+import (
+	"fmt"
+	"math/rand"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// CountingComponent tracks how many times it is running, detecting overlapping
+// starts or stops of the same instance
+type CountingComponent struct {
+	running  atomic.Int32
+	overlaps atomic.Int32
+}
+
+func (c *CountingComponent) Start(ctx Context) (Lifecycle, error) {
+	if c.running.Add(1) != 1 {
+		c.overlaps.Add(1)
+	}
+	for key, dep := range ctx {
+		if dep == nil {
+			return nil, fmt.Errorf("dependency %s resolved to nil", key)
+		}
+	}
+	return c, nil
+}
+
+func (c *CountingComponent) Stop(ctx Context) error {
+	if c.running.Add(-1) != 0 {
+		c.overlaps.Add(1)
+	}
+	return nil
+}
+
+// concurrencySystem builds a diamond-shaped graph of counting components
+func concurrencySystem() (*System, []*CountingComponent) {
+	instances := make([]*CountingComponent, 4)
+	for i := range instances {
+		instances[i] = &CountingComponent{}
+	}
+	system := CreateSystem(map[string]*Component{
+		"root":  Define("root", instances[0]),
+		"left":  Define("left", instances[1], "root"),
+		"right": Define("right", instances[2], "root"),
+		"top":   Define("top", instances[3], "left", "right"),
+	})
+	return system, instances
+}
+
+// TestConcurrentLifecycle hammers a system with randomized lifecycle and
+// introspection calls. Run with -race to detect unsynchronized access
+func TestConcurrentLifecycle(t *testing.T) {
+	silenceTestStdout(t)
+	system, instances := concurrencySystem()
+
+	const workers = 8
+	const iterations = 50
+
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(seed int64) {
+			defer wg.Done()
+			rng := rand.New(rand.NewSource(seed))
+			for i := 0; i < iterations; i++ {
+				switch rng.Intn(6) {
+				case 0:
+					system.Start()
+				case 1:
+					system.Stop()
+				case 2:
+					system.Restart()
+				case 3:
+					_ = system.GetContext()
+				case 4:
+					_ = system.IsStarted()
+					for _, c := range system.components {
+						_ = c.State()
+						_ = c.Result()
+						_ = c.GetDependencies()
+					}
+				case 5:
+					time.Sleep(time.Duration(rng.Intn(100)) * time.Microsecond)
+				}
+			}
+		}(int64(w))
+	}
+	wg.Wait()
+
+	if err := system.Stop(); err != nil {
+		t.Fatalf("Failed to stop system: %v", err)
+	}
+	for i, instance := range instances {
+		if instance.overlaps.Load() != 0 {
+			t.Errorf("Instance %d had %d overlapping lifecycle calls", i, instance.overlaps.Load())
+		}
+		if instance.running.Load() != 0 {
+			t.Errorf("Instance %d still running after final stop", i)
+		}
+	}
+}
+
+func TestConcurrentStartIsIdempotent(t *testing.T) {
+	silenceTestStdout(t)
+	system, instances := concurrencySystem()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 16; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := system.Start(); err != nil {
+				t.Errorf("Failed to start system: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	for i, instance := range instances {
+		if instance.running.Load() != 1 {
+			t.Errorf("Instance %d started %d times", i, instance.running.Load())
+		}
+	}
+}
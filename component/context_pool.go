@@ -0,0 +1,52 @@
+package component
+
+import (
+	"sync/atomic"
+)
+
+// ContextStats counts how dependency contexts were obtained across starts
+type ContextStats struct {
+	Allocated uint64
+	Reused    uint64
+}
+
+// ContextStats returns the dependency context allocation counters
+func (s *System) ContextStats() ContextStats {
+	return ContextStats{
+		Allocated: atomic.LoadUint64(&s.ctxStats.Allocated),
+		Reused:    atomic.LoadUint64(&s.ctxStats.Reused),
+	}
+}
+
+// WithContextReuse reuses each component's dependency context map across
+// starts instead of allocating a fresh one, for systems restarted often
+// such as in tests. A Context passed to Start is then only valid until the
+// component starts again, so it suits components that do not keep it
+func WithContextReuse() Option {
+	return func(s *System) {
+		s.reuseContexts = true
+	}
+}
+
+// dependencyContext returns an empty context for the component's next Start,
+// reusing the map from its previous start when the system was created
+// WithContextReuse
+func (s *System) dependencyContext(component *Component, size int) Context {
+	if !s.reuseContexts {
+		atomic.AddUint64(&s.ctxStats.Allocated, 1)
+		return make(Context, size)
+	}
+
+	component.mu.Lock()
+	defer component.mu.Unlock()
+
+	if component.ctx != nil {
+		clear(component.ctx)
+		atomic.AddUint64(&s.ctxStats.Reused, 1)
+		return component.ctx
+	}
+
+	component.ctx = make(Context, size)
+	atomic.AddUint64(&s.ctxStats.Allocated, 1)
+	return component.ctx
+}
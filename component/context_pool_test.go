@@ -0,0 +1,118 @@
+package component
+
+import (
+	"fmt"
+	"os"
+	"testing"
+)
+
+func TestDependencyContextReusedAcrossRestarts(t *testing.T) {
+	system := CreateSystem(map[string]*Component{
+		"compA": Define("compA", &MockComponent{}),
+		"compB": Define("compB", &MockComponent{}, "compA"),
+	}, WithContextReuse())
+
+	for i := 0; i < 3; i++ {
+		if err := system.Start(); err != nil {
+			t.Fatalf("Failed to start system: %v", err)
+		}
+		if err := system.Stop(); err != nil {
+			t.Fatalf("Failed to stop system: %v", err)
+		}
+	}
+
+	stats := system.ContextStats()
+	if stats.Allocated != 2 || stats.Reused != 4 {
+		t.Errorf("Expected 2 allocations and 4 reuses, got %+v", stats)
+	}
+}
+
+// ContextKeeper keeps the Context it was started with
+type ContextKeeper struct {
+	started []Context
+}
+
+func (c *ContextKeeper) Start(ctx Context) (Lifecycle, error) {
+	c.started = append(c.started, ctx)
+	return c, nil
+}
+
+func (c *ContextKeeper) Stop(ctx Context) error {
+	return nil
+}
+
+func TestDependencyContextFreshPerStart(t *testing.T) {
+	silenceTestStdout(t)
+	keeper := &ContextKeeper{}
+	system := CreateSystem(map[string]*Component{
+		"compA":  Define("compA", &MockComponent{}),
+		"keeper": Define("keeper", keeper, "compA"),
+	})
+
+	for i := 0; i < 2; i++ {
+		if err := system.Start(); err != nil {
+			t.Fatalf("Failed to start system: %v", err)
+		}
+		if err := system.Stop(); err != nil {
+			t.Fatalf("Failed to stop system: %v", err)
+		}
+	}
+
+	delete(keeper.started[1], "compA")
+	if _, ok := keeper.started[0]["compA"]; !ok {
+		t.Error("Expected each start to get its own Context")
+	}
+	if stats := system.ContextStats(); stats.Allocated != 4 || stats.Reused != 0 {
+		t.Errorf("Expected 4 allocations and no reuse, got %+v", stats)
+	}
+}
+
+// silenceTestStdout discards lifecycle output for the duration of a test
+func silenceTestStdout(tb testing.TB) {
+	stdout := os.Stdout
+	devNull, err := os.OpenFile(os.DevNull, os.O_WRONLY, 0)
+	if err != nil {
+		tb.Fatal(err)
+	}
+	os.Stdout = devNull
+	tb.Cleanup(func() {
+		os.Stdout = stdout
+		devNull.Close()
+	})
+}
+
+func benchmarkSystem(size int, opts ...Option) *System {
+	components := make(map[string]*Component, size)
+	for i := 0; i < size; i++ {
+		key := fmt.Sprintf("comp%03d", i)
+		var deps []string
+		if i > 0 {
+			deps = append(deps, fmt.Sprintf("comp%03d", i-1))
+		}
+		components[key] = Define(key, &MockComponent{}, deps...)
+	}
+	return CreateSystem(components, opts...)
+}
+
+func BenchmarkStartStopCycle(b *testing.B) {
+	benchmarkStartStopCycle(b, benchmarkSystem(50))
+}
+
+func BenchmarkStartStopCycleReusingContexts(b *testing.B) {
+	benchmarkStartStopCycle(b, benchmarkSystem(50, WithContextReuse()))
+}
+
+func benchmarkStartStopCycle(b *testing.B, system *System) {
+	silenceTestStdout(b)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := system.Start(); err != nil {
+			b.Fatal(err)
+		}
+		if err := system.Stop(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
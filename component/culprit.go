@@ -0,0 +1,109 @@
+package component
+
+import (
+	"strings"
+)
+
+// Suspect is the component most likely responsible for a panic
+type Suspect struct {
+	Component string
+
+	// Frame is the stack frame attributed to the component
+	Frame string
+
+	// ByType is true when the frame belongs to the component's instance type
+	// and false when only its package matched
+	ByType bool
+}
+
+// Culprit maps the frames of a stack trace, as captured by debug.Stack in a
+// recover, to component packages and returns the component that most likely
+// caused the panic: the one owning the innermost non-runtime frame below
+// the panic call
+func (s *System) Culprit(stack []byte) (Suspect, bool) {
+	snapshot := s.snapshot()
+	for _, function := range stackFunctions(string(stack)) {
+		pkg, typeName := splitFunction(function)
+		if pkg == "" || pkg == "runtime" || strings.HasPrefix(pkg, "runtime/") {
+			continue
+		}
+
+		var byPackage *Suspect
+		for _, key := range snapshot.sortedKeys() {
+			component := snapshot.components[key]
+			instancePkg, instanceName := component.instanceType()
+
+			if pkg == instancePkg && typeName != "" && typeName == instanceName {
+				return Suspect{Component: key, Frame: function, ByType: true}, true
+			}
+			if byPackage == nil && ownsPackage(component, instancePkg, pkg) {
+				byPackage = &Suspect{Component: key, Frame: function}
+			}
+		}
+		if byPackage != nil {
+			return *byPackage, true
+		}
+	}
+	return Suspect{}, false
+}
+
+// ownsPackage reports whether a package belongs to a component
+func ownsPackage(component *Component, instancePkg, pkg string) bool {
+	if pkg == instancePkg {
+		return true
+	}
+	for _, owned := range component.GetMetadata().Packages {
+		if pkg == owned || strings.HasPrefix(pkg, owned+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// stackFunctions extracts the function names of a goroutine dump, innermost
+// first. Frames above the panic call, such as the recovering function, are dropped
+func stackFunctions(stack string) []string {
+	var functions []string
+	for _, line := range strings.Split(stack, "\n") {
+		if line == "" || strings.HasPrefix(line, "\t") || strings.HasPrefix(line, "goroutine ") {
+			continue
+		}
+		line = strings.TrimPrefix(line, "created by ")
+		if i := strings.Index(line, " in goroutine "); i >= 0 {
+			line = line[:i]
+		}
+		if i := strings.LastIndex(line, "("); i > 0 && strings.HasSuffix(line, ")") {
+			line = line[:i]
+		}
+		if line == "panic" || line == "runtime.gopanic" {
+			functions = functions[:0]
+			continue
+		}
+		functions = append(functions, line)
+	}
+	return functions
+}
+
+// splitFunction splits a qualified function name such as
+// "github.com/org/app/db.(*Pool).Open" into its package and receiver type
+func splitFunction(function string) (pkg, typeName string) {
+	lastSlash := strings.LastIndex(function, "/")
+	dot := strings.Index(function[lastSlash+1:], ".")
+	if dot < 0 {
+		return "", ""
+	}
+	pkg = function[:lastSlash+1+dot]
+	rest := function[lastSlash+1+dot+1:]
+
+	if strings.HasPrefix(rest, "(*") {
+		if end := strings.Index(rest, ")"); end > 0 {
+			typeName = rest[2:end]
+		}
+	} else if i := strings.Index(rest, "."); i > 0 {
+		typeName = rest[:i]
+	}
+	if i := strings.Index(typeName, "["); i >= 0 {
+		typeName = typeName[:i]
+	}
+	return pkg, typeName
+}
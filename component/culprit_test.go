@@ -0,0 +1,79 @@
+package component
+
+import (
+	"runtime/debug"
+	"strings"
+	"testing"
+)
+
+// PanickingComponent panics while starting
+type PanickingComponent struct{}
+
+func (p *PanickingComponent) Start(ctx Context) (Lifecycle, error) {
+	panic("nil map write")
+}
+
+func (p *PanickingComponent) Stop(ctx Context) error {
+	return nil
+}
+
+func TestCulpritFromCapturedStack(t *testing.T) {
+	system := CreateSystem(map[string]*Component{
+		"healthy": Define("healthy", &MockComponent{}),
+		"broken":  Define("broken", &PanickingComponent{}),
+	})
+
+	var stack []byte
+	func() {
+		defer func() {
+			recover()
+			stack = debug.Stack()
+		}()
+		(&PanickingComponent{}).Start(nil)
+	}()
+
+	suspect, ok := system.Culprit(stack)
+	if !ok {
+		t.Fatalf("Expected a suspect in stack:\n%s", stack)
+	}
+	if suspect.Component != "broken" || !suspect.ByType {
+		t.Errorf("Expected broken identified by type, got %+v", suspect)
+	}
+	if !strings.Contains(suspect.Frame, "PanickingComponent") {
+		t.Errorf("Expected frame of PanickingComponent, got %q", suspect.Frame)
+	}
+}
+
+func TestCulpritByPackageMetadata(t *testing.T) {
+	system := CreateSystem(map[string]*Component{
+		"db": Define("db", &MockComponent{}).WithMetadata(Metadata{Packages: []string{"github.com/acme/app/storage"}}),
+	})
+
+	stack := []byte(`goroutine 7 [running]:
+runtime/debug.Stack()
+	/usr/local/go/src/runtime/debug/stack.go:24 +0x5e
+panic({0x4a1b20?, 0x52c6f0?})
+	/usr/local/go/src/runtime/panic.go:770 +0x132
+github.com/acme/app/storage/pool.(*conn).exec(0xc000010000)
+	/src/storage/pool/conn.go:42 +0x1d
+main.main()
+	/src/main.go:10 +0x25
+`)
+
+	suspect, ok := system.Culprit(stack)
+	if !ok || suspect.Component != "db" || suspect.ByType {
+		t.Fatalf("Expected db identified by package, got %+v (%v)", suspect, ok)
+	}
+	if suspect.Frame != "github.com/acme/app/storage/pool.(*conn).exec" {
+		t.Errorf("Unexpected frame %q", suspect.Frame)
+	}
+}
+
+func TestCulpritUnknown(t *testing.T) {
+	system := CreateSystem(map[string]*Component{
+		"db": Define("db", &MockComponent{}),
+	})
+	if _, ok := system.Culprit([]byte("goroutine 1 [running]:\nmain.main()\n\t/src/main.go:1\n")); ok {
+		t.Error("Expected no suspect for a stack outside component packages")
+	}
+}
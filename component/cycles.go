@@ -0,0 +1,124 @@
+package component
+
+import (
+	"slices"
+	"sort"
+)
+
+// FindCycles returns every cyclic dependency of the graph, one per strongly
+// connected component, as the shortest path closing it from its lowest key,
+// e.g. [a b c a]. A component depending on itself is reported as [a a].
+// Cycles are ordered by their first key; an acyclic graph has none
+func (s *System) FindCycles() [][]string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.providers == nil {
+		s.buildProviders()
+	}
+	return s.findCycles()
+}
+
+// findCycles finds the cycles of the graph with Tarjan's algorithm; the
+// caller must hold s.mu and the providers must be built
+func (s *System) findCycles() [][]string {
+	edges := make(map[string][]string, len(s.components))
+	for name, component := range s.components {
+		edges[name] = s.resolvedDependencies(component)
+	}
+
+	var cycles [][]string
+	for _, scc := range stronglyConnected(s.sortedKeys(), edges) {
+		members := make(map[string]bool, len(scc))
+		for _, key := range scc {
+			members[key] = true
+		}
+		start := scc[0]
+		if len(scc) == 1 && !slices.Contains(edges[start], start) {
+			continue
+		}
+		cycles = append(cycles, shortestCycle(start, edges, members))
+	}
+	sort.Slice(cycles, func(i, j int) bool {
+		return cycles[i][0] < cycles[j][0]
+	})
+	return cycles
+}
+
+// stronglyConnected returns the strongly connected components of the graph,
+// each sorted by key
+func stronglyConnected(keys []string, edges map[string][]string) [][]string {
+	index := make(map[string]int, len(keys))
+	low := make(map[string]int, len(keys))
+	onStack := make(map[string]bool, len(keys))
+	var stack []string
+	var sccs [][]string
+
+	var visit func(key string)
+	visit = func(key string) {
+		index[key] = len(index)
+		low[key] = index[key]
+		stack = append(stack, key)
+		onStack[key] = true
+
+		for _, dep := range edges[key] {
+			if _, visited := index[dep]; !visited {
+				visit(dep)
+				low[key] = min(low[key], low[dep])
+			} else if onStack[dep] {
+				low[key] = min(low[key], index[dep])
+			}
+		}
+
+		if low[key] == index[key] {
+			var scc []string
+			for {
+				top := stack[len(stack)-1]
+				stack = stack[:len(stack)-1]
+				onStack[top] = false
+				scc = append(scc, top)
+				if top == key {
+					break
+				}
+			}
+			sort.Strings(scc)
+			sccs = append(sccs, scc)
+		}
+	}
+
+	for _, key := range keys {
+		if _, visited := index[key]; !visited {
+			visit(key)
+		}
+	}
+	return sccs
+}
+
+// shortestCycle returns the shortest path from start back to itself through
+// the members of its strongly connected component
+func shortestCycle(start string, edges map[string][]string, members map[string]bool) []string {
+	previous := make(map[string]string)
+	queue := []string{start}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		for _, dep := range edges[current] {
+			if dep == start {
+				path := []string{start}
+				for key := current; key != start; key = previous[key] {
+					path = append(path, key)
+				}
+				path = append(path, start)
+				// The path was collected backwards from the closing edge
+				for i, j := 1, len(path)-2; i < j; i, j = i+1, j-1 {
+					path[i], path[j] = path[j], path[i]
+				}
+				return path
+			}
+			if _, seen := previous[dep]; !seen && members[dep] {
+				previous[dep] = current
+				queue = append(queue, dep)
+			}
+		}
+	}
+	return []string{start, start}
+}
@@ -0,0 +1,57 @@
+package component
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestFindCycles(t *testing.T) {
+	system := CreateSystem(map[string]*Component{
+		"a":      Define("a", &MockComponent{}, "b"),
+		"b":      Define("b", &MockComponent{}, "c", "d"),
+		"c":      Define("c", &MockComponent{}, "a"),
+		"d":      Define("d", &MockComponent{}, "b"),
+		"self":   Define("self", &MockComponent{}, "self"),
+		"x":      Define("x", &MockComponent{}, "y"),
+		"y":      Define("y", &MockComponent{}, "x"),
+		"leaf":   Define("leaf", &MockComponent{}),
+		"user":   Define("user", &MockComponent{}, "leaf", "a"),
+		"broken": Define("broken", &MockComponent{}, "missing"),
+	})
+
+	expected := [][]string{
+		{"a", "b", "c", "a"},
+		{"self", "self"},
+		{"x", "y", "x"},
+	}
+	if cycles := system.FindCycles(); !reflect.DeepEqual(cycles, expected) {
+		t.Errorf("Expected cycles %v, got %v", expected, cycles)
+	}
+
+	var cycleErr *CycleError
+	if err := system.Start(); !errors.As(err, &cycleErr) || cycleErr.Error() != "cyclic dependency detected involving component a: a -> b -> c -> a" {
+		t.Errorf("Expected the first cycle in the start error, got %v", err)
+	}
+}
+
+func TestFindCyclesAcyclic(t *testing.T) {
+	system := CreateSystem(map[string]*Component{
+		"db":  Define("db", &MockComponent{}),
+		"api": Define("api", &MockComponent{}, "db"),
+	})
+	if cycles := system.FindCycles(); len(cycles) != 0 {
+		t.Errorf("Expected no cycles, got %v", cycles)
+	}
+}
+
+func TestFindCyclesThroughProvidedKeys(t *testing.T) {
+	system := CreateSystem(map[string]*Component{
+		"db":  Define("db", &MockComponent{}, "api").Provides("db.read"),
+		"api": Define("api", &MockComponent{}, "db.read"),
+	})
+	expected := [][]string{{"api", "db", "api"}}
+	if cycles := system.FindCycles(); !reflect.DeepEqual(cycles, expected) {
+		t.Errorf("Expected cycles %v, got %v", expected, cycles)
+	}
+}
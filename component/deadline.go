@@ -0,0 +1,136 @@
+package component
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// StartDeadlineError reports a Start that did not finish within its deadline
+type StartDeadlineError struct {
+	// InFlight lists the components that were starting when the deadline hit
+	InFlight []string
+	Elapsed  time.Duration
+	Err      error
+}
+
+func (e *StartDeadlineError) Error() string {
+	if len(e.InFlight) == 0 {
+		return fmt.Sprintf("system start exceeded its deadline after %v: %v", e.Elapsed, e.Err)
+	}
+	return fmt.Sprintf("system start exceeded its deadline after %v while starting %s: %v", e.Elapsed, strings.Join(e.InFlight, ", "), e.Err)
+}
+
+func (e *StartDeadlineError) Unwrap() error {
+	return e.Err
+}
+
+// StartWithDeadline starts the system like Start, failing fast when the
+// whole boot takes longer than d
+func (s *System) StartWithDeadline(d time.Duration) error {
+	ctx, cancel := context.WithTimeout(context.Background(), d)
+	defer cancel()
+	return s.StartContext(ctx)
+}
+
+// StartContext starts the system like Start, returning a StartDeadlineError
+// as soon as ctx is done. A component Start cannot be interrupted, so the
+// boot finishes in the background: no further component starts, gates and
+// preflight checks are abandoned, and the components already started are
+// stopped again. Other lifecycle calls wait until that is over
+func (s *System) StartContext(ctx context.Context) error {
+	if ctx.Done() == nil {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.startCtx = ctx
+		defer func() { s.startCtx = nil }()
+		return s.startLocked()
+	}
+
+	startTime := time.Now()
+	done := make(chan error, 1)
+	go func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		s.startCtx = ctx
+		err := s.startLocked()
+		if ctx.Err() != nil {
+			// The caller was told the start failed, so a boot finishing
+			// late must not leave the system started
+			reason := ShutdownReason{Cause: ShutdownAPI, Detail: "start deadline"}
+			if s.started.Load() {
+				s.stopLocked(reason)
+			} else {
+				s.unwindPartialStart(reason)
+			}
+			if err == nil {
+				err = &StartDeadlineError{Elapsed: time.Since(startTime), Err: ctx.Err()}
+			}
+		}
+		s.startCtx = nil
+		done <- err
+	}()
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		select {
+		case err := <-done:
+			return err
+		default:
+		}
+		return &StartDeadlineError{InFlight: s.InFlight(), Elapsed: time.Since(startTime), Err: ctx.Err()}
+	}
+}
+
+// bootContext returns the context bounding the Start in progress; the
+// caller must hold s.mu
+func (s *System) bootContext() context.Context {
+	if s.startCtx != nil {
+		return s.startCtx
+	}
+	return context.Background()
+}
+
+// InFlight returns the keys of the components currently starting, including
+// those waiting on start gates
+func (s *System) InFlight() []string {
+	s.shared.Lock()
+	defer s.shared.Unlock()
+
+	keys := make([]string, 0, len(s.inFlight))
+	for key := range s.inFlight {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// markInFlight records whether a component is starting
+func (s *System) markInFlight(key string, inFlight bool) {
+	s.shared.Lock()
+	defer s.shared.Unlock()
+
+	if s.inFlight == nil {
+		s.inFlight = make(map[string]bool)
+	}
+	if inFlight {
+		s.inFlight[key] = true
+	} else {
+		delete(s.inFlight, key)
+	}
+}
+
+// unwindPartialStart stops, in reverse order, the components a failed Start
+// already started; the caller must hold s.mu
+func (s *System) unwindPartialStart(reason ShutdownReason) {
+	correlationID := newCorrelationID()
+	for i := len(s.startOrder) - 1; i >= 0; i-- {
+		s.stopComponent(s.components[s.startOrder[i]], correlationID, reason)
+	}
+	s.startOrder = s.startOrder[:0]
+}
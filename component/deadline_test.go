@@ -0,0 +1,94 @@
+package component
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// BlockingComponent starts only once Release is closed
+type BlockingComponent struct {
+	MockComponent
+	Release chan struct{}
+}
+
+func (b *BlockingComponent) Start(ctx Context) (Lifecycle, error) {
+	<-b.Release
+	return b.MockComponent.Start(ctx)
+}
+
+func TestStartWithDeadlineReportsInFlightComponent(t *testing.T) {
+	config := &MockComponent{Key: "config"}
+	db := &BlockingComponent{Release: make(chan struct{})}
+	server := &MockComponent{Key: "http_server"}
+	system := CreateSystem(map[string]*Component{
+		"config":      Define("config", config),
+		"db":          Define("db", db, "config"),
+		"http_server": Define("http_server", server, "db"),
+	})
+
+	err := system.StartWithDeadline(20 * time.Millisecond)
+	var deadlineErr *StartDeadlineError
+	if !errors.As(err, &deadlineErr) {
+		t.Fatalf("Expected StartDeadlineError, got %v", err)
+	}
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected error to wrap context.DeadlineExceeded, got %v", err)
+	}
+	if !reflect.DeepEqual(deadlineErr.InFlight, []string{"db"}) {
+		t.Errorf("Expected db in flight, got %v", deadlineErr.InFlight)
+	}
+
+	// Stop waits for the abandoned boot to unwind
+	close(db.Release)
+	if err := system.Stop(); err != nil {
+		t.Fatalf("Expected stop to succeed, got %v", err)
+	}
+	if server.StartCalled {
+		t.Error("Expected no component to start after the deadline")
+	}
+	if !config.StopCalled || !db.StopCalled {
+		t.Error("Expected components started before the deadline to be stopped")
+	}
+	if system.IsStarted() {
+		t.Error("Expected system not to be started")
+	}
+}
+
+func TestStartContextWithinDeadline(t *testing.T) {
+	system := CreateSystem(map[string]*Component{
+		"config": Define("config", &MockComponent{Key: "config"}),
+	})
+
+	if err := system.StartWithDeadline(time.Second); err != nil {
+		t.Fatalf("Expected start to succeed, got %v", err)
+	}
+	if !system.IsStarted() {
+		t.Error("Expected system to be started")
+	}
+	system.Stop()
+}
+
+func TestStartWithDeadlineStopsLateSuccess(t *testing.T) {
+	db := &BlockingComponent{Release: make(chan struct{})}
+	system := CreateSystem(map[string]*Component{
+		"db": Define("db", db),
+	})
+	time.AfterFunc(50*time.Millisecond, func() { close(db.Release) })
+
+	if err := system.StartWithDeadline(10 * time.Millisecond); !errors.As(err, new(*StartDeadlineError)) {
+		t.Fatalf("Expected StartDeadlineError, got %v", err)
+	}
+
+	// EffectiveOrder waits for the boot finishing in the background
+	system.EffectiveOrder()
+	if system.IsStarted() || !db.StopCalled {
+		t.Errorf("Expected the late start to be undone, got started %v and db stopped %v", system.IsStarted(), db.StopCalled)
+	}
+	if err := system.Start(); err != nil || !system.IsStarted() {
+		t.Errorf("Expected a later Start to succeed, got %v", err)
+	}
+	system.Stop()
+}
@@ -0,0 +1,173 @@
+package component
+
+import (
+	"fmt"
+	"sync"
+)
+
+const (
+	EventComponentDegraded  EventType = "component_degraded"
+	EventComponentRecovered EventType = "component_recovered"
+)
+
+// DependencyChange notifies a component that one of its direct
+// dependencies changed state
+type DependencyChange struct {
+	// Component is the dependent being notified
+	Component string
+
+	// Dependency is the key of the dependency that changed
+	Dependency string
+
+	State State
+	Err   error
+}
+
+// DependencyWatcher is an optional interface for components that want to
+// shed load or switch to fallbacks when a dependency degrades or stops.
+// It is called synchronously during lifecycle operations and must not block
+type DependencyWatcher interface {
+	DependencyChanged(change DependencyChange)
+}
+
+// dependencySubscription is a callback registered with SubscribeDependencies
+type dependencySubscription struct {
+	fn func(DependencyChange)
+}
+
+// dependencySubscribers holds the callbacks registered per dependent key
+type dependencySubscribers struct {
+	byKey map[string][]*dependencySubscription
+	mu    sync.Mutex
+}
+
+// Degrade marks a started component as degraded, notifying its dependents
+func (s *System) Degrade(key string, reason error) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	component, exists := s.components[key]
+	if !exists {
+		return fmt.Errorf("component %s not found", key)
+	}
+	if component.State() != StateStarted {
+		return fmt.Errorf("component %s is %s, not started", key, component.State())
+	}
+
+	if err := component.transition(StateDegraded); err != nil {
+		return err
+	}
+	component.setLastErr(reason)
+	event := componentEvent(EventComponentDegraded, component, "")
+	event.Err = reason
+	s.emit(event)
+	return nil
+}
+
+// Recover returns a degraded component to the started state, notifying its dependents
+func (s *System) Recover(key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	component, exists := s.components[key]
+	if !exists {
+		return fmt.Errorf("component %s not found", key)
+	}
+	if component.State() != StateDegraded {
+		return nil
+	}
+
+	if err := component.transition(StateStarted); err != nil {
+		return err
+	}
+	component.setLastErr(nil)
+	s.emit(componentEvent(EventComponentRecovered, component, ""))
+	return nil
+}
+
+// SubscribeDependencies registers fn to be called whenever a direct
+// dependency of the component changes state. The returned function cancels
+// the subscription. fn is called synchronously and must not block
+func (s *System) SubscribeDependencies(key string, fn func(DependencyChange)) (unsubscribe func()) {
+	subscription := &dependencySubscription{fn: fn}
+
+	s.subscribers.mu.Lock()
+	if s.subscribers.byKey == nil {
+		s.subscribers.byKey = make(map[string][]*dependencySubscription)
+	}
+	s.subscribers.byKey[key] = append(s.subscribers.byKey[key], subscription)
+	s.subscribers.mu.Unlock()
+
+	return func() {
+		s.subscribers.mu.Lock()
+		defer s.subscribers.mu.Unlock()
+		subscriptions := s.subscribers.byKey[key]
+		for i, sub := range subscriptions {
+			if sub == subscription {
+				s.subscribers.byKey[key] = append(subscriptions[:i], subscriptions[i+1:]...)
+				break
+			}
+		}
+	}
+}
+
+// dependencyState returns the state a component event moves its component to
+// that dependents should be told about
+func dependencyState(event Event) (State, bool) {
+	switch event.Type {
+	case EventComponentStarted, EventComponentRecovered:
+		return StateStarted, true
+	case EventComponentCompleted:
+		return StateCompleted, true
+	case EventComponentDegraded:
+		return StateDegraded, true
+	case EventComponentFailed:
+		return StateFailed, true
+	case EventComponentStopping:
+		return StateStopping, true
+	case EventComponentStopped:
+		if event.Err != nil {
+			return StateFailed, true
+		}
+		return StateStopped, true
+	default:
+		return 0, false
+	}
+}
+
+// notifyDependents delivers a component state change to its direct dependents
+func (s *System) notifyDependents(event Event) {
+	state, ok := dependencyState(event)
+	if !ok || event.Component == "" || s.providers == nil {
+		return
+	}
+
+	for _, name := range s.sortedKeys() {
+		dependent := s.components[name]
+		if !s.dependsOn(dependent, event.Component) {
+			continue
+		}
+
+		change := DependencyChange{Component: name, Dependency: event.Component, State: state, Err: event.Err}
+		if watcher, ok := dependent.instance.(DependencyWatcher); ok && dependent.IsStarted() {
+			watcher.DependencyChanged(change)
+		}
+
+		s.subscribers.mu.Lock()
+		subscriptions := append([]*dependencySubscription(nil), s.subscribers.byKey[name]...)
+		s.subscribers.mu.Unlock()
+		for _, sub := range subscriptions {
+			sub.fn(change)
+		}
+	}
+}
+
+// dependsOn reports whether a component directly depends on the provider key
+func (s *System) dependsOn(component *Component, provider string) bool {
+	for _, dep := range s.dependencyKeys(component) {
+		if resolved, ok := s.resolve(dep); ok && resolved == provider {
+			return true
+		}
+	}
+	return false
+}
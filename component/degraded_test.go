@@ -0,0 +1,101 @@
+package component
+
+import (
+	"errors"
+	"testing"
+)
+
+// SheddingComponent records the dependency changes it was notified of
+type SheddingComponent struct {
+	MockComponent
+	Changes []DependencyChange
+}
+
+func (s *SheddingComponent) DependencyChanged(change DependencyChange) {
+	s.Changes = append(s.Changes, change)
+}
+
+func TestDependencyWatcherNotified(t *testing.T) {
+	api := &SheddingComponent{}
+	system := CreateSystem(map[string]*Component{
+		"db":  Define("db", &MockComponent{}),
+		"api": Define("api", api, "db"),
+	})
+	if err := system.Start(); err != nil {
+		t.Fatalf("Failed to start system: %v", err)
+	}
+
+	if err := system.Degrade("db", errors.New("replica lag")); err != nil {
+		t.Fatalf("Failed to degrade: %v", err)
+	}
+	if err := system.Recover("db"); err != nil {
+		t.Fatalf("Failed to recover: %v", err)
+	}
+
+	if len(api.Changes) != 2 {
+		t.Fatalf("Expected 2 changes, got %v", api.Changes)
+	}
+	if api.Changes[0].Dependency != "db" || api.Changes[0].State != StateDegraded || api.Changes[0].Err == nil {
+		t.Errorf("Expected db degraded with reason, got %+v", api.Changes[0])
+	}
+	if api.Changes[1].State != StateStarted {
+		t.Errorf("Expected db recovered, got %+v", api.Changes[1])
+	}
+}
+
+func TestSubscribeDependencies(t *testing.T) {
+	system := CreateSystem(map[string]*Component{
+		"db":     Define("db", &MockComponent{}),
+		"api":    Define("api", &MockComponent{}, "db"),
+		"worker": Define("worker", &MockComponent{}),
+	})
+
+	var apiChanges, workerChanges []DependencyChange
+	unsubscribe := system.SubscribeDependencies("api", func(c DependencyChange) {
+		apiChanges = append(apiChanges, c)
+	})
+	system.SubscribeDependencies("worker", func(c DependencyChange) {
+		workerChanges = append(workerChanges, c)
+	})
+
+	if err := system.Start(); err != nil {
+		t.Fatalf("Failed to start system: %v", err)
+	}
+	if err := system.Stop(); err != nil {
+		t.Fatalf("Failed to stop system: %v", err)
+	}
+
+	// db started, then stopping and stopped once api is down
+	var states []State
+	for _, c := range apiChanges {
+		states = append(states, c.State)
+	}
+	if len(states) != 3 || states[0] != StateStarted || states[1] != StateStopping || states[2] != StateStopped {
+		t.Errorf("Expected started, stopping, stopped, got %v", states)
+	}
+	if len(workerChanges) != 0 {
+		t.Errorf("Expected no changes for a component without dependencies, got %v", workerChanges)
+	}
+
+	unsubscribe()
+	system.Start()
+	if len(apiChanges) != 3 {
+		t.Errorf("Expected no changes after unsubscribing, got %d", len(apiChanges))
+	}
+}
+
+func TestDegradedComponentStillStops(t *testing.T) {
+	db := &MockComponent{}
+	system := CreateSystem(map[string]*Component{
+		"db": Define("db", db),
+	})
+	system.Start()
+	system.Degrade("db", errors.New("slow"))
+
+	if err := system.Stop(); err != nil {
+		t.Fatalf("Failed to stop system: %v", err)
+	}
+	if !db.StopCalled {
+		t.Error("Expected degraded component to be stopped")
+	}
+}
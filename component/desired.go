@@ -0,0 +1,215 @@
+package component
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"sync"
+)
+
+const EventStateDrift EventType = "state_drift"
+
+// DesiredComponent is the intended definition and state of one component
+type DesiredComponent struct {
+	Dependencies []string `json:"dependencies,omitempty"`
+	State        State    `json:"state"`
+}
+
+// DesiredTopology is the intended set of components keyed by component key
+type DesiredTopology map[string]DesiredComponent
+
+// DesiredStateStore persists the desired topology between runs so a new
+// process can tell whether it reached the state the previous one intended
+type DesiredStateStore interface {
+	SaveDesired(topology DesiredTopology) error
+
+	// LoadDesired returns the saved topology, if any
+	LoadDesired() (DesiredTopology, bool, error)
+}
+
+// Drift reasons
+const (
+	DriftMissing      = "missing"
+	DriftUnexpected   = "unexpected"
+	DriftState        = "state"
+	DriftDependencies = "dependencies"
+)
+
+// Drift is a difference between the desired and the actual state of a component
+type Drift struct {
+	Component string
+	Reason    string
+	Desired   DesiredComponent
+	Actual    DesiredComponent
+}
+
+func (d Drift) String() string {
+	switch d.Reason {
+	case DriftMissing:
+		return fmt.Sprintf("%s: desired but not defined", d.Component)
+	case DriftUnexpected:
+		return fmt.Sprintf("%s: defined but not desired", d.Component)
+	case DriftDependencies:
+		return fmt.Sprintf("%s: desired dependencies %v, actual %v", d.Component, d.Desired.Dependencies, d.Actual.Dependencies)
+	default:
+		return fmt.Sprintf("%s: desired %s, actual %s", d.Component, d.Desired.State, d.Actual.State)
+	}
+}
+
+// WithDesiredState reconciles every Start against the topology saved in the
+// store: differences are emitted as state_drift events and reported by
+// Drift, then the topology the system now intends is saved for the next run
+func WithDesiredState(store DesiredStateStore) Option {
+	return func(s *System) {
+		s.desiredStore = store
+	}
+}
+
+// Drift returns the differences found by the last reconciliation
+func (s *System) Drift() []Drift {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Drift(nil), s.drift...)
+}
+
+// desiredTopology describes what the current definitions intend: every
+// component running, one-shots completed, disabled components stopped; the
+// caller must hold s.mu
+func (s *System) desiredTopology() DesiredTopology {
+	topology := make(DesiredTopology, len(s.components))
+	for key, component := range s.components {
+		desired := DesiredComponent{Dependencies: component.GetDependencies(), State: StateStarted}
+		if component.IsOneShot() {
+			desired.State = StateCompleted
+		}
+		if s.skipped[key] {
+			desired.State = StateStopped
+		}
+		topology[key] = desired
+	}
+	return topology
+}
+
+// reconcile compares the saved desired topology with the actual state and
+// saves the current intent; the caller must hold s.mu
+func (s *System) reconcile(correlationID string) error {
+	if s.desiredStore == nil {
+		return nil
+	}
+
+	saved, found, err := s.desiredStore.LoadDesired()
+	if err != nil {
+		return fmt.Errorf("failed to load desired state: %w", err)
+	}
+	current := s.desiredTopology()
+	if !found {
+		saved = current
+	}
+
+	s.drift = s.drift[:0]
+	for _, key := range s.sortedKeys() {
+		component := s.components[key]
+		actual := DesiredComponent{Dependencies: component.GetDependencies(), State: component.State()}
+		if actual.State == StateDegraded {
+			actual.State = StateStarted
+		}
+
+		desired, exists := saved[key]
+		switch {
+		case !exists:
+			s.drift = append(s.drift, Drift{Component: key, Reason: DriftUnexpected, Actual: actual})
+		case desired.State != actual.State:
+			s.drift = append(s.drift, Drift{Component: key, Reason: DriftState, Desired: desired, Actual: actual})
+		case !slices.Equal(desired.Dependencies, actual.Dependencies):
+			s.drift = append(s.drift, Drift{Component: key, Reason: DriftDependencies, Desired: desired, Actual: actual})
+		}
+	}
+	var missing []string
+	for key := range saved {
+		if _, exists := s.components[key]; !exists {
+			missing = append(missing, key)
+		}
+	}
+	slices.Sort(missing)
+	for _, key := range missing {
+		s.drift = append(s.drift, Drift{Component: key, Reason: DriftMissing, Desired: saved[key]})
+	}
+
+	for _, drift := range s.drift {
+		event := Event{Type: EventStateDrift, Component: drift.Component, ComponentID: componentID(drift.Component), CorrelationID: correlationID}
+		event.Err = fmt.Errorf("%s", drift)
+		s.emit(event)
+	}
+
+	return s.saveDesired(current)
+}
+
+// saveDesired persists the intended topology; the caller must hold s.mu
+func (s *System) saveDesired(topology DesiredTopology) error {
+	if s.desiredStore == nil {
+		return nil
+	}
+	if err := s.desiredStore.SaveDesired(topology); err != nil {
+		return fmt.Errorf("failed to save desired state: %w", err)
+	}
+	return nil
+}
+
+// MemoryDesiredStore keeps the desired topology in memory
+type MemoryDesiredStore struct {
+	topology DesiredTopology
+	mu       sync.Mutex
+}
+
+// NewMemoryDesiredStore creates an empty in-memory desired state store
+func NewMemoryDesiredStore() *MemoryDesiredStore {
+	return &MemoryDesiredStore{}
+}
+
+func (m *MemoryDesiredStore) SaveDesired(topology DesiredTopology) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.topology = topology
+	return nil
+}
+
+func (m *MemoryDesiredStore) LoadDesired() (DesiredTopology, bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.topology, m.topology != nil, nil
+}
+
+// FileDesiredStore keeps the desired topology as a JSON file
+type FileDesiredStore struct {
+	path string
+}
+
+// NewFileDesiredStore creates a desired state store backed by the file at path
+func NewFileDesiredStore(path string) *FileDesiredStore {
+	return &FileDesiredStore{path: path}
+}
+
+func (f *FileDesiredStore) SaveDesired(topology DesiredTopology) error {
+	data, err := json.MarshalIndent(topology, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(f.path, data, 0o600)
+}
+
+func (f *FileDesiredStore) LoadDesired() (DesiredTopology, bool, error) {
+	data, err := os.ReadFile(f.path)
+	if os.IsNotExist(err) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+
+	var topology DesiredTopology
+	if err := json.Unmarshal(data, &topology); err != nil {
+		return nil, false, fmt.Errorf("invalid desired state file %s: %w", f.path, err)
+	}
+	return topology, true, nil
+}
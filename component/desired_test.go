@@ -0,0 +1,62 @@
+package component
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+)
+
+func TestDesiredStateDrift(t *testing.T) {
+	silenceTestStdout(t)
+
+	store := NewFileDesiredStore(filepath.Join(t.TempDir(), "desired.json"))
+	first := CreateSystem(map[string]*Component{
+		"db":         Define("db", &MockComponent{}),
+		"cache":      Define("cache", &MockComponent{}),
+		"migrations": DefineOneShot("migrations", Task(func(ctx Context) error { return nil }), "db"),
+	}, WithDesiredState(store))
+	if err := first.Start(); err != nil {
+		t.Fatalf("Failed to start system: %v", err)
+	}
+	if drift := first.Drift(); len(drift) != 0 {
+		t.Fatalf("Expected no drift on the first boot, got %v", drift)
+	}
+	first.Stop()
+
+	// The next process lost cache, failed to start db and added search
+	var events []Event
+	second := CreateSystem(map[string]*Component{
+		"db":         Define("db", &MockComponent{StartError: errors.New("disk full")}),
+		"migrations": DefineOneShot("migrations", Task(func(ctx Context) error { return nil }), "db"),
+		"search":     Define("search", &MockComponent{}),
+	}, WithDesiredState(store), WithEventListener(func(e Event) {
+		if e.Type == EventStateDrift {
+			events = append(events, e)
+		}
+	}))
+	if err := second.Start(); err == nil {
+		t.Fatal("Expected db to fail")
+	}
+
+	got := make(map[string]string)
+	for _, drift := range second.Drift() {
+		got[drift.Component] = drift.Reason
+	}
+	want := map[string]string{"db": DriftState, "migrations": DriftState, "search": DriftUnexpected, "cache": DriftMissing}
+	if len(got) != len(want) {
+		t.Fatalf("Expected drift %v, got %v", want, got)
+	}
+	for key, reason := range want {
+		if got[key] != reason {
+			t.Errorf("Expected %s drift for %s, got %q", reason, key, got[key])
+		}
+	}
+	if len(events) != len(want) {
+		t.Errorf("Expected one drift event per difference, got %d", len(events))
+	}
+
+	saved, _, _ := store.LoadDesired()
+	if _, exists := saved["search"]; !exists || saved["migrations"].State != StateCompleted {
+		t.Errorf("Expected the current intent to be saved, got %+v", saved)
+	}
+}
@@ -0,0 +1,83 @@
+package component
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// DefaultDOTStyles highlights components that are not running
+var DefaultDOTStyles = map[State]string{
+	StateFailed:      `style=filled, fillcolor="#f8d7da", color="#c0392b"`,
+	StateQuarantined: `style=filled, fillcolor="#f8d7da", color="#c0392b", peripheries=2`,
+	StateStopped:     `style="filled,dashed", fillcolor="#eeeeee", color="#888888"`,
+	StateDegraded:    `style=filled, fillcolor="#fff3cd", color="#d4a017"`,
+	StateStarting:    `style=dashed`,
+	StateStopping:    `style=dashed`,
+}
+
+// DOTOption customizes ExportDOT
+type DOTOption func(*dotConfig)
+
+type dotConfig struct {
+	styles map[State]string
+}
+
+// WithDOTStyles adds Graphviz node attributes for components in the given
+// states, e.g. WithDOTStyles(DefaultDOTStyles)
+func WithDOTStyles(styles map[State]string) DOTOption {
+	return func(c *dotConfig) {
+		c.styles = styles
+	}
+}
+
+// ExportDOT renders the component graph in Graphviz DOT format, one node per
+// component labelled with its state and one edge from each component to
+// every dependency. It never waits for an in-flight lifecycle operation
+func (s *System) ExportDOT(w io.Writer, opts ...DOTOption) error {
+	var config dotConfig
+	for _, opt := range opts {
+		opt(&config)
+	}
+	return DOTEncoder{Styles: config.styles}.Encode(w, s.snapshot().topology())
+}
+
+// DOTEncoder writes graph documents in Graphviz DOT format, adding Styles
+// to the nodes of components in the given states
+type DOTEncoder struct {
+	Styles map[State]string
+}
+
+func (e DOTEncoder) Encode(w io.Writer, document interface{}) error {
+	nodes, edges, err := diagramOf(document)
+	if err != nil {
+		return err
+	}
+
+	b := bufio.NewWriter(w)
+	fmt.Fprintln(b, "digraph components {")
+	fmt.Fprintln(b, "  rankdir=BT;")
+	fmt.Fprintln(b, "  node [shape=box];")
+	for _, node := range nodes {
+		attributes := "label=" + dotQuote(node.key+"\n"+node.state.String())
+		if style, ok := e.Styles[node.state]; ok {
+			attributes += ", " + style
+		}
+		fmt.Fprintf(b, "  %s [%s];\n", dotQuote(node.key), attributes)
+	}
+	for _, edge := range edges {
+		fmt.Fprintf(b, "  %s -> %s;\n", dotQuote(edge.From), dotQuote(edge.To))
+	}
+	fmt.Fprintln(b, "}")
+	return b.Flush()
+}
+
+func (DOTEncoder) ContentType() string {
+	return "text/vnd.graphviz; charset=utf-8"
+}
+
+// dotQuote quotes a DOT identifier or label
+func dotQuote(text string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(text) + `"`
+}
@@ -0,0 +1,43 @@
+package component
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestExportDOT(t *testing.T) {
+	silenceTestStdout(t)
+
+	system := CreateSystem(map[string]*Component{
+		"db":     Define("db", &MockComponent{}),
+		"api":    Define("api", &MockComponent{}, "db"),
+		"worker": Define("worker", &MockComponent{StartError: errors.New("boom")}, "db"),
+	})
+	system.Start()
+
+	var plain strings.Builder
+	if err := system.ExportDOT(&plain); err != nil {
+		t.Fatalf("Expected export to succeed, got %v", err)
+	}
+	for _, line := range []string{
+		`digraph components {`,
+		`  "api" [label="api\nstarted"];`,
+		`  "db" [label="db\nstarted"];`,
+		`  "api" -> "db";`,
+		`  "worker" -> "db";`,
+	} {
+		if !strings.Contains(plain.String(), line+"\n") {
+			t.Errorf("Expected line %q in:\n%s", line, plain.String())
+		}
+	}
+	if strings.Contains(plain.String(), "fillcolor") {
+		t.Error("Expected no styling without options")
+	}
+
+	var styled strings.Builder
+	system.ExportDOT(&styled, WithDOTStyles(DefaultDOTStyles))
+	if !strings.Contains(styled.String(), `"worker" [label="worker\nfailed", `+DefaultDOTStyles[StateFailed]+`];`) {
+		t.Errorf("Expected the failed worker styled, got:\n%s", styled.String())
+	}
+}
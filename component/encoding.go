@@ -0,0 +1,174 @@
+package component
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Encoder writes an exported document, such as a GraphExport, Topology or
+// HealthReport, in one serialization format
+type Encoder interface {
+	Encode(w io.Writer, document interface{}) error
+
+	// ContentType is the media type of the encoded document
+	ContentType() string
+}
+
+// ErrUnsupportedDocument is returned by encoders that cannot represent a
+// document, e.g. the DOT encoder given a TraceReport
+var ErrUnsupportedDocument = errors.New("document not supported by encoder")
+
+// Built-in encoder formats
+const (
+	FormatJSON     = "json"
+	FormatYAML     = "yaml"
+	FormatDOT      = "dot"
+	FormatMermaid  = "mermaid"
+	FormatProtobuf = "protobuf"
+)
+
+var (
+	encodersMu sync.RWMutex
+	encoders   = map[string]Encoder{
+		FormatJSON:        JSONEncoder{Indent: "  "},
+		FormatYAML:        YAMLEncoder{},
+		FormatDOT:         DOTEncoder{},
+		FormatMermaid:     MermaidEncoder{},
+		FormatProtobuf:    ProtobufEncoder{},
+		FormatChromeTrace: ChromeTraceEncoder{},
+	}
+)
+
+// RegisterEncoder makes an encoder available under a format name, replacing
+// any encoder registered under it, so custom formats can be selected like
+// the built-in ones
+func RegisterEncoder(format string, encoder Encoder) {
+	encodersMu.Lock()
+	defer encodersMu.Unlock()
+	encoders[format] = encoder
+}
+
+// LookupEncoder returns the encoder registered under a format name
+func LookupEncoder(format string) (Encoder, bool) {
+	encodersMu.RLock()
+	defer encodersMu.RUnlock()
+	encoder, ok := encoders[format]
+	return encoder, ok
+}
+
+// EncoderFormats returns the registered format names in sorted order
+func EncoderFormats() []string {
+	encodersMu.RLock()
+	defer encodersMu.RUnlock()
+	formats := make([]string, 0, len(encoders))
+	for format := range encoders {
+		formats = append(formats, format)
+	}
+	sort.Strings(formats)
+	return formats
+}
+
+// ExportGraph writes the GraphExport of the system with an encoder. It never
+// waits for an in-flight lifecycle operation
+func (s *System) ExportGraph(w io.Writer, encoder Encoder) error {
+	return encoder.Encode(w, s.GraphExport())
+}
+
+// ExportHealth runs the health checks and writes the HealthReport with an encoder
+func (s *System) ExportHealth(w io.Writer, encoder Encoder) error {
+	return encoder.Encode(w, s.Health())
+}
+
+// JSONEncoder writes documents as JSON, indented with Indent when set
+type JSONEncoder struct {
+	Indent string
+}
+
+func (e JSONEncoder) Encode(w io.Writer, document interface{}) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", e.Indent)
+	return encoder.Encode(document)
+}
+
+func (JSONEncoder) ContentType() string {
+	return "application/json"
+}
+
+// graphNode is a node of a document rendered as a diagram
+type graphNode struct {
+	key   string
+	state State
+}
+
+// diagramOf extracts the nodes and dependency edges of the documents that
+// can be rendered as a diagram
+func diagramOf(document interface{}) ([]graphNode, []GraphEdge, error) {
+	var nodes []graphNode
+	var edges []GraphEdge
+	switch document := document.(type) {
+	case GraphExport:
+		for _, c := range document.Components {
+			nodes = append(nodes, graphNode{key: c.Key, state: c.State})
+		}
+		edges = document.Edges
+	case Topology:
+		for _, node := range document.Nodes {
+			nodes = append(nodes, graphNode{key: node.Key, state: node.State})
+		}
+		for _, edge := range document.Edges {
+			edges = append(edges, GraphEdge{From: edge.From, To: edge.To})
+		}
+	case HealthReport:
+		for _, health := range document.Components {
+			nodes = append(nodes, graphNode{key: health.Key, state: health.State})
+			for _, dep := range health.Dependencies {
+				edges = append(edges, GraphEdge{From: health.Key, To: dep})
+			}
+		}
+	default:
+		return nil, nil, fmt.Errorf("%w: %T", ErrUnsupportedDocument, document)
+	}
+	return nodes, edges, nil
+}
+
+// MermaidEncoder writes graph documents as a Mermaid flowchart, one node
+// per component labelled with its state and one edge to every dependency
+type MermaidEncoder struct{}
+
+func (MermaidEncoder) Encode(w io.Writer, document interface{}) error {
+	nodes, edges, err := diagramOf(document)
+	if err != nil {
+		return err
+	}
+
+	ids := make(map[string]string, len(nodes))
+	b := bufio.NewWriter(w)
+	fmt.Fprintln(b, "flowchart BT")
+	for i, node := range nodes {
+		ids[node.key] = fmt.Sprintf("n%d", i)
+		fmt.Fprintf(b, "  %s[\"%s<br/>%s\"]\n", ids[node.key], mermaidEscape(node.key), node.state)
+	}
+	for _, edge := range edges {
+		from, to := ids[edge.From], ids[edge.To]
+		if from == "" || to == "" {
+			continue
+		}
+		fmt.Fprintf(b, "  %s --> %s\n", from, to)
+	}
+	return b.Flush()
+}
+
+func (MermaidEncoder) ContentType() string {
+	return "text/vnd.mermaid; charset=utf-8"
+}
+
+// mermaidEscape escapes text for a quoted Mermaid label
+func mermaidEscape(text string) string {
+	return strings.NewReplacer(`"`, "#quot;", "<", "#lt;", ">", "#gt;").Replace(text)
+}
@@ -0,0 +1,263 @@
+package component
+
+import (
+	"fmt"
+	"io"
+)
+
+// ProtoSchema is the protobuf schema of the documents written by
+// ProtobufEncoder, so processes in other languages can read the graph,
+// health and events of a system. It is also published as
+// proto/dependencygraph/v1/system.proto. Field numbers are never reused
+const ProtoSchema = `syntax = "proto3";
+
+package dependencygraph.v1;
+
+// Graph is a GraphExport: the components with their states and edges
+message Graph {
+  int32 schema_version = 1;
+  bool started = 2;
+  repeated Component components = 3;
+  repeated Edge edges = 4;
+}
+
+message Component {
+  string key = 1;
+  string id = 2;
+  string state = 3;
+  repeated string dependencies = 4;
+  repeated string tags = 5;
+  bool one_shot = 6;
+  string description = 7;
+  string owner = 8;
+  int64 start_duration_ns = 9;
+  int64 stop_duration_ns = 10;
+  string error = 11;
+}
+
+message Edge {
+  string from = 1;
+  string to = 2;
+}
+
+// HealthReport is the health of every component, ordered like the graph
+message HealthReport {
+  string status = 1;
+  repeated ComponentHealth components = 2;
+}
+
+message ComponentHealth {
+  string key = 1;
+  string status = 2;
+  string state = 3;
+  string error = 4;
+  bool checked = 5;
+  repeated string dependencies = 6;
+  string cause = 7;
+  string reason = 8;
+}
+
+// Event is a lifecycle event. Streams of events are written as
+// varint length-delimited Event messages
+message Event {
+  string type = 1;
+  string component = 2;
+  string component_id = 3;
+  string correlation_id = 4;
+  int64 time_unix_nano = 5;
+  int64 duration_ns = 6;
+  string error = 7;
+  ShutdownReason reason = 8;
+  string hook = 9;
+  string dependency = 10;
+  string stack = 11;
+  int32 attempt = 12;
+}
+
+message ShutdownReason {
+  string cause = 1;
+  string detail = 2;
+  string error = 3;
+}
+
+message EventBatch {
+  repeated Event events = 1;
+}
+`
+
+// ProtobufEncoder writes documents as binary messages of ProtoSchema: a
+// GraphExport as Graph, a HealthReport as HealthReport, an Event as Event
+// and a []Event as EventBatch
+type ProtobufEncoder struct{}
+
+func (ProtobufEncoder) Encode(w io.Writer, document interface{}) error {
+	var m protoMessage
+	switch document := document.(type) {
+	case GraphExport:
+		m = protoGraph(document)
+	case HealthReport:
+		m = protoHealth(document)
+	case Event:
+		m = protoEvent(document)
+	case []Event:
+		for _, event := range document {
+			m.message(1, protoEvent(event))
+		}
+	default:
+		return fmt.Errorf("%w: %T", ErrUnsupportedDocument, document)
+	}
+	_, err := w.Write(m)
+	return err
+}
+
+func (ProtobufEncoder) ContentType() string {
+	return "application/x-protobuf"
+}
+
+// NewProtobufEventListener returns a listener writing every event to w as
+// a varint length-delimited Event message, e.g. to a file or pipe read by
+// a sidecar. Write errors are dropped, as listeners cannot fail
+func NewProtobufEventListener(w io.Writer) EventListener {
+	return func(event Event) {
+		var m protoMessage
+		encoded := protoEvent(event)
+		m.rawVarint(uint64(len(encoded)))
+		w.Write(append(m, encoded...))
+	}
+}
+
+func protoGraph(graph GraphExport) protoMessage {
+	var m protoMessage
+	m.varint(1, uint64(graph.SchemaVersion))
+	m.bool(2, graph.Started)
+	for _, c := range graph.Components {
+		var component protoMessage
+		component.string(1, c.Key)
+		component.string(2, c.ID)
+		component.string(3, c.State.String())
+		for _, dep := range c.Dependencies {
+			component.repeatedString(4, dep)
+		}
+		for _, tag := range c.Tags {
+			component.repeatedString(5, tag)
+		}
+		component.bool(6, c.OneShot)
+		component.string(7, c.Description)
+		component.string(8, c.Owner)
+		component.varint(9, uint64(c.StartDuration))
+		component.varint(10, uint64(c.StopDuration))
+		component.string(11, c.Error)
+		m.message(3, component)
+	}
+	for _, e := range graph.Edges {
+		var edge protoMessage
+		edge.string(1, e.From)
+		edge.string(2, e.To)
+		m.message(4, edge)
+	}
+	return m
+}
+
+func protoHealth(report HealthReport) protoMessage {
+	var m protoMessage
+	m.string(1, string(report.Status))
+	for _, health := range report.Components {
+		var component protoMessage
+		component.string(1, health.Key)
+		component.string(2, string(health.Status))
+		component.string(3, health.State.String())
+		component.string(4, health.Error)
+		component.bool(5, health.Checked)
+		for _, dep := range health.Dependencies {
+			component.repeatedString(6, dep)
+		}
+		component.string(7, health.Cause)
+		component.string(8, health.Reason)
+		m.message(2, component)
+	}
+	return m
+}
+
+func protoEvent(event Event) protoMessage {
+	var m protoMessage
+	m.string(1, string(event.Type))
+	m.string(2, event.Component)
+	m.string(3, event.ComponentID)
+	m.string(4, event.CorrelationID)
+	if !event.Time.IsZero() {
+		m.varint(5, uint64(event.Time.UnixNano()))
+	}
+	m.varint(6, uint64(event.Duration))
+	if event.Err != nil {
+		m.string(7, event.Err.Error())
+	}
+	if event.Reason != nil {
+		var reason protoMessage
+		reason.string(1, string(event.Reason.Cause))
+		reason.string(2, event.Reason.Detail)
+		if event.Reason.Err != nil {
+			reason.string(3, event.Reason.Err.Error())
+		}
+		m.message(8, reason)
+	}
+	m.string(9, event.Hook)
+	m.string(10, event.Dependency)
+	m.string(11, event.Stack)
+	m.varint(12, uint64(event.Attempt))
+	return m
+}
+
+// protoMessage accumulates the protobuf wire encoding of a message. Like
+// proto3, fields holding their zero value are omitted
+type protoMessage []byte
+
+const (
+	protoVarint = 0
+	protoBytes  = 2
+)
+
+func (m *protoMessage) tag(field, wireType int) {
+	m.rawVarint(uint64(field)<<3 | uint64(wireType))
+}
+
+func (m *protoMessage) rawVarint(v uint64) {
+	for v >= 0x80 {
+		*m = append(*m, byte(v)|0x80)
+		v >>= 7
+	}
+	*m = append(*m, byte(v))
+}
+
+func (m *protoMessage) varint(field int, v uint64) {
+	if v == 0 {
+		return
+	}
+	m.tag(field, protoVarint)
+	m.rawVarint(v)
+}
+
+func (m *protoMessage) bool(field int, v bool) {
+	if v {
+		m.varint(field, 1)
+	}
+}
+
+func (m *protoMessage) string(field int, v string) {
+	if v != "" {
+		m.repeatedString(field, v)
+	}
+}
+
+// repeatedString writes a string even when empty, as elements of repeated
+// fields are never omitted
+func (m *protoMessage) repeatedString(field int, v string) {
+	m.tag(field, protoBytes)
+	m.rawVarint(uint64(len(v)))
+	*m = append(*m, v...)
+}
+
+func (m *protoMessage) message(field int, nested protoMessage) {
+	m.tag(field, protoBytes)
+	m.rawVarint(uint64(len(nested)))
+	*m = append(*m, nested...)
+}
@@ -0,0 +1,210 @@
+package component
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"strings"
+	"testing"
+)
+
+func encodingTestSystem(t *testing.T) *System {
+	silenceTestStdout(t)
+	system := CreateSystem(map[string]*Component{
+		"db":  Define("db", &MockComponent{}).WithTags("storage"),
+		"api": Define("api", &MockComponent{}, "db"),
+	})
+	if err := system.Start(); err != nil {
+		t.Fatalf("Failed to start system: %v", err)
+	}
+	t.Cleanup(func() { system.Stop() })
+	return system
+}
+
+func TestYAMLEncoder(t *testing.T) {
+	system := encodingTestSystem(t)
+
+	var buf bytes.Buffer
+	if err := system.ExportGraph(&buf, YAMLEncoder{}); err != nil {
+		t.Fatalf("Failed to encode: %v", err)
+	}
+	for _, line := range []string{
+		"schema_version: 1\n",
+		"started: true\n",
+		"components:\n  - key: api\n",
+		"    state: started\n",
+		"    dependencies:\n      - db\n",
+		"    dependencies: []\n",
+		"    tags:\n      - storage\n",
+		"edges:\n  - from: api\n    to: db\n",
+	} {
+		if !strings.Contains(buf.String(), line) {
+			t.Errorf("Expected %q in:\n%s", line, buf.String())
+		}
+	}
+}
+
+func TestYAMLScalarQuoting(t *testing.T) {
+	for text, want := range map[string]string{
+		"db":         "db",
+		"":           `""`,
+		"true":       `"true"`,
+		"42":         `"42"`,
+		"a: b":       `"a: b"`,
+		"- item":     `"- item"`,
+		" padded":    `" padded"`,
+		"shared/db":  "shared/db",
+		"two\nlines": `"two\nlines"`,
+	} {
+		if got := yamlScalar(text); got != want {
+			t.Errorf("Expected %q rendered as %s, got %s", text, want, got)
+		}
+	}
+}
+
+func TestMermaidEncoder(t *testing.T) {
+	system := encodingTestSystem(t)
+
+	var buf bytes.Buffer
+	if err := system.ExportGraph(&buf, MermaidEncoder{}); err != nil {
+		t.Fatalf("Failed to encode: %v", err)
+	}
+	want := "flowchart BT\n" +
+		"  n0[\"api<br/>started\"]\n" +
+		"  n1[\"db<br/>started\"]\n" +
+		"  n0 --> n1\n"
+	if buf.String() != want {
+		t.Errorf("Expected:\n%s\ngot:\n%s", want, buf.String())
+	}
+}
+
+func TestDOTEncoderMatchesExportDOT(t *testing.T) {
+	system := encodingTestSystem(t)
+
+	var exported, encoded bytes.Buffer
+	system.ExportDOT(&exported, WithDOTStyles(DefaultDOTStyles))
+	if err := system.ExportGraph(&encoded, DOTEncoder{Styles: DefaultDOTStyles}); err != nil {
+		t.Fatalf("Failed to encode: %v", err)
+	}
+	if exported.String() != encoded.String() {
+		t.Errorf("Expected the same DOT from the GraphExport:\n%s\ngot:\n%s", exported.String(), encoded.String())
+	}
+
+	var health bytes.Buffer
+	if err := system.ExportHealth(&health, DOTEncoder{}); err != nil || !strings.Contains(health.String(), `"api" -> "db";`) {
+		t.Errorf("Expected the health report rendered as a graph, got %v:\n%s", err, health.String())
+	}
+}
+
+func TestProtobufEncoder(t *testing.T) {
+	graph := GraphExport{
+		SchemaVersion: 1,
+		Started:       true,
+		Components:    []GraphComponent{{Key: "db", State: StateStarted, Dependencies: []string{}}},
+		Edges:         []GraphEdge{{From: "api", To: "db"}},
+	}
+
+	var buf bytes.Buffer
+	if err := (ProtobufEncoder{}).Encode(&buf, graph); err != nil {
+		t.Fatalf("Failed to encode: %v", err)
+	}
+	want := []byte{
+		0x08, 0x01, // schema_version
+		0x10, 0x01, // started
+		0x1a, 0x0d, 0x0a, 0x02, 'd', 'b', 0x1a, 0x07, 's', 't', 'a', 'r', 't', 'e', 'd', // component
+		0x22, 0x09, 0x0a, 0x03, 'a', 'p', 'i', 0x12, 0x02, 'd', 'b', // edge
+	}
+	if !bytes.Equal(buf.Bytes(), want) {
+		t.Errorf("Expected % x, got % x", want, buf.Bytes())
+	}
+
+	if err := (ProtobufEncoder{}).Encode(&buf, TraceReport{}); !errors.Is(err, ErrUnsupportedDocument) {
+		t.Errorf("Expected ErrUnsupportedDocument, got %v", err)
+	}
+}
+
+type cmdbEncoder struct{}
+
+func (cmdbEncoder) Encode(w io.Writer, document interface{}) error {
+	nodes, _, err := diagramOf(document)
+	for _, node := range nodes {
+		io.WriteString(w, "CI "+node.key+"\n")
+	}
+	return err
+}
+
+func (cmdbEncoder) ContentType() string {
+	return "text/plain"
+}
+
+func TestRegisterEncoder(t *testing.T) {
+	system := encodingTestSystem(t)
+	RegisterEncoder("cmdb", cmdbEncoder{})
+	t.Cleanup(func() {
+		encodersMu.Lock()
+		delete(encoders, "cmdb")
+		encodersMu.Unlock()
+	})
+
+	encoder, ok := LookupEncoder("cmdb")
+	if !ok {
+		t.Fatalf("Expected the custom encoder registered, got formats %v", EncoderFormats())
+	}
+	var buf bytes.Buffer
+	if err := system.ExportGraph(&buf, encoder); err != nil || buf.String() != "CI api\nCI db\n" {
+		t.Errorf("Expected the custom format, got %v: %q", err, buf.String())
+	}
+	if _, ok := LookupEncoder("svg"); ok {
+		t.Error("Expected no encoder for an unknown format")
+	}
+}
+
+func TestProtobufEventListener(t *testing.T) {
+	var buf bytes.Buffer
+	listener := NewProtobufEventListener(&buf)
+	listener(Event{Type: EventComponentStarted, Component: "db", Duration: 300})
+	listener(Event{Type: EventSystemStopping, Reason: &ShutdownReason{Cause: ShutdownSignal, Detail: "interrupt"}})
+
+	want := []byte{
+		0x1a, // length of the first event
+		0x0a, 0x11, 'c', 'o', 'm', 'p', 'o', 'n', 'e', 'n', 't', '_', 's', 't', 'a', 'r', 't', 'e', 'd',
+		0x12, 0x02, 'd', 'b',
+		0x30, 0xac, 0x02, // duration_ns 300
+		0x26, // length of the second event
+		0x0a, 0x0f, 's', 'y', 's', 't', 'e', 'm', '_', 's', 't', 'o', 'p', 'p', 'i', 'n', 'g',
+		0x42, 0x13, 0x0a, 0x06, 's', 'i', 'g', 'n', 'a', 'l', 0x12, 0x09, 'i', 'n', 't', 'e', 'r', 'r', 'u', 'p', 't',
+	}
+	if !bytes.Equal(buf.Bytes(), want) {
+		t.Errorf("Expected % x, got % x", want, buf.Bytes())
+	}
+}
+
+func TestProtobufHealthReport(t *testing.T) {
+	var buf bytes.Buffer
+	report := HealthReport{Status: HealthUnhealthy, Components: []ComponentHealth{{Key: "db", Status: HealthUnhealthy, State: StateFailed, Cause: "db"}}}
+	if err := (ProtobufEncoder{}).Encode(&buf, report); err != nil {
+		t.Fatalf("Failed to encode: %v", err)
+	}
+	want := []byte{
+		0x0a, 0x09, 'u', 'n', 'h', 'e', 'a', 'l', 't', 'h', 'y',
+		0x12, 0x1b,
+		0x0a, 0x02, 'd', 'b',
+		0x12, 0x09, 'u', 'n', 'h', 'e', 'a', 'l', 't', 'h', 'y',
+		0x1a, 0x06, 'f', 'a', 'i', 'l', 'e', 'd',
+		0x3a, 0x02, 'd', 'b',
+	}
+	if !bytes.Equal(buf.Bytes(), want) {
+		t.Errorf("Expected % x, got % x", want, buf.Bytes())
+	}
+}
+
+func TestProtoSchemaFile(t *testing.T) {
+	published, err := os.ReadFile("../proto/dependencygraph/v1/system.proto")
+	if err != nil {
+		t.Fatalf("Failed to read the published schema: %v", err)
+	}
+	if string(published) != ProtoSchema {
+		t.Error("Expected proto/dependencygraph/v1/system.proto to match ProtoSchema")
+	}
+}
@@ -0,0 +1,169 @@
+package component
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// YAMLEncoder writes documents as YAML. The document is first marshalled as
+// JSON, so it follows the json tags and field order of the document types
+type YAMLEncoder struct{}
+
+func (YAMLEncoder) Encode(w io.Writer, document interface{}) error {
+	data, err := json.Marshal(document)
+	if err != nil {
+		return err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	value, err := decodeOrdered(decoder)
+	if err != nil {
+		return err
+	}
+
+	b := bufio.NewWriter(w)
+	writeYAML(b, value, 0)
+	return b.Flush()
+}
+
+func (YAMLEncoder) ContentType() string {
+	return "application/yaml"
+}
+
+// orderedField is one field of a JSON object, kept in document order
+type orderedField struct {
+	key   string
+	value interface{}
+}
+
+// decodeOrdered decodes the next JSON value, objects as []orderedField and
+// arrays as []interface{}
+func decodeOrdered(decoder *json.Decoder) (interface{}, error) {
+	token, err := decoder.Token()
+	if err != nil {
+		return nil, err
+	}
+	switch token {
+	case json.Delim('{'):
+		fields := []orderedField{}
+		for decoder.More() {
+			key, err := decoder.Token()
+			if err != nil {
+				return nil, err
+			}
+			value, err := decodeOrdered(decoder)
+			if err != nil {
+				return nil, err
+			}
+			fields = append(fields, orderedField{key: key.(string), value: value})
+		}
+		_, err := decoder.Token()
+		return fields, err
+	case json.Delim('['):
+		items := []interface{}{}
+		for decoder.More() {
+			item, err := decodeOrdered(decoder)
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, item)
+		}
+		_, err := decoder.Token()
+		return items, err
+	default:
+		return token, nil
+	}
+}
+
+// writeYAML writes a decoded value as the body of a block at indent
+func writeYAML(b *bufio.Writer, value interface{}, indent int) {
+	pad := strings.Repeat(" ", indent)
+	switch value := value.(type) {
+	case []orderedField:
+		if len(value) == 0 {
+			b.WriteString(pad + "{}\n")
+			return
+		}
+		for _, field := range value {
+			b.WriteString(pad + yamlScalar(field.key) + ":")
+			writeYAMLChild(b, field.value, indent)
+		}
+	case []interface{}:
+		if len(value) == 0 {
+			b.WriteString(pad + "[]\n")
+			return
+		}
+		for _, item := range value {
+			b.WriteString(pad + "-")
+			if fields, ok := item.([]orderedField); ok && len(fields) > 0 {
+				// the first field shares the line of the dash
+				var nested bytes.Buffer
+				nb := bufio.NewWriter(&nested)
+				writeYAML(nb, fields, indent+2)
+				nb.Flush()
+				b.WriteString(" " + strings.TrimPrefix(nested.String(), pad+"  "))
+				continue
+			}
+			writeYAMLChild(b, item, indent)
+		}
+	default:
+		b.WriteString(pad + yamlValue(value) + "\n")
+	}
+}
+
+// writeYAMLChild writes the value following a key or dash: scalars and
+// empty collections on the same line, others as a nested block
+func writeYAMLChild(b *bufio.Writer, value interface{}, indent int) {
+	switch v := value.(type) {
+	case []orderedField:
+		if len(v) > 0 {
+			b.WriteString("\n")
+			writeYAML(b, v, indent+2)
+			return
+		}
+		b.WriteString(" {}\n")
+	case []interface{}:
+		if len(v) > 0 {
+			b.WriteString("\n")
+			writeYAML(b, v, indent+2)
+			return
+		}
+		b.WriteString(" []\n")
+	default:
+		b.WriteString(" " + yamlValue(v) + "\n")
+	}
+}
+
+// yamlValue renders a JSON scalar token
+func yamlValue(value interface{}) string {
+	switch value := value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return strconv.FormatBool(value)
+	case json.Number:
+		return value.String()
+	default:
+		return yamlScalar(value.(string))
+	}
+}
+
+// yamlScalar renders a string, quoting it when a plain scalar would be
+// read back as something else
+func yamlScalar(text string) string {
+	if text == "" || strings.TrimSpace(text) != text || strings.ContainsAny(text, ":#{}[],&*!|>'\"%@`\n\t\\") || strings.HasPrefix(text, "-") || strings.HasPrefix(text, "?") {
+		return strconv.Quote(text)
+	}
+	switch strings.ToLower(text) {
+	case "true", "false", "yes", "no", "on", "off", "null", "~":
+		return strconv.Quote(text)
+	}
+	if _, err := strconv.ParseFloat(text, 64); err == nil {
+		return strconv.Quote(text)
+	}
+	return text
+}
@@ -0,0 +1,149 @@
+package component
+
+import (
+	"os"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// ReservedPrefix starts every context key populated by the system itself.
+// Component and provided keys may not use it
+const ReservedPrefix = "@"
+
+// EnvContextKey holds the component's namespaced environment in its Context
+const EnvContextKey = ReservedPrefix + "env"
+
+// Env reads environment variables namespaced for one component, so
+// HTTP_SERVER__PORT is read as Port by the http_server component
+type Env struct {
+	prefix string
+	lookup func(string) (string, bool)
+}
+
+// NewEnv creates the environment accessor for a component key
+func NewEnv(key string) *Env {
+	return &Env{prefix: EnvPrefix(key), lookup: os.LookupEnv}
+}
+
+// EnvPrefix returns the variable prefix for a component key: the key in
+// upper case with non-alphanumeric runes replaced by "_", followed by "__"
+func EnvPrefix(key string) string {
+	if key == "" {
+		return ""
+	}
+	mapped := strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return unicode.ToUpper(r)
+		}
+		return '_'
+	}, key)
+	return mapped + "__"
+}
+
+// Start implements Lifecycle so the accessor can be carried in a Context
+func (e *Env) Start(ctx Context) (Lifecycle, error) {
+	return e, nil
+}
+
+// Stop implements Lifecycle
+func (e *Env) Stop(ctx Context) error {
+	return nil
+}
+
+// Name returns the full variable name for a setting
+func (e *Env) Name(name string) string {
+	return e.prefix + strings.ToUpper(name)
+}
+
+// Lookup returns the value of a setting and whether it is set
+func (e *Env) Lookup(name string) (string, bool) {
+	return e.lookup(e.Name(name))
+}
+
+// String returns a setting or the fallback when unset
+func (e *Env) String(name, fallback string) string {
+	if value, ok := e.Lookup(name); ok {
+		return value
+	}
+	return fallback
+}
+
+// Int returns a setting parsed as an integer or the fallback when unset
+func (e *Env) Int(name string, fallback int) (int, error) {
+	value, ok := e.Lookup(name)
+	if !ok {
+		return fallback, nil
+	}
+	parsed, err := strconv.Atoi(value)
+	if err != nil {
+		return fallback, &EnvError{Name: e.Name(name), Err: err}
+	}
+	return parsed, nil
+}
+
+// Bool returns a setting parsed as a boolean or the fallback when unset
+func (e *Env) Bool(name string, fallback bool) (bool, error) {
+	value, ok := e.Lookup(name)
+	if !ok {
+		return fallback, nil
+	}
+	parsed, err := strconv.ParseBool(value)
+	if err != nil {
+		return fallback, &EnvError{Name: e.Name(name), Err: err}
+	}
+	return parsed, nil
+}
+
+// Duration returns a setting parsed as a duration or the fallback when unset
+func (e *Env) Duration(name string, fallback time.Duration) (time.Duration, error) {
+	value, ok := e.Lookup(name)
+	if !ok {
+		return fallback, nil
+	}
+	parsed, err := time.ParseDuration(value)
+	if err != nil {
+		return fallback, &EnvError{Name: e.Name(name), Err: err}
+	}
+	return parsed, nil
+}
+
+// EnvError reports an environment variable that could not be parsed
+type EnvError struct {
+	Name string
+	Err  error
+}
+
+func (e *EnvError) Error() string {
+	return "invalid value for " + e.Name + ": " + e.Err.Error()
+}
+
+func (e *EnvError) Unwrap() error {
+	return e.Err
+}
+
+// Env returns the namespaced environment of the component the context was
+// built for. Outside a System it reads unprefixed variables
+func (ctx Context) Env() *Env {
+	if env, ok := ctx[EnvContextKey].(*Env); ok {
+		return env
+	}
+	return NewEnv("")
+}
+
+// injectReserved adds the system-provided entries to a component's context
+func (s *System) injectReserved(component *Component, ctx Context) {
+	ctx[EnvContextKey] = NewEnv(component.key)
+	identity := component.Identity()
+	ctx[IdentityContextKey] = &identity
+	if params := component.GetParams(); params != nil {
+		ctx[ParamsContextKey] = &paramsHolder{value: params}
+	}
+	ctx[BackpressureContextKey] = s.newBackpressureFor(component)
+	ctx[ResolverContextKey] = &resolver{system: s, component: component}
+	ctx[LoggerContextKey] = &componentLogger{logger: s.Logger(), key: component.key}
+	if s.tracer != nil {
+		ctx[TracerContextKey] = &contextTracer{tracer: s.tracer, component: component.key, aliases: component.aliases}
+	}
+}
@@ -0,0 +1,60 @@
+package component
+
+import (
+	"testing"
+	"time"
+)
+
+func TestEnvPrefix(t *testing.T) {
+	tests := map[string]string{
+		"http_server":    "HTTP_SERVER__",
+		"handlers/users": "HANDLERS_USERS__",
+		"db.read":        "DB_READ__",
+	}
+	for key, expected := range tests {
+		if prefix := EnvPrefix(key); prefix != expected {
+			t.Errorf("EnvPrefix(%q): expected %q, got %q", key, expected, prefix)
+		}
+	}
+}
+
+func TestContextEnvIsNamespaced(t *testing.T) {
+	t.Setenv("HTTP_SERVER__PORT", "8080")
+	t.Setenv("HTTP_SERVER__TIMEOUT", "5s")
+	t.Setenv("PORT", "9999")
+
+	capture := &ContextCapture{}
+	system := CreateSystem(map[string]*Component{
+		"http_server": Define("http_server", capture),
+	})
+	if err := system.Start(); err != nil {
+		t.Fatalf("Failed to start system: %v", err)
+	}
+
+	env := capture.Ctx.Env()
+	if port, err := env.Int("port", 3000); err != nil || port != 8080 {
+		t.Errorf("Expected namespaced port 8080, got %d (%v)", port, err)
+	}
+	if timeout, err := env.Duration("timeout", time.Second); err != nil || timeout != 5*time.Second {
+		t.Errorf("Expected timeout 5s, got %v (%v)", timeout, err)
+	}
+	if debug, err := env.Bool("debug", true); err != nil || !debug {
+		t.Errorf("Expected fallback for unset variable, got %v (%v)", debug, err)
+	}
+}
+
+func TestEnvParseError(t *testing.T) {
+	t.Setenv("WORKER__CONCURRENCY", "many")
+	if _, err := NewEnv("worker").Int("concurrency", 1); err == nil {
+		t.Error("Expected parse error for non-numeric value")
+	}
+}
+
+func TestReservedKeysRejected(t *testing.T) {
+	system := CreateSystem(map[string]*Component{
+		"@env": Define("@env", &MockComponent{}),
+	})
+	if err := system.Start(); err == nil {
+		t.Fatal("Expected reserved key to be rejected")
+	}
+}
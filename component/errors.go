@@ -0,0 +1,246 @@
+package component
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Operations reported by ComponentError
+const (
+	OpValidate = "validate"
+	OpResolve  = "resolve"
+	OpStart    = "start"
+	OpStop     = "stop"
+)
+
+// ComponentError is a lifecycle error attributed to a single component
+type ComponentError struct {
+	Key string
+	Op  string
+	Err error
+}
+
+func (e *ComponentError) Error() string {
+	switch e.Op {
+	case OpStart:
+		return fmt.Sprintf("failed to start component %s: %v", e.Key, e.Err)
+	case OpStop:
+		return fmt.Sprintf("failed to stop component %s: %v", e.Key, e.Err)
+	default:
+		return fmt.Sprintf("%v for component %s", e.Err, e.Key)
+	}
+}
+
+func (e *ComponentError) Unwrap() error {
+	return e.Err
+}
+
+// FormatError renders a lifecycle error, possibly holding several joined
+// errors, grouped by component with the dependency chain leading to each
+func (s *System) FormatError(err error) string {
+	if err == nil {
+		return ""
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.providers == nil {
+		s.buildProviders()
+	}
+
+	groups := make(map[string][]error)
+	var general []error
+	collectErrors(err, groups, &general)
+
+	keys := make([]string, 0, len(groups))
+	for key := range groups {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var b strings.Builder
+	if id, ok := CorrelationIDOf(err); ok {
+		fmt.Fprintf(&b, "lifecycle failed [correlation_id=%s]\n", id)
+	} else {
+		b.WriteString("lifecycle failed\n")
+	}
+
+	for _, key := range keys {
+		fmt.Fprintf(&b, "  component %s\n", key)
+		fmt.Fprintf(&b, "    chain: %s\n", strings.Join(s.dependencyChain(key), " -> "))
+		for _, e := range groups[key] {
+			writeIndented(&b, e.Error(), "    - ", "      ")
+		}
+	}
+	if len(general) > 0 {
+		b.WriteString("  system\n")
+		for _, e := range general {
+			writeIndented(&b, e.Error(), "    - ", "      ")
+		}
+	}
+
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+// collectErrors walks joined and wrapped errors, grouping component errors by key
+func collectErrors(err error, groups map[string][]error, general *[]error) {
+	var componentErr *ComponentError
+	if errors.As(err, &componentErr) && componentErr == err {
+		prefix := componentErr.Op
+		if prefix == "" {
+			prefix = "error"
+		}
+		groups[componentErr.Key] = append(groups[componentErr.Key], fmt.Errorf("%s: %w", prefix, componentErr.Err))
+		return
+	}
+
+	switch e := err.(type) {
+	case *CorrelatedError:
+		collectErrors(e.Err, groups, general)
+	case interface{ Unwrap() []error }:
+		for _, inner := range e.Unwrap() {
+			collectErrors(inner, groups, general)
+		}
+	default:
+		if errors.As(err, &componentErr) {
+			collectErrors(componentErr, groups, general)
+			return
+		}
+		*general = append(*general, err)
+	}
+}
+
+// dependencyChain returns the path from a top-level component down to key
+// following dependency edges, showing why the component was being started
+func (s *System) dependencyChain(key string) []string {
+	chain := []string{key}
+	seen := map[string]bool{key: true}
+
+	current := key
+	for {
+		next := ""
+		for _, name := range s.sortedKeys() {
+			if seen[name] {
+				continue
+			}
+			for _, dep := range s.dependencyKeys(s.components[name]) {
+				if provider, ok := s.resolve(dep); ok && provider == current {
+					next = name
+					break
+				}
+			}
+			if next != "" {
+				break
+			}
+		}
+		if next == "" {
+			break
+		}
+		chain = append([]string{next}, chain...)
+		seen[next] = true
+		current = next
+	}
+
+	return chain
+}
+
+// joinErrors joins the non-nil errors, returning a single error as is so
+// callers can still compare or assert it directly
+func joinErrors(errs []error) error {
+	var nonNil []error
+	for _, err := range errs {
+		if err != nil {
+			nonNil = append(nonNil, err)
+		}
+	}
+	switch len(nonNil) {
+	case 0:
+		return nil
+	case 1:
+		return nonNil[0]
+	default:
+		return errors.Join(nonNil...)
+	}
+}
+
+// sortedKeys returns the component keys in lexical order
+func (s *System) sortedKeys() []string {
+	keys := make([]string, 0, len(s.components))
+	for key := range s.components {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// writeIndented writes a possibly multi-line message with a first-line prefix
+func writeIndented(b *strings.Builder, message, first, rest string) {
+	for i, line := range strings.Split(message, "\n") {
+		if i == 0 {
+			b.WriteString(first)
+		} else {
+			b.WriteString(rest)
+		}
+		b.WriteString(line)
+		b.WriteString("\n")
+	}
+}
+
+// Sentinel errors matched with errors.Is against the typed errors below
+var (
+	ErrCyclicDependency     = errors.New("cyclic dependency")
+	ErrMissingDependency    = errors.New("missing dependency")
+	ErrComponentStartFailed = errors.New("component start failed")
+	ErrComponentStopFailed  = errors.New("component stop failed")
+)
+
+// Is matches ErrComponentStartFailed for start errors and
+// ErrComponentStopFailed for stop errors
+func (e *ComponentError) Is(target error) bool {
+	switch e.Op {
+	case OpStart:
+		return target == ErrComponentStartFailed
+	case OpStop:
+		return target == ErrComponentStopFailed
+	}
+	return false
+}
+
+// CycleError reports a cyclic dependency. Path closes the cycle, starting
+// and ending with the same key, e.g. [a b c a]; it is empty when only the
+// ordering found the cycle
+type CycleError struct {
+	Key  string
+	Path []string
+}
+
+func (e *CycleError) Error() string {
+	if e.Key == "" {
+		return "cyclic dependency detected"
+	}
+	if len(e.Path) == 0 {
+		return fmt.Sprintf("cyclic dependency detected involving component %s", e.Key)
+	}
+	return fmt.Sprintf("cyclic dependency detected involving component %s: %s", e.Key, strings.Join(e.Path, " -> "))
+}
+
+func (e *CycleError) Is(target error) bool {
+	return target == ErrCyclicDependency
+}
+
+// MissingDependencyError reports a dependency no component or provided key
+// satisfies
+type MissingDependencyError struct {
+	Key        string
+	Dependency string
+}
+
+func (e *MissingDependencyError) Error() string {
+	return fmt.Sprintf("dependency %s not found", e.Dependency)
+}
+
+func (e *MissingDependencyError) Is(target error) bool {
+	return target == ErrMissingDependency
+}
@@ -0,0 +1,145 @@
+package component
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestFormatErrorGroupsByComponent(t *testing.T) {
+	system := CreateSystem(map[string]*Component{
+		"config":      Define("config", &MockComponent{}),
+		"db":          Define("db", &MockComponent{StartError: errors.New("connection refused")}, "config"),
+		"http_server": Define("http_server", &MockComponent{}, "db"),
+	})
+
+	err := system.Start()
+	if err == nil {
+		t.Fatal("Expected start to fail")
+	}
+
+	var componentErr *ComponentError
+	if !errors.As(err, &componentErr) || componentErr.Key != "db" || componentErr.Op != OpStart {
+		t.Fatalf("Expected start error for db, got %v", err)
+	}
+
+	formatted := system.FormatError(err)
+	for _, expected := range []string{
+		"component db",
+		"chain: http_server -> db",
+		"start: failed to start component: connection refused",
+	} {
+		if !strings.Contains(formatted, expected) {
+			t.Errorf("Expected formatted error to contain %q, got:\n%s", expected, formatted)
+		}
+	}
+}
+
+func TestFormatErrorJoined(t *testing.T) {
+	system := CreateSystem(map[string]*Component{
+		"a": Define("a", &MockComponent{}),
+		"b": Define("b", &MockComponent{}),
+	})
+
+	err := errors.Join(
+		&ComponentError{Key: "b", Op: OpStop, Err: errors.New("timeout")},
+		&ComponentError{Key: "a", Op: OpStop, Err: errors.New("leak")},
+		errors.New("unrelated"),
+	)
+
+	formatted := system.FormatError(err)
+	a := strings.Index(formatted, "component a")
+	b := strings.Index(formatted, "component b")
+	if a < 0 || b < 0 || a > b {
+		t.Errorf("Expected components grouped in order, got:\n%s", formatted)
+	}
+	if !strings.Contains(formatted, "system\n    - unrelated") {
+		t.Errorf("Expected unattributed errors under system, got:\n%s", formatted)
+	}
+}
+
+func TestTypedErrors(t *testing.T) {
+	cyclic := CreateSystem(map[string]*Component{
+		"a": Define("a", &MockComponent{}, "b"),
+		"b": Define("b", &MockComponent{}, "c"),
+		"c": Define("c", &MockComponent{}, "a"),
+	})
+	err := cyclic.Start()
+	var cycleErr *CycleError
+	if !errors.Is(err, ErrCyclicDependency) || !errors.As(err, &cycleErr) {
+		t.Fatalf("Expected a cycle error, got %v", err)
+	}
+	if want := []string{"a", "b", "c", "a"}; strings.Join(cycleErr.Path, " ") != strings.Join(want, " ") {
+		t.Errorf("Expected cycle path %v, got %v", want, cycleErr.Path)
+	}
+
+	missing := CreateSystem(map[string]*Component{
+		"api": Define("api", &MockComponent{}, "db"),
+	})
+	err = missing.Start()
+	var missingErr *MissingDependencyError
+	if !errors.Is(err, ErrMissingDependency) || !errors.As(err, &missingErr) {
+		t.Fatalf("Expected a missing dependency error, got %v", err)
+	}
+	if missingErr.Key != "api" || missingErr.Dependency != "db" {
+		t.Errorf("Expected api missing db, got %+v", missingErr)
+	}
+
+	failing := CreateSystem(map[string]*Component{
+		"db": Define("db", &MockComponent{StartError: errors.New("connection refused")}),
+	})
+	err = failing.Start()
+	if !errors.Is(err, ErrComponentStartFailed) || errors.Is(err, ErrComponentStopFailed) {
+		t.Errorf("Expected only ErrComponentStartFailed to match, got %v", err)
+	}
+
+	stopErr := &ComponentError{Key: "db", Op: OpStop, Err: errors.New("leak")}
+	if !errors.Is(stopErr, ErrComponentStopFailed) {
+		t.Error("Expected a stop error to match ErrComponentStopFailed")
+	}
+}
+
+func TestStartReportsEveryMissingDependency(t *testing.T) {
+	system := CreateSystem(map[string]*Component{
+		"api":    Define("api", &MockComponent{}, "db", "sessions"),
+		"worker": Define("worker", &MockComponent{}, "queue"),
+	})
+
+	err := system.Start()
+	for _, dep := range []string{"db", "sessions", "queue"} {
+		if err == nil || !strings.Contains(err.Error(), "dependency "+dep+" not found") {
+			t.Errorf("Expected missing %s in %v", dep, err)
+		}
+	}
+}
+
+func TestStopReportsEveryFailure(t *testing.T) {
+	system := CreateSystem(map[string]*Component{
+		"db":     Define("db", &MockComponent{StopError: errors.New("connection leak")}),
+		"cache":  Define("cache", &MockComponent{}, "db"),
+		"api":    Define("api", &MockComponent{StopError: errors.New("requests in flight")}, "cache"),
+		"worker": Define("worker", &MockComponent{}, "db"),
+	})
+	if err := system.Start(); err != nil {
+		t.Fatalf("Failed to start system: %v", err)
+	}
+
+	err := system.Stop()
+	var failed []string
+	var joined interface{ Unwrap() []error }
+	var correlated *CorrelatedError
+	if errors.As(err, &correlated) && errors.As(correlated.Err, &joined) {
+		for _, inner := range joined.Unwrap() {
+			var componentErr *ComponentError
+			if errors.As(inner, &componentErr) {
+				failed = append(failed, componentErr.Key)
+			}
+		}
+	}
+	if strings.Join(failed, ",") != "api,db" {
+		t.Errorf("Expected the stop failures of api and db in stop order, got %v", err)
+	}
+	if !errors.Is(err, ErrComponentStopFailed) {
+		t.Errorf("Expected the stop error to match ErrComponentStopFailed, got %v", err)
+	}
+}
@@ -0,0 +1,258 @@
+package component
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// EventRecorder keeps the ordered log of lifecycle events of a system so it
+// can be persisted on demand and replayed offline
+type EventRecorder struct {
+	events []Event
+	mu     sync.Mutex
+}
+
+// NewEventRecorder creates an empty event recorder. Register it with
+// WithEventListener(recorder.Record)
+func NewEventRecorder() *EventRecorder {
+	return &EventRecorder{}
+}
+
+// Record appends an event to the log
+func (r *EventRecorder) Record(event Event) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = append(r.events, event)
+}
+
+// Events returns a copy of the recorded events in order
+func (r *EventRecorder) Events() []Event {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]Event(nil), r.events...)
+}
+
+// WriteJSONLines writes the recorded events as one JSON object per line
+func (r *EventRecorder) WriteJSONLines(w io.Writer) error {
+	return WriteEvents(w, r.Events())
+}
+
+// eventJSON is the persisted form of an Event
+type eventJSON struct {
+	Type          EventType   `json:"type"`
+	Component     string      `json:"component,omitempty"`
+	ComponentID   string      `json:"component_id,omitempty"`
+	CorrelationID string      `json:"correlation_id,omitempty"`
+	Time          time.Time   `json:"time"`
+	DurationNanos int64       `json:"duration_ns,omitempty"`
+	Error         string      `json:"error,omitempty"`
+	Reason        *reasonJSON `json:"reason,omitempty"`
+	Hook          string      `json:"hook,omitempty"`
+	Dependency    string      `json:"dependency,omitempty"`
+	Stack         string      `json:"stack,omitempty"`
+	Attempt       int         `json:"attempt,omitempty"`
+}
+
+// reasonJSON is the persisted form of a ShutdownReason
+type reasonJSON struct {
+	Cause  ShutdownCause `json:"cause"`
+	Detail string        `json:"detail,omitempty"`
+}
+
+// MarshalJSON encodes the event with its error rendered as a string
+func (e Event) MarshalJSON() ([]byte, error) {
+	out := eventJSON{
+		Type:          e.Type,
+		Component:     e.Component,
+		ComponentID:   e.ComponentID,
+		CorrelationID: e.CorrelationID,
+		Time:          e.Time,
+		DurationNanos: int64(e.Duration),
+		Hook:          e.Hook,
+		Dependency:    e.Dependency,
+		Stack:         e.Stack,
+		Attempt:       e.Attempt,
+	}
+	if e.Err != nil {
+		out.Error = e.Err.Error()
+	}
+	if e.Reason != nil {
+		out.Reason = &reasonJSON{Cause: e.Reason.Cause, Detail: e.Reason.Detail}
+	}
+	return json.Marshal(out)
+}
+
+// UnmarshalJSON decodes an event written by MarshalJSON
+func (e *Event) UnmarshalJSON(data []byte) error {
+	var in eventJSON
+	if err := json.Unmarshal(data, &in); err != nil {
+		return err
+	}
+
+	*e = Event{
+		Type:          in.Type,
+		Component:     in.Component,
+		ComponentID:   in.ComponentID,
+		CorrelationID: in.CorrelationID,
+		Time:          in.Time,
+		Duration:      time.Duration(in.DurationNanos),
+		Hook:          in.Hook,
+		Dependency:    in.Dependency,
+		Stack:         in.Stack,
+		Attempt:       in.Attempt,
+	}
+	if in.Error != "" {
+		e.Err = errors.New(in.Error)
+	}
+	if in.Reason != nil {
+		e.Reason = &ShutdownReason{Cause: in.Reason.Cause, Detail: in.Reason.Detail}
+	}
+	return nil
+}
+
+// WriteEvents writes events as JSON lines
+func WriteEvents(w io.Writer, events []Event) error {
+	encoder := json.NewEncoder(w)
+	for _, event := range events {
+		if err := encoder.Encode(event); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ReadEvents reads events written as JSON lines
+func ReadEvents(r io.Reader) ([]Event, error) {
+	var events []Event
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+		var event Event
+		if err := json.Unmarshal([]byte(text), &event); err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		events = append(events, event)
+	}
+	return events, scanner.Err()
+}
+
+// Timeline is the reconstruction of a recorded event sequence
+type Timeline struct {
+	Events []Event
+
+	// States holds the final state of each component
+	States map[string]State
+
+	// Errors holds the last error reported by each component
+	Errors map[string]error
+}
+
+// Replay reconstructs the timeline and the final component states from an
+// ordered event sequence
+func Replay(events []Event) *Timeline {
+	timeline := &Timeline{
+		Events: events,
+		States: make(map[string]State),
+		Errors: make(map[string]error),
+	}
+
+	for _, event := range events {
+		if event.Component == "" {
+			continue
+		}
+		if state, ok := stateAfter(event); ok {
+			timeline.States[event.Component] = state
+		}
+		if event.Err != nil {
+			timeline.Errors[event.Component] = event.Err
+		}
+	}
+	return timeline
+}
+
+// stateAfter returns the component state implied by an event
+func stateAfter(event Event) (State, bool) {
+	switch event.Type {
+	case EventComponentStarting:
+		return StateStarting, true
+	case EventComponentStarted:
+		return StateStarted, true
+	case EventComponentFailed:
+		return StateFailed, true
+	case EventComponentCompleted:
+		return StateCompleted, true
+	case EventComponentStopping:
+		return StateStopping, true
+	case EventComponentDegraded:
+		return StateDegraded, true
+	case EventComponentRecovered:
+		return StateStarted, true
+	case EventComponentQuarantined:
+		return StateQuarantined, true
+	case EventQuarantineCleared:
+		return StateStopped, true
+	case EventComponentStopped:
+		if event.Err != nil {
+			return StateFailed, true
+		}
+		return StateStopped, true
+	default:
+		return 0, false
+	}
+}
+
+// Write renders the timeline with offsets relative to the first event,
+// followed by the final state of every component
+func (t *Timeline) Write(w io.Writer) error {
+	var origin time.Time
+	if len(t.Events) > 0 {
+		origin = t.Events[0].Time
+	}
+
+	for _, event := range t.Events {
+		line := fmt.Sprintf("+%-12v %-20s", event.Time.Sub(origin), event.Type)
+		if event.Component != "" {
+			line += " " + event.Component
+		}
+		if event.Duration > 0 {
+			line += fmt.Sprintf(" (%v)", event.Duration)
+		}
+		if event.Reason != nil {
+			line += fmt.Sprintf(" reason=%s", event.Reason)
+		}
+		if event.Err != nil {
+			line += fmt.Sprintf(" error=%q", event.Err.Error())
+		}
+		if _, err := fmt.Fprintln(w, line); err != nil {
+			return err
+		}
+	}
+
+	keys := make([]string, 0, len(t.States))
+	for key := range t.States {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	if _, err := fmt.Fprintln(w, "\nFinal state:"); err != nil {
+		return err
+	}
+	for _, key := range keys {
+		if _, err := fmt.Fprintf(w, "  %-30s %s\n", key, t.States[key]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
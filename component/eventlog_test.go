@@ -0,0 +1,89 @@
+package component
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestEventLogRoundTripAndReplay(t *testing.T) {
+	recorder := NewEventRecorder()
+	system := CreateSystem(map[string]*Component{
+		"compA": Define("compA", &MockComponent{}),
+		"compB": Define("compB", &MockComponent{StopError: errors.New("leak")}, "compA"),
+	}, WithEventListener(recorder.Record))
+
+	if err := system.Start(); err != nil {
+		t.Fatalf("Failed to start system: %v", err)
+	}
+	system.Stop()
+
+	var buf bytes.Buffer
+	if err := recorder.WriteJSONLines(&buf); err != nil {
+		t.Fatalf("Failed to write events: %v", err)
+	}
+
+	events, err := ReadEvents(&buf)
+	if err != nil {
+		t.Fatalf("Failed to read events: %v", err)
+	}
+	if len(events) != len(recorder.Events()) {
+		t.Fatalf("Expected %d events, got %d", len(recorder.Events()), len(events))
+	}
+
+	timeline := Replay(events)
+	if timeline.States["compA"] != StateStopped {
+		t.Errorf("Expected compA stopped, got %s", timeline.States["compA"])
+	}
+	if timeline.States["compB"] != StateFailed {
+		t.Errorf("Expected compB failed, got %s", timeline.States["compB"])
+	}
+	if timeline.Errors["compB"] == nil || !strings.Contains(timeline.Errors["compB"].Error(), "leak") {
+		t.Errorf("Expected compB error to survive the round trip, got %v", timeline.Errors["compB"])
+	}
+
+	var out bytes.Buffer
+	if err := timeline.Write(&out); err != nil {
+		t.Fatalf("Failed to render timeline: %v", err)
+	}
+	if !strings.Contains(out.String(), "reason=api") {
+		t.Errorf("Expected rendered timeline to include the shutdown reason, got:\n%s", out.String())
+	}
+}
+
+func TestEventLogKeepsDiagnostics(t *testing.T) {
+	slow := Event{Type: EventComponentSlowStart, Component: "db", Duration: time.Second, Stack: "goroutine 7 [IO wait]:\nnet.(*conn).Read"}
+	retrying := Event{Type: EventComponentRetrying, Component: "db", Duration: time.Millisecond, Err: errors.New("connection refused"), Attempt: 2}
+
+	var buf bytes.Buffer
+	if err := WriteEvents(&buf, []Event{slow, retrying}); err != nil {
+		t.Fatalf("Failed to write events: %v", err)
+	}
+	events, err := ReadEvents(&buf)
+	if err != nil {
+		t.Fatalf("Failed to read events: %v", err)
+	}
+	if !reflect.DeepEqual(events, []Event{slow, retrying}) {
+		t.Errorf("Expected %+v and %+v to survive the round trip, got %+v", slow, retrying, events)
+	}
+
+	var encoded bytes.Buffer
+	if err := (ProtobufEncoder{}).Encode(&encoded, slow); err != nil {
+		t.Fatalf("Failed to encode: %v", err)
+	}
+	stack := append([]byte{0x5a, byte(len(slow.Stack))}, slow.Stack...)
+	if !bytes.HasSuffix(encoded.Bytes(), stack) {
+		t.Errorf("Expected the stack as field 11, got % x", encoded.Bytes())
+	}
+
+	encoded.Reset()
+	if err := (ProtobufEncoder{}).Encode(&encoded, retrying); err != nil {
+		t.Fatalf("Failed to encode: %v", err)
+	}
+	if !bytes.HasSuffix(encoded.Bytes(), []byte{0x60, 0x02}) {
+		t.Errorf("Expected the attempt as field 12, got % x", encoded.Bytes())
+	}
+}
@@ -0,0 +1,133 @@
+package component
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// EventType identifies a lifecycle event
+type EventType string
+
+const (
+	EventSystemStarting    EventType = "system_starting"
+	EventSystemStarted     EventType = "system_started"
+	EventSystemStopping    EventType = "system_stopping"
+	EventSystemStopped     EventType = "system_stopped"
+	EventComponentStarting EventType = "component_starting"
+	EventComponentStarted  EventType = "component_started"
+	EventComponentFailed   EventType = "component_failed"
+	EventComponentStopping EventType = "component_stopping"
+	EventComponentStopped  EventType = "component_stopped"
+)
+
+// Event describes something that happened during the system lifecycle
+type Event struct {
+	Type EventType
+
+	// Component and ComponentID are empty for system-level events
+	Component   string
+	ComponentID string
+
+	// CorrelationID is shared by every event of the same Start or Stop call
+	CorrelationID string
+
+	Time     time.Time
+	Duration time.Duration
+	Err      error
+
+	// Reason is set on stop events
+	Reason *ShutdownReason
+
+	// Hook names the system hook of hook events
+	Hook string
+
+	// Dependency names the primary dependency of fallback events
+	Dependency string
+
+	// Stack is the goroutine stack a slow start was most often blocked on
+	Stack string
+
+	// Attempt is the failed attempt of retry events
+	Attempt int
+}
+
+// EventListener receives lifecycle events. Listeners are called
+// synchronously and must not call back into the System
+type EventListener func(Event)
+
+// WithEventListener registers a listener for lifecycle events
+func WithEventListener(listener EventListener) Option {
+	return func(s *System) {
+		s.listeners = append(s.listeners, listener)
+	}
+}
+
+// emit delivers an event to every registered listener. Deliveries are
+// serialized, so listeners are never called concurrently
+func (s *System) emit(event Event) {
+	if event.Time.IsZero() {
+		event.Time = time.Now()
+	}
+	s.emitMu.Lock()
+	defer s.emitMu.Unlock()
+	s.traceEvent(event)
+	for _, listener := range s.listeners {
+		listener(event)
+	}
+	s.notifyObservers(event)
+	s.notifyDependents(event)
+}
+
+// componentEvent builds an event for a component within an operation
+func componentEvent(eventType EventType, component *Component, correlationID string) Event {
+	return Event{
+		Type:          eventType,
+		Component:     component.key,
+		ComponentID:   component.id,
+		CorrelationID: correlationID,
+	}
+}
+
+// newCorrelationID returns a random identifier for a lifecycle operation
+func newCorrelationID() string {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return fmt.Sprintf("%016x", time.Now().UnixNano())
+	}
+	return hex.EncodeToString(b)
+}
+
+// CorrelatedError annotates a lifecycle error with the correlation ID of
+// the operation that produced it
+type CorrelatedError struct {
+	CorrelationID string
+	Err           error
+}
+
+func (e *CorrelatedError) Error() string {
+	return fmt.Sprintf("%v [correlation_id=%s]", e.Err, e.CorrelationID)
+}
+
+func (e *CorrelatedError) Unwrap() error {
+	return e.Err
+}
+
+// CorrelationIDOf returns the correlation ID attached to a lifecycle error
+func CorrelationIDOf(err error) (string, bool) {
+	var correlated *CorrelatedError
+	if errors.As(err, &correlated) {
+		return correlated.CorrelationID, true
+	}
+	return "", false
+}
+
+// correlate attaches a correlation ID to a non-nil error
+func correlate(correlationID string, err error) error {
+	if err == nil {
+		return nil
+	}
+	return &CorrelatedError{CorrelationID: correlationID, Err: err}
+}
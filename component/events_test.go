@@ -0,0 +1,70 @@
+package component
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestEventsShareCorrelationID(t *testing.T) {
+	var events []Event
+	system := CreateSystem(map[string]*Component{
+		"compA": Define("compA", &MockComponent{}),
+		"compB": Define("compB", &MockComponent{}, "compA"),
+	}, WithEventListener(func(e Event) {
+		events = append(events, e)
+	}))
+
+	if err := system.Start(); err != nil {
+		t.Fatalf("Failed to start system: %v", err)
+	}
+
+	expected := []EventType{
+		EventSystemStarting,
+		EventComponentStarting, EventComponentStarted,
+		EventComponentStarting, EventComponentStarted,
+		EventSystemStarted,
+	}
+	if len(events) != len(expected) {
+		t.Fatalf("Expected %d events, got %d: %v", len(expected), len(events), events)
+	}
+	for i, e := range events {
+		if e.Type != expected[i] {
+			t.Errorf("Event %d: expected %s, got %s", i, expected[i], e.Type)
+		}
+		if e.CorrelationID == "" || e.CorrelationID != events[0].CorrelationID {
+			t.Errorf("Event %d: expected correlation ID %q, got %q", i, events[0].CorrelationID, e.CorrelationID)
+		}
+	}
+	if events[1].ComponentID != componentID("compA") {
+		t.Errorf("Expected stable component ID for compA, got %q", events[1].ComponentID)
+	}
+}
+
+func TestComponentIDIsStable(t *testing.T) {
+	if Define("db", &MockComponent{}).ID() != Define("db", &MockComponent{}).ID() {
+		t.Error("Expected components with the same key to share an ID")
+	}
+	if Define("db", &MockComponent{}).ID() == Define("cache", &MockComponent{}).ID() {
+		t.Error("Expected components with different keys to have different IDs")
+	}
+}
+
+func TestErrorsCarryCorrelationID(t *testing.T) {
+	var failed Event
+	system := CreateSystem(map[string]*Component{
+		"compA": Define("compA", &MockComponent{StartError: errors.New("boom")}),
+	}, WithEventListener(func(e Event) {
+		if e.Type == EventComponentFailed {
+			failed = e
+		}
+	}))
+
+	err := system.Start()
+	id, ok := CorrelationIDOf(err)
+	if !ok {
+		t.Fatalf("Expected correlated error, got %v", err)
+	}
+	if id != failed.CorrelationID {
+		t.Errorf("Expected error correlation ID %q to match event %q", id, failed.CorrelationID)
+	}
+}
@@ -0,0 +1,205 @@
+package component
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ErrBudgetExceeded is reported for the steps a BudgetExecutor skipped
+var ErrBudgetExceeded = errors.New("execution budget exceeded")
+
+// Plan is an ordered lifecycle operation handed to an Executor
+type Plan struct {
+	// Op is OpStart or OpStop
+	Op string
+
+	// Steps lists component keys in an order that honours Waits
+	Steps []string
+
+	// Levels groups Steps so that every step only waits on steps of
+	// earlier levels
+	Levels [][]string
+
+	// Waits lists for each key the keys that must complete first: its
+	// dependencies when starting, its dependents when stopping
+	Waits map[string][]string
+}
+
+// StepFunc runs the step of one component
+type StepFunc func(key string) error
+
+// Executor runs lifecycle plans, letting callers plug their own scheduling
+// strategy. A start must not launch a step before its Waits completed and
+// must stop launching steps after a failure, returning it. A stop must run
+// every step, even past failures, and return every failure joined in the
+// order the steps ran: step order when running them one at a time, level by
+// level when running Levels
+type Executor interface {
+	Execute(plan Plan, run StepFunc) error
+}
+
+// WithExecutor runs starts and stops with the given executor instead of
+// the sequential one, taking precedence over WithParallelStart and
+// WithParallelStop
+func WithExecutor(executor Executor) Option {
+	return func(s *System) {
+		s.executor = executor
+	}
+}
+
+// SequentialExecutor runs one step at a time in plan order
+type SequentialExecutor struct{}
+
+// Execute runs the steps in order
+func (SequentialExecutor) Execute(plan Plan, run StepFunc) error {
+	var errs []error
+	for _, key := range plan.Steps {
+		if err := run(key); err != nil {
+			if plan.Op == OpStart {
+				return err
+			}
+			errs = append(errs, err)
+		}
+	}
+	return joinErrors(errs)
+}
+
+// ParallelExecutor runs the steps of each level concurrently, at most
+// Workers at a time, a level starting once the previous one finished. Stop
+// failures are joined level by level, in plan order within each level
+type ParallelExecutor struct {
+	Workers int
+}
+
+// Execute runs the plan level by level
+func (e ParallelExecutor) Execute(plan Plan, run StepFunc) error {
+	workers := make(chan struct{}, max(e.Workers, 1))
+
+	var stopErrs []error
+	for _, level := range plan.Levels {
+		errs := make([]error, len(level))
+		var wg sync.WaitGroup
+		for i, key := range level {
+			wg.Add(1)
+			workers <- struct{}{}
+			go func() {
+				defer wg.Done()
+				defer func() { <-workers }()
+				errs[i] = run(key)
+			}()
+		}
+		wg.Wait()
+
+		if plan.Op == OpStart {
+			if err := errors.Join(errs...); err != nil {
+				return err
+			}
+			continue
+		}
+		stopErrs = append(stopErrs, errs...)
+	}
+	return joinErrors(stopErrs)
+}
+
+// BudgetExecutor runs a plan with another executor within a time budget:
+// once Budget elapsed, steps not launched yet are skipped and reported with
+// ErrBudgetExceeded, so a boot or shutdown never overruns its allowance
+type BudgetExecutor struct {
+	Budget   time.Duration
+	Executor Executor
+}
+
+// Execute runs the plan until the budget is spent
+func (e BudgetExecutor) Execute(plan Plan, run StepFunc) error {
+	executor := e.Executor
+	if executor == nil {
+		executor = SequentialExecutor{}
+	}
+
+	deadline := time.Now().Add(e.Budget)
+	return executor.Execute(plan, func(key string) error {
+		if time.Now().After(deadline) {
+			return &ComponentError{Key: key, Op: plan.Op, Err: fmt.Errorf("%w after %v", ErrBudgetExceeded, e.Budget)}
+		}
+		return run(key)
+	})
+}
+
+// startExecutor returns the executor of starts
+func (s *System) startExecutor() Executor {
+	workers := s.effectiveRuntime().ParallelStart
+	switch {
+	case s.executor != nil:
+		return s.executor
+	case workers > 1:
+		return ParallelExecutor{Workers: workers}
+	default:
+		return SequentialExecutor{}
+	}
+}
+
+// stopExecutor returns the executor of stops
+func (s *System) stopExecutor() Executor {
+	workers := s.effectiveRuntime().ParallelStop
+	switch {
+	case s.executor != nil:
+		return s.executor
+	case workers > 1:
+		return ParallelExecutor{Workers: workers}
+	default:
+		return SequentialExecutor{}
+	}
+}
+
+// startPlan describes starting ordered components; the caller must hold s.mu
+func (s *System) startPlan(orderedComponents []string) Plan {
+	plan := Plan{Op: OpStart, Steps: orderedComponents, Levels: s.levels(orderedComponents), Waits: make(map[string][]string)}
+	for _, name := range orderedComponents {
+		plan.Waits[name] = s.resolvedDependencies(s.components[name])
+	}
+	return plan
+}
+
+// stopPlan describes stopping the started components in the exact reverse
+// of the effective start order; the caller must hold s.mu
+func (s *System) stopPlan() Plan {
+	plan := Plan{Op: OpStop, Waits: make(map[string][]string)}
+	for i := len(s.startOrder) - 1; i >= 0; i-- {
+		plan.Steps = append(plan.Steps, s.startOrder[i])
+	}
+
+	levels := s.levels(s.startOrder)
+	for l := len(levels) - 1; l >= 0; l-- {
+		level := make([]string, 0, len(levels[l]))
+		for i := len(levels[l]) - 1; i >= 0; i-- {
+			level = append(level, levels[l][i])
+		}
+		plan.Levels = append(plan.Levels, level)
+	}
+
+	for _, name := range s.startOrder {
+		for _, dep := range s.resolvedDependencies(s.components[name]) {
+			plan.Waits[dep] = append(plan.Waits[dep], name)
+		}
+	}
+	return plan
+}
+
+// stopBatches returns the batches the stop executor runs a plan in: its
+// levels for a ParallelExecutor, and one step per batch otherwise
+func (s *System) stopBatches(plan Plan) [][]string {
+	if _, parallel := s.stopExecutor().(ParallelExecutor); parallel {
+		batches := make([][]string, len(plan.Levels))
+		for i, level := range plan.Levels {
+			batches[i] = append([]string(nil), level...)
+		}
+		return batches
+	}
+	batches := make([][]string, len(plan.Steps))
+	for i, key := range plan.Steps {
+		batches[i] = []string{key}
+	}
+	return batches
+}
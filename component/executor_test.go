@@ -0,0 +1,87 @@
+package component
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// recordingExecutor keeps the plans it ran sequentially
+type recordingExecutor struct {
+	plans []Plan
+}
+
+func (e *recordingExecutor) Execute(plan Plan, run StepFunc) error {
+	e.plans = append(e.plans, plan)
+	return SequentialExecutor{}.Execute(plan, run)
+}
+
+func TestCustomExecutorReceivesPlans(t *testing.T) {
+	silenceTestStdout(t)
+
+	executor := &recordingExecutor{}
+	system := CreateSystem(map[string]*Component{
+		"db":    Define("db", &MockComponent{}),
+		"cache": Define("cache", &MockComponent{}),
+		"api":   Define("api", &MockComponent{}, "db", "cache"),
+	}, WithExecutor(executor), WithParallelStart(4))
+	if err := system.Start(); err != nil {
+		t.Fatalf("Failed to start system: %v", err)
+	}
+	if err := system.Stop(); err != nil {
+		t.Fatalf("Failed to stop system: %v", err)
+	}
+
+	if len(executor.plans) != 2 {
+		t.Fatalf("Expected a start and a stop plan, got %d", len(executor.plans))
+	}
+	start, stop := executor.plans[0], executor.plans[1]
+	if start.Op != OpStart || !reflect.DeepEqual(start.Levels, [][]string{{"cache", "db"}, {"api"}}) {
+		t.Errorf("Unexpected start plan %+v", start)
+	}
+	if !reflect.DeepEqual(start.Waits["api"], []string{"db", "cache"}) {
+		t.Errorf("Expected api to wait on its dependencies, got %v", start.Waits["api"])
+	}
+	if stop.Op != OpStop || !reflect.DeepEqual(stop.Steps, []string{"api", "db", "cache"}) || !reflect.DeepEqual(stop.Waits["db"], []string{"api"}) {
+		t.Errorf("Unexpected stop plan %+v", stop)
+	}
+	if !reflect.DeepEqual(stop.Levels, [][]string{{"api"}, {"db", "cache"}}) {
+		t.Errorf("Expected stop levels from the deepest, got %v", stop.Levels)
+	}
+}
+
+func TestBudgetExecutorSkipsStepsPastBudget(t *testing.T) {
+	plan := Plan{Op: OpStart, Steps: []string{"slow", "next"}}
+	var ran []string
+	err := BudgetExecutor{Budget: 5 * time.Millisecond}.Execute(plan, func(key string) error {
+		ran = append(ran, key)
+		time.Sleep(10 * time.Millisecond)
+		return nil
+	})
+
+	var componentErr *ComponentError
+	if !errors.Is(err, ErrBudgetExceeded) || !errors.As(err, &componentErr) || componentErr.Key != "next" {
+		t.Errorf("Expected next skipped past the budget, got %v", err)
+	}
+	if !reflect.DeepEqual(ran, []string{"slow"}) {
+		t.Errorf("Expected only the first step to run, got %v", ran)
+	}
+}
+
+func TestExecutorsKeepStoppingPastFailures(t *testing.T) {
+	plan := Plan{Op: OpStop, Steps: []string{"a", "b", "c"}, Levels: [][]string{{"a", "b"}, {"c"}}}
+	for name, executor := range map[string]Executor{"sequential": SequentialExecutor{}, "parallel": ParallelExecutor{Workers: 2}} {
+		ran := make(chan string, 3)
+		err := executor.Execute(plan, func(key string) error {
+			ran <- key
+			if key != "c" {
+				return errors.New(key)
+			}
+			return nil
+		})
+		if len(ran) != 3 || err == nil || err.Error() != "a\nb" {
+			t.Errorf("%s: expected every step run and both failures, got %d steps and %v", name, len(ran), err)
+		}
+	}
+}
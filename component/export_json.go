@@ -0,0 +1,128 @@
+package component
+
+import (
+	"io"
+)
+
+// GraphSchemaVersion is the version of the ExportJSON document. It changes
+// only when a field is removed or changes meaning; new fields may be added
+const GraphSchemaVersion = 1
+
+// GraphJSONSchema is the JSON Schema of the ExportJSON document
+const GraphJSONSchema = `{
+  "$schema": "https://json-schema.org/draft/2020-12/schema",
+  "title": "go-dependency-graph export",
+  "type": "object",
+  "required": ["schema_version", "started", "components", "edges"],
+  "properties": {
+    "schema_version": {"const": 1},
+    "started": {"type": "boolean"},
+    "components": {
+      "type": "array",
+      "items": {
+        "type": "object",
+        "required": ["key", "id", "state", "dependencies"],
+        "properties": {
+          "key": {"type": "string"},
+          "id": {"type": "string"},
+          "state": {"enum": ["not_started", "starting", "started", "failed", "stopping", "stopped", "degraded", "completed", "quarantined"]},
+          "dependencies": {"type": "array", "items": {"type": "string"}},
+          "tags": {"type": "array", "items": {"type": "string"}},
+          "one_shot": {"type": "boolean"},
+          "description": {"type": "string"},
+          "owner": {"type": "string"},
+          "start_duration_ns": {"type": "integer", "minimum": 0},
+          "stop_duration_ns": {"type": "integer", "minimum": 0},
+          "error": {"type": "string"}
+        }
+      }
+    },
+    "edges": {
+      "type": "array",
+      "items": {
+        "type": "object",
+        "required": ["from", "to"],
+        "properties": {
+          "from": {"type": "string"},
+          "to": {"type": "string"}
+        }
+      }
+    }
+  }
+}
+`
+
+// GraphExport is the document written by ExportJSON, described by GraphJSONSchema
+type GraphExport struct {
+	SchemaVersion int              `json:"schema_version"`
+	Started       bool             `json:"started"`
+	Components    []GraphComponent `json:"components"`
+	Edges         []GraphEdge      `json:"edges"`
+}
+
+// GraphComponent is one component of a GraphExport. Durations are those of
+// the last successful start and stop, omitted until one happened
+type GraphComponent struct {
+	Key           string   `json:"key"`
+	ID            string   `json:"id"`
+	State         State    `json:"state"`
+	Dependencies  []string `json:"dependencies"`
+	Tags          []string `json:"tags,omitempty"`
+	OneShot       bool     `json:"one_shot,omitempty"`
+	Description   string   `json:"description,omitempty"`
+	Owner         string   `json:"owner,omitempty"`
+	StartDuration int64    `json:"start_duration_ns,omitempty"`
+	StopDuration  int64    `json:"stop_duration_ns,omitempty"`
+	Error         string   `json:"error,omitempty"`
+}
+
+// GraphEdge records that From depends on To
+type GraphEdge struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// ExportJSON writes the component graph with states and start durations as
+// a GraphExport, components and edges sorted by key
+func (s *System) ExportJSON(w io.Writer) error {
+	return s.ExportGraph(w, JSONEncoder{Indent: "  "})
+}
+
+// GraphExport returns the document ExportJSON writes. It is built from the
+// published graph, so it never waits for an in-flight lifecycle operation
+func (s *System) GraphExport() GraphExport {
+	snapshot := s.snapshot()
+	s.shared.Lock()
+	timings := make(map[string]componentTimings, len(s.timings))
+	for key, timing := range s.timings {
+		timings[key] = timing
+	}
+	s.shared.Unlock()
+
+	export := GraphExport{SchemaVersion: GraphSchemaVersion, Started: s.started.Load(), Components: []GraphComponent{}, Edges: []GraphEdge{}}
+	for _, key := range snapshot.sortedKeys() {
+		component := snapshot.components[key]
+		metadata := component.GetMetadata()
+		dependencies := snapshot.dependencies[key]
+		exported := GraphComponent{
+			Key:           key,
+			ID:            component.id,
+			State:         component.State(),
+			Dependencies:  append([]string{}, dependencies...),
+			Tags:          component.GetTags(),
+			OneShot:       component.IsOneShot(),
+			Description:   metadata.Description,
+			Owner:         metadata.Owner,
+			StartDuration: int64(timings[key].start),
+			StopDuration:  int64(timings[key].stop),
+		}
+		if err := component.LastError(); err != nil {
+			exported.Error = err.Error()
+		}
+		export.Components = append(export.Components, exported)
+		for _, dep := range dependencies {
+			export.Edges = append(export.Edges, GraphEdge{From: key, To: dep})
+		}
+	}
+	return export
+}
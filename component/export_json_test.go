@@ -0,0 +1,55 @@
+package component
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+)
+
+func TestExportJSON(t *testing.T) {
+	silenceTestStdout(t)
+
+	system := CreateSystem(map[string]*Component{
+		"db":  Define("db", &MockComponent{}).WithMetadata(Metadata{Owner: "storage-team"}),
+		"api": Define("api", &MockComponent{}, "db").WithTags("http"),
+	})
+	if err := system.Start(); err != nil {
+		t.Fatalf("Failed to start system: %v", err)
+	}
+	defer system.Stop()
+
+	var buf bytes.Buffer
+	if err := system.ExportJSON(&buf); err != nil {
+		t.Fatalf("Expected export to succeed, got %v", err)
+	}
+
+	var export GraphExport
+	if err := json.Unmarshal(buf.Bytes(), &export); err != nil {
+		t.Fatalf("Expected valid JSON, got %v", err)
+	}
+	if export.SchemaVersion != GraphSchemaVersion || !export.Started || len(export.Components) != 2 {
+		t.Fatalf("Unexpected export %+v", export)
+	}
+	api, db := export.Components[0], export.Components[1]
+	if api.Key != "api" || api.State != StateStarted || len(api.Dependencies) != 1 || api.Dependencies[0] != "db" || api.Tags[0] != "http" {
+		t.Errorf("Unexpected api entry %+v", api)
+	}
+	if db.Owner != "storage-team" || db.StartDuration <= 0 || len(db.Dependencies) != 0 {
+		t.Errorf("Unexpected db entry %+v", db)
+	}
+	if len(export.Edges) != 1 || export.Edges[0] != (GraphEdge{From: "api", To: "db"}) {
+		t.Errorf("Unexpected edges %+v", export.Edges)
+	}
+
+	// The document uses the names documented by the schema
+	var raw map[string]interface{}
+	json.Unmarshal(buf.Bytes(), &raw)
+	component := raw["components"].([]interface{})[0].(map[string]interface{})
+	if component["state"] != "started" || component["dependencies"] == nil {
+		t.Errorf("Expected state by name and dependencies always present, got %v", component)
+	}
+	var schema map[string]interface{}
+	if err := json.Unmarshal([]byte(GraphJSONSchema), &schema); err != nil {
+		t.Errorf("Expected the schema to be valid JSON, got %v", err)
+	}
+}
@@ -0,0 +1,162 @@
+package component
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"time"
+)
+
+// ResolverContextKey holds the live dependency resolver of a component in
+// its Context
+const ResolverContextKey = ReservedPrefix + "resolver"
+
+const (
+	// EventFallbackEngaged is emitted when a Handle switches from its
+	// primary dependency to the fallback
+	EventFallbackEngaged EventType = "fallback_engaged"
+
+	// EventFallbackReleased is emitted when a Handle switches back to its
+	// primary dependency
+	EventFallbackReleased EventType = "fallback_released"
+)
+
+// ErrDependencyUnavailable is returned by Handle.Resolve when neither the
+// primary dependency nor its fallback became available in time
+var ErrDependencyUnavailable = errors.New("dependency unavailable")
+
+// handlePollInterval is how often Handle.Resolve checks the dependencies
+// while waiting for one of them
+const handlePollInterval = 5 * time.Millisecond
+
+// resolver reads the current results of a component's dependencies from
+// the system running it
+type resolver struct {
+	system    *System
+	component *Component
+}
+
+func (r *resolver) Start(ctx Context) (Lifecycle, error) {
+	return r, nil
+}
+
+func (r *resolver) Stop(ctx Context) error {
+	return nil
+}
+
+// Handle is a dependency lookup with a fallback provider, such as a cache
+// standing in for a database, used while the primary component is
+// degraded, restarting or stopped. It reads the current result of the
+// dependencies on every call, so it follows restarts, and it emits
+// EventFallbackEngaged and EventFallbackReleased on transitions. Declare
+// both keys as dependencies so they start first
+type Handle struct {
+	primary  string
+	fallback string
+	resolver *resolver
+	ctx      Context
+	engaged  atomic.Bool
+}
+
+// Handle returns a lookup of primary falling back to fallback. Outside a
+// System it returns the entries of the context without watching states
+func (ctx Context) Handle(primary, fallback string) *Handle {
+	r, _ := ctx[ResolverContextKey].(*resolver)
+	return &Handle{primary: primary, fallback: fallback, resolver: r, ctx: ctx}
+}
+
+// Get returns the primary dependency when it is started, the fallback when
+// only the fallback is, and the primary otherwise
+func (h *Handle) Get() Lifecycle {
+	value, _ := h.current()
+	return value
+}
+
+// Resolve returns like Get, but when neither dependency is started and the
+// primary is not degraded either it
+// waits for one of them until ctx is done, returning
+// ErrDependencyUnavailable wrapped with the context error
+func (h *Handle) Resolve(ctx context.Context) (Lifecycle, error) {
+	if value, ok := h.current(); ok {
+		return value, nil
+	}
+
+	ticker := time.NewTicker(handlePollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("%w: %s and fallback %s: %w", ErrDependencyUnavailable, h.primary, h.fallback, ctx.Err())
+		case <-ticker.C:
+			if value, ok := h.current(); ok {
+				return value, nil
+			}
+		}
+	}
+}
+
+// UsingFallback reports whether the last lookup returned the fallback
+func (h *Handle) UsingFallback() bool {
+	return h.engaged.Load()
+}
+
+// current picks the dependency to use and reports whether it is available
+func (h *Handle) current() (Lifecycle, bool) {
+	if h.resolver == nil {
+		if value, ok := h.ctx[h.primary]; ok {
+			return value, true
+		}
+		value, ok := h.ctx[h.fallback]
+		return value, ok
+	}
+
+	if state, ok := h.resolver.state(h.primary); ok && state == StateStarted {
+		h.transition(false, state)
+		return h.resolver.system.published(h.resolver.qualified(h.primary)), true
+	}
+	state, _ := h.resolver.state(h.primary)
+	if fallbackState, ok := h.resolver.state(h.fallback); ok && fallbackState == StateStarted {
+		h.transition(true, state)
+		return h.resolver.system.published(h.resolver.qualified(h.fallback)), true
+	}
+
+	// A degraded primary still serves when the fallback cannot
+	h.transition(false, state)
+	return h.resolver.system.published(h.resolver.qualified(h.primary)), state == StateDegraded
+}
+
+// transition records whether the fallback is in use, emitting an event
+// when that changed
+func (h *Handle) transition(engaged bool, primaryState State) {
+	if h.engaged.Swap(engaged) == engaged {
+		return
+	}
+
+	event := componentEvent(EventFallbackReleased, h.resolver.component, "")
+	event.Dependency = h.primary
+	if engaged {
+		event.Type = EventFallbackEngaged
+		event.Err = fmt.Errorf("%s is %s, using %s", h.primary, primaryState, h.fallback)
+	}
+	h.resolver.system.emit(event)
+}
+
+// qualified maps a key local to the component's module to its system key
+func (r *resolver) qualified(key string) string {
+	return qualifiedKey(r.component.aliases, key)
+}
+
+// state returns the state of the component providing key
+func (r *resolver) state(key string) (State, bool) {
+	snapshot := r.system.snapshot()
+	key = r.qualified(key)
+	if provider, ok := snapshot.providers[key]; ok {
+		key = provider
+	}
+	component, ok := snapshot.components[key]
+	if !ok {
+		return 0, false
+	}
+	return component.State(), true
+}
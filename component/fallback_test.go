@@ -0,0 +1,102 @@
+package component
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// HandleHolder keeps a fallback handle from its Start
+type HandleHolder struct {
+	MockComponent
+	Handle *Handle
+}
+
+func (h *HandleHolder) Start(ctx Context) (Lifecycle, error) {
+	h.Handle = ctx.Handle("db", "cache")
+	return h.MockComponent.Start(ctx)
+}
+
+func TestHandleFallsBackWhilePrimaryDown(t *testing.T) {
+	db := &MockComponent{Key: "db"}
+	cache := &MockComponent{Key: "cache"}
+	api := &HandleHolder{}
+	var events []Event
+	system := CreateSystem(map[string]*Component{
+		"db":    Define("db", db),
+		"cache": Define("cache", cache),
+		"api":   Define("api", api, "db", "cache"),
+	}, WithEventListener(func(event Event) {
+		if event.Type == EventFallbackEngaged || event.Type == EventFallbackReleased {
+			events = append(events, event)
+		}
+	}))
+	if err := system.Start(); err != nil {
+		t.Fatalf("Failed to start system: %v", err)
+	}
+	handle := api.Handle
+
+	if handle.Get() != Lifecycle(db) || handle.UsingFallback() {
+		t.Fatal("Expected the primary while it is started")
+	}
+
+	system.Degrade("db", errors.New("replica lag"))
+	if handle.Get() != Lifecycle(cache) || !handle.UsingFallback() {
+		t.Fatal("Expected the fallback while the primary is degraded")
+	}
+	handle.Get()
+
+	system.Recover("db")
+	if handle.Get() != Lifecycle(db) || handle.UsingFallback() {
+		t.Fatal("Expected the primary once recovered")
+	}
+
+	if len(events) != 2 || events[0].Type != EventFallbackEngaged || events[1].Type != EventFallbackReleased {
+		t.Fatalf("Expected one engaged and one released event, got %v", events)
+	}
+	if events[0].Component != "api" || events[0].Dependency != "db" || events[0].Err.Error() != "db is degraded, using cache" {
+		t.Errorf("Expected the engaged event to describe the switch, got %+v", events[0])
+	}
+}
+
+func TestHandleResolveWaitsForADependency(t *testing.T) {
+	api := &HandleHolder{}
+	system := CreateSystem(map[string]*Component{
+		"db":    Define("db", &MockComponent{}),
+		"cache": Define("cache", &MockComponent{}),
+		"api":   Define("api", api, "db", "cache"),
+	})
+	if err := system.Start(); err != nil {
+		t.Fatalf("Failed to start system: %v", err)
+	}
+
+	for _, key := range []string{"db", "cache"} {
+		component, _ := system.Component(key)
+		component.transition(StateStopping)
+		component.transition(StateStopped)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := api.Handle.Resolve(ctx); !errors.Is(err, ErrDependencyUnavailable) || !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Expected the dependencies to be unavailable, got %v", err)
+	}
+
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		cache, _ := system.Component("cache")
+		cache.transition(StateStarting)
+		cache.transition(StateStarted)
+	}()
+	value, err := api.Handle.Resolve(context.Background())
+	if err != nil || !api.Handle.UsingFallback() {
+		t.Fatalf("Expected the fallback once it started, got %v %v", value, err)
+	}
+}
+
+func TestHandleOutsideSystem(t *testing.T) {
+	cache := &MockComponent{}
+	if got := (Context{"cache": cache}).Handle("db", "cache").Get(); got != Lifecycle(cache) {
+		t.Errorf("Expected the fallback entry, got %v", got)
+	}
+}
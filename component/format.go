@@ -0,0 +1,157 @@
+package component
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Format renders the component: %v and %s give its key and state, %+v every
+// attribute on one line and %q the quoted key
+func (c *Component) Format(f fmt.State, verb rune) {
+	switch {
+	case verb == 'q':
+		io.WriteString(f, strconv.Quote(c.key))
+	case verb == 'v' && f.Flag('+'):
+		io.WriteString(f, c.describe())
+	default:
+		fmt.Fprintf(f, "%s (%s)", c.key, c.State())
+	}
+}
+
+// describe renders every attribute of the component
+func (c *Component) describe() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s [id=%s state=%s", c.key, c.id, c.State())
+	if deps := c.GetDependencies(); len(deps) > 0 {
+		fmt.Fprintf(&b, " dependencies=%v", deps)
+	}
+	if provides := c.GetProvides(); len(provides) > 0 {
+		fmt.Fprintf(&b, " provides=%v", provides)
+	}
+	if tags := c.GetTags(); len(tags) > 0 {
+		fmt.Fprintf(&b, " tags=%v", tags)
+	}
+	if owner := c.GetMetadata().Owner; owner != "" {
+		fmt.Fprintf(&b, " owner=%s", owner)
+	}
+	if c.IsOneShot() {
+		b.WriteString(" one_shot")
+	}
+	if err := c.LastError(); err != nil {
+		fmt.Fprintf(&b, " error=%q", err.Error())
+	}
+	b.WriteString("]")
+	return b.String()
+}
+
+// Format renders the system: %v and %s give a one-line summary, %+v adds a
+// line per component in graph order with its state, dependencies and last
+// error. It never waits for an in-flight lifecycle operation
+func (s *System) Format(f fmt.State, verb rune) {
+	snapshot := s.snapshot()
+	status := "stopped"
+	if s.started.Load() {
+		status = "started"
+	}
+	fmt.Fprintf(f, "system (%d components, %s)", len(snapshot.components), status)
+	if verb != 'v' || !f.Flag('+') {
+		return
+	}
+
+	order := snapshot.order
+	if len(order) == 0 {
+		order = snapshot.sortedKeys()
+	}
+	width := 0
+	for _, key := range order {
+		width = max(width, len(key))
+	}
+	for _, key := range order {
+		component := snapshot.components[key]
+		var line strings.Builder
+		fmt.Fprintf(&line, "\n  %-*s  %-11s", width, key, component.State())
+		if deps := snapshot.dependencies[key]; len(deps) > 0 {
+			fmt.Fprintf(&line, "  <- %s", strings.Join(deps, ", "))
+		}
+		if err := component.LastError(); err != nil {
+			fmt.Fprintf(&line, "  error: %v", err)
+		}
+		io.WriteString(f, strings.TrimRight(line.String(), " "))
+	}
+}
+
+// Format renders the topology: %v and %s give its size, %+v a line per node
+// with its state and dependencies
+func (t Topology) Format(f fmt.State, verb rune) {
+	fmt.Fprintf(f, "topology (%d components, %d edges)", len(t.Nodes), len(t.Edges))
+	if verb != 'v' || !f.Flag('+') {
+		return
+	}
+
+	for _, node := range t.Nodes {
+		fmt.Fprintf(f, "\n  %s %s", node.Key, node.State)
+		var deps []string
+		for _, edge := range t.Edges {
+			if edge.From == node.Key {
+				deps = append(deps, edge.To)
+			}
+		}
+		if len(deps) > 0 {
+			fmt.Fprintf(f, " <- %s", strings.Join(deps, ", "))
+		}
+	}
+}
+
+// errorField is a labelled attribute of an error rendered by %+v
+type errorField struct {
+	name  string
+	value interface{}
+}
+
+// formatError renders an error: %v and %s give its message, %q the quoted
+// message and %+v the message followed by its fields and, indented, the
+// detailed rendering of its cause
+func formatError(f fmt.State, verb rune, err error, cause error, fields ...errorField) {
+	switch {
+	case verb == 'q':
+		io.WriteString(f, strconv.Quote(err.Error()))
+	case verb == 'v' && f.Flag('+'):
+		var b strings.Builder
+		b.WriteString(err.Error())
+		for _, field := range fields {
+			fmt.Fprintf(&b, "\n  %s: %v", field.name, field.value)
+		}
+		if cause != nil {
+			writeIndented(&b, fmt.Sprintf("%+v", cause), "\n  cause: ", "  ")
+		}
+		io.WriteString(f, strings.TrimSuffix(b.String(), "\n"))
+	default:
+		io.WriteString(f, err.Error())
+	}
+}
+
+func (e *ComponentError) Format(f fmt.State, verb rune) {
+	formatError(f, verb, e, e.Err, errorField{"component", e.Key}, errorField{"op", e.Op})
+}
+
+func (e *CorrelatedError) Format(f fmt.State, verb rune) {
+	formatError(f, verb, e, e.Err, errorField{"correlation_id", e.CorrelationID})
+}
+
+func (e *StartDeadlineError) Format(f fmt.State, verb rune) {
+	formatError(f, verb, e, e.Err, errorField{"in_flight", e.InFlight}, errorField{"elapsed", e.Elapsed})
+}
+
+func (e *TopologyGuardError) Format(f fmt.State, verb rune) {
+	formatError(f, verb, e, e.Cycle, errorField{"hash", e.Hash}, errorField{"last_hash", e.LastHash}, errorField{"removed", e.Removed})
+}
+
+func (e *ProbeError) Format(f fmt.State, verb rune) {
+	formatError(f, verb, e, e.Err, errorField{"component", e.Key})
+}
+
+func (e *EnvError) Format(f fmt.State, verb rune) {
+	formatError(f, verb, e, e.Err, errorField{"variable", e.Name})
+}
@@ -0,0 +1,71 @@
+package component
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestComponentFormat(t *testing.T) {
+	c := Define("api", &MockComponent{}, "db").Provides("http").WithTags("edge")
+
+	if got := fmt.Sprintf("%v", c); got != "api (not_started)" {
+		t.Errorf("Expected a concise rendering, got %q", got)
+	}
+	if got := fmt.Sprintf("%q", c); got != `"api"` {
+		t.Errorf("Expected the quoted key, got %s", got)
+	}
+	want := "api [id=" + c.ID() + " state=not_started dependencies=[db] provides=[http] tags=[edge]]"
+	if got := fmt.Sprintf("%+v", c); got != want {
+		t.Errorf("Expected %q, got %q", want, got)
+	}
+}
+
+func TestSystemFormat(t *testing.T) {
+	system := CreateSystem(map[string]*Component{
+		"db":  Define("db", &MockComponent{}),
+		"api": Define("api", &MockComponent{StartError: errors.New("port in use")}, "db"),
+	})
+	system.Start()
+
+	if got := fmt.Sprintf("%v", system); got != "system (2 components, stopped)" {
+		t.Errorf("Expected a summary, got %q", got)
+	}
+	want := "system (2 components, stopped)\n" +
+		"  db   started\n" +
+		"  api  failed       <- db  error: failed to start component: port in use"
+	if got := fmt.Sprintf("%+v", system); got != want {
+		t.Errorf("Expected:\n%s\ngot:\n%s", want, got)
+	}
+
+	topology := system.Topology()
+	if got := fmt.Sprintf("%v", topology); got != "topology (2 components, 1 edges)" {
+		t.Errorf("Expected the topology size, got %q", got)
+	}
+	if got := fmt.Sprintf("%+v", topology); !strings.HasSuffix(got, "\n  api failed <- db\n  db started") {
+		t.Errorf("Expected a line per node, got %q", got)
+	}
+}
+
+func TestErrorFormat(t *testing.T) {
+	err := correlate("abc", &ComponentError{Key: "db", Op: OpStart, Err: &EnvError{Name: "DB_POOL", Err: errors.New("not a number")}})
+
+	if got := fmt.Sprintf("%v", err); got != err.Error() {
+		t.Errorf("Expected %%v to be the message, got %q", got)
+	}
+	want := err.Error() + "\n" +
+		"  correlation_id: abc\n" +
+		"  cause: failed to start component db: invalid value for DB_POOL: not a number\n" +
+		"    component: db\n" +
+		"    op: start\n" +
+		"    cause: invalid value for DB_POOL: not a number\n" +
+		"      variable: DB_POOL\n" +
+		"      cause: not a number"
+	if got := fmt.Sprintf("%+v", err); got != want {
+		t.Errorf("Expected:\n%s\ngot:\n%s", want, got)
+	}
+	if got := fmt.Sprintf("%q", &ProbeError{Key: "db", Err: ErrNotRunning}); got != `"component db: not running"` {
+		t.Errorf("Expected the quoted message, got %s", got)
+	}
+}
@@ -0,0 +1,93 @@
+package component
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+const (
+	EventGateWaiting EventType = "gate_waiting"
+	EventGateOpened  EventType = "gate_opened"
+	EventGateFailed  EventType = "gate_failed"
+)
+
+// StartGate holds back a component's Start until an external condition is
+// met, such as a distributed lock being acquired or a migration finishing
+type StartGate interface {
+	// Wait blocks until the component may start or the context is done
+	Wait(ctx context.Context, key string) error
+}
+
+// StartGateFunc adapts a function to the StartGate interface
+type StartGateFunc func(ctx context.Context, key string) error
+
+// Wait calls f(ctx, key)
+func (f StartGateFunc) Wait(ctx context.Context, key string) error {
+	return f(ctx, key)
+}
+
+// gateRule binds a gate to the components it applies to
+type gateRule struct {
+	gate    StartGate
+	timeout time.Duration
+	keys    []string
+}
+
+// applies reports whether the rule gates the given component key
+func (r gateRule) applies(key string) bool {
+	if len(r.keys) == 0 {
+		return true
+	}
+	for _, k := range r.keys {
+		if k == key || (isPattern(k) && matchesPattern(k, key)) {
+			return true
+		}
+	}
+	return false
+}
+
+// WithStartGate gates the Start of the given components (or every component
+// when no keys are given) behind gate. A zero timeout waits indefinitely.
+// Keys may be prefix patterns such as "workers/*"
+func WithStartGate(gate StartGate, timeout time.Duration, keys ...string) Option {
+	return func(s *System) {
+		s.gates = append(s.gates, gateRule{gate: gate, timeout: timeout, keys: keys})
+	}
+}
+
+// waitGates blocks until every gate applying to the component opens
+func (s *System) waitGates(component *Component, correlationID string) error {
+	for _, rule := range s.gates {
+		if !rule.applies(component.key) {
+			continue
+		}
+
+		ctx := s.bootContext()
+		cancel := func() {}
+		if rule.timeout > 0 {
+			ctx, cancel = context.WithTimeout(ctx, rule.timeout)
+		}
+
+		s.emit(componentEvent(EventGateWaiting, component, correlationID))
+		waitTime := time.Now()
+		err := rule.gate.Wait(ctx, component.key)
+		if err == nil && ctx.Err() != nil {
+			err = ctx.Err()
+		}
+		cancel()
+
+		event := componentEvent(EventGateOpened, component, correlationID)
+		event.Duration = time.Since(waitTime)
+		if err != nil {
+			event.Type = EventGateFailed
+			event.Err = err
+		}
+		s.emit(event)
+
+		if err != nil {
+			return fmt.Errorf("start gate did not open: %w", err)
+		}
+	}
+	return nil
+}
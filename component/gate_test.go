@@ -0,0 +1,60 @@
+package component
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestStartGateHoldsComponent(t *testing.T) {
+	var gated []string
+	gate := StartGateFunc(func(ctx context.Context, key string) error {
+		gated = append(gated, key)
+		return nil
+	})
+
+	compB := &MockComponent{}
+	system := CreateSystem(map[string]*Component{
+		"compA": Define("compA", &MockComponent{}),
+		"compB": Define("compB", compB, "compA"),
+	}, WithStartGate(gate, time.Second, "compB"))
+
+	if err := system.Start(); err != nil {
+		t.Fatalf("Failed to start system: %v", err)
+	}
+	if len(gated) != 1 || gated[0] != "compB" {
+		t.Errorf("Expected only compB to be gated, got %v", gated)
+	}
+	if !compB.StartCalled {
+		t.Error("Expected compB to start once the gate opened")
+	}
+}
+
+func TestStartGateTimeout(t *testing.T) {
+	gate := StartGateFunc(func(ctx context.Context, key string) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+
+	var failed []Event
+	compA := &MockComponent{}
+	system := CreateSystem(map[string]*Component{
+		"compA": Define("compA", compA),
+	}, WithStartGate(gate, 10*time.Millisecond), WithEventListener(func(e Event) {
+		if e.Type == EventGateFailed {
+			failed = append(failed, e)
+		}
+	}))
+
+	err := system.Start()
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Expected deadline exceeded, got %v", err)
+	}
+	if compA.StartCalled {
+		t.Error("Component should not start when its gate times out")
+	}
+	if len(failed) != 1 || failed[0].Component != "compA" {
+		t.Errorf("Expected one gate_failed event for compA, got %v", failed)
+	}
+}
@@ -0,0 +1,94 @@
+package component
+
+import "sort"
+
+// graphSnapshot is the validated graph published for readers that must not
+// wait for an in-flight lifecycle operation, such as health endpoints
+type graphSnapshot struct {
+	components   map[string]*Component
+	dependencies map[string][]string
+	order        []string
+
+	// contextKeys are the keys each component finds in its Context and
+	// providers maps every published key to its component
+	contextKeys map[string][]string
+	providers   map[string]string
+}
+
+// publishGraph records the graph the system currently runs, leaving the
+// disabled components out of its order so probes and health reports do not
+// wait for them; the caller must hold s.mu and the providers must be built
+func (s *System) publishGraph(order []string) {
+	snapshot := &graphSnapshot{
+		components:   s.components,
+		dependencies: make(map[string][]string, len(s.components)),
+		order:        make([]string, 0, len(order)),
+		contextKeys:  make(map[string][]string, len(s.components)),
+		providers:    s.providers,
+	}
+	for _, name := range order {
+		if !s.skipped[name] {
+			snapshot.order = append(snapshot.order, name)
+		}
+	}
+	for name, component := range s.components {
+		snapshot.dependencies[name] = s.resolvedDependencies(component)
+		snapshot.contextKeys[name] = s.dependencyKeys(component)
+	}
+	s.graph.Store(snapshot)
+}
+
+// snapshot returns the last published graph, or one built from the declared
+// dependencies when the system was never validated
+func (s *System) snapshot() *graphSnapshot {
+	if snapshot := s.graph.Load(); snapshot != nil {
+		return snapshot
+	}
+	return s.declaredSnapshot()
+}
+
+// declaredSnapshot builds a snapshot from the declared dependencies of the
+// components; the caller must hold s.mu unless the system is not shared yet
+func (s *System) declaredSnapshot() *graphSnapshot {
+	snapshot := &graphSnapshot{
+		components:   s.components,
+		dependencies: make(map[string][]string, len(s.components)),
+		order:        s.sortedKeys(),
+		contextKeys:  make(map[string][]string, len(s.components)),
+	}
+	for name, component := range s.components {
+		snapshot.dependencies[name] = component.GetDependencies()
+		snapshot.contextKeys[name] = component.GetDependencies()
+	}
+	return snapshot
+}
+
+// sortedKeys returns the keys of the snapshot's components in sorted order
+func (snapshot *graphSnapshot) sortedKeys() []string {
+	keys := make([]string, 0, len(snapshot.components))
+	for key := range snapshot.components {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// topology returns the snapshot's graph with the current state of every
+// component, like System.Topology
+func (snapshot *graphSnapshot) topology() Topology {
+	var topology Topology
+	for _, key := range snapshot.sortedKeys() {
+		component := snapshot.components[key]
+		topology.Nodes = append(topology.Nodes, TopologyNode{
+			Key:      key,
+			ID:       component.id,
+			State:    component.State(),
+			Tags:     component.GetTags(),
+			Metadata: component.GetMetadata(),
+		})
+		for _, dep := range snapshot.dependencies[key] {
+			topology.Edges = append(topology.Edges, TopologyEdge{From: key, To: dep})
+		}
+	}
+	return topology
+}
@@ -0,0 +1,147 @@
+package component
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// EventTopologyGuardOverridden is emitted when a forced start goes ahead
+// despite a dangerous topology
+const EventTopologyGuardOverridden EventType = "topology_guard_overridden"
+
+// TopologyGuardError reports a boot refused because the topology lost
+// components others still reference, or gained a cycle, compared with the
+// last topology that started successfully
+type TopologyGuardError struct {
+	LastHash string
+	Hash     string
+
+	// Removed lists the components of the last good topology that are gone
+	Removed []string
+
+	// Dangling lists the edges from remaining components to removed ones
+	Dangling []TopologyEdge
+
+	// Cycle is set when the new topology has a cyclic dependency
+	Cycle error
+}
+
+func (e *TopologyGuardError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "topology %s is unsafe compared with last good topology %s", e.Hash, e.LastHash)
+	for _, edge := range e.Dangling {
+		fmt.Fprintf(&b, "; %s still references removed %s", edge.From, edge.To)
+	}
+	if e.Cycle != nil {
+		fmt.Fprintf(&b, "; %v", e.Cycle)
+	}
+	return b.String()
+}
+
+// WithTopologyGuard saves the topology of every successful start in store
+// and refuses to start a topology that removes components still referenced
+// or introduces a cycle, returning a TopologyGuardError with the diff. With
+// force the start goes ahead and an EventTopologyGuardOverridden is emitted
+func WithTopologyGuard(store DesiredStateStore, force bool) Option {
+	return func(s *System) {
+		s.guardStore = store
+		s.guardForce = force
+	}
+}
+
+// TopologyHash identifies a topology by its components and dependencies
+func TopologyHash(topology DesiredTopology) string {
+	keys := make([]string, 0, len(topology))
+	for key := range topology {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	h := sha256.New()
+	for _, key := range keys {
+		fmt.Fprintf(h, "%s<-%s\n", key, strings.Join(topology[key].Dependencies, ","))
+	}
+	return hex.EncodeToString(h.Sum(nil))[:16]
+}
+
+// resolvedTopology records each component with its resolved dependencies;
+// the caller must hold s.mu and the providers must be built
+func (s *System) resolvedTopology() DesiredTopology {
+	topology := make(DesiredTopology, len(s.components))
+	for key, component := range s.components {
+		topology[key] = DesiredComponent{Dependencies: s.resolvedDependencies(component), State: component.State()}
+	}
+	return topology
+}
+
+// guardTopology compares the topology about to start with the last good
+// one; the caller must hold s.mu
+func (s *System) guardTopology(correlationID string) error {
+	if s.guardStore == nil {
+		return nil
+	}
+	last, found, err := s.guardStore.LoadDesired()
+	if err != nil {
+		return fmt.Errorf("failed to load last good topology: %w", err)
+	}
+	if !found {
+		return nil
+	}
+	if err := s.buildProviders(); err != nil {
+		return err
+	}
+
+	current := s.resolvedTopology()
+	guardErr := &TopologyGuardError{LastHash: TopologyHash(last), Hash: TopologyHash(current), Cycle: s.checkCyclicDependencies()}
+	removed := make(map[string]bool)
+	for key := range last {
+		if _, exists := current[key]; !exists {
+			removed[key] = true
+			guardErr.Removed = append(guardErr.Removed, key)
+		}
+	}
+	sort.Strings(guardErr.Removed)
+
+	for _, key := range s.sortedKeys() {
+		// Edges of the last good graph cover removed prefix matches and
+		// provided keys; declared names cover direct references
+		references := append([]string(nil), last[key].Dependencies...)
+		for _, dep := range s.dependencyKeys(s.components[key]) {
+			if _, exists := s.resolve(dep); !exists {
+				references = append(references, dep)
+			}
+		}
+
+		seen := make(map[string]bool)
+		for _, dep := range references {
+			if removed[dep] && !seen[dep] {
+				seen[dep] = true
+				guardErr.Dangling = append(guardErr.Dangling, TopologyEdge{From: key, To: dep})
+			}
+		}
+	}
+
+	if len(guardErr.Dangling) == 0 && guardErr.Cycle == nil {
+		return nil
+	}
+	if !s.guardForce {
+		return guardErr
+	}
+	event := Event{Type: EventTopologyGuardOverridden, CorrelationID: correlationID, Err: guardErr}
+	s.emit(event)
+	return nil
+}
+
+// saveGoodTopology records the topology that just started; the caller must hold s.mu
+func (s *System) saveGoodTopology() error {
+	if s.guardStore == nil {
+		return nil
+	}
+	if err := s.guardStore.SaveDesired(s.resolvedTopology()); err != nil {
+		return fmt.Errorf("failed to save last good topology: %w", err)
+	}
+	return nil
+}
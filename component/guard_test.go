@@ -0,0 +1,70 @@
+package component
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestTopologyGuardRefusesDanglingReferences(t *testing.T) {
+	silenceTestStdout(t)
+	store := NewMemoryDesiredStore()
+
+	first := CreateSystem(map[string]*Component{
+		"db":               Define("db", &MockComponent{}),
+		"handlers/invoice": Define("handlers/invoice", &MockComponent{}),
+		"api":              Define("api", &MockComponent{}, "db", "handlers/*"),
+	}, WithTopologyGuard(store, false))
+	if err := first.Start(); err != nil {
+		t.Fatalf("Failed to start system: %v", err)
+	}
+	first.Stop()
+
+	// The prefix dependency still validates once every handler is gone
+	next := func(force bool, listener EventListener) *System {
+		return CreateSystem(map[string]*Component{
+			"db":  Define("db", &MockComponent{}),
+			"api": Define("api", &MockComponent{}, "db", "handlers/*"),
+		}, WithTopologyGuard(store, force), WithEventListener(listener))
+	}
+
+	err := next(false, func(Event) {}).Start()
+	var guardErr *TopologyGuardError
+	if !errors.As(err, &guardErr) {
+		t.Fatalf("Expected TopologyGuardError, got %v", err)
+	}
+	if !reflect.DeepEqual(guardErr.Removed, []string{"handlers/invoice"}) ||
+		!reflect.DeepEqual(guardErr.Dangling, []TopologyEdge{{From: "api", To: "handlers/invoice"}}) {
+		t.Errorf("Expected the removed handler reported, got %+v", guardErr)
+	}
+	if guardErr.Hash == guardErr.LastHash {
+		t.Error("Expected the topology hashes to differ")
+	}
+
+	overridden := false
+	forced := next(true, func(event Event) {
+		overridden = overridden || event.Type == EventTopologyGuardOverridden
+	})
+	if err := forced.Start(); err != nil {
+		t.Fatalf("Expected a forced start to succeed, got %v", err)
+	}
+	defer forced.Stop()
+	if !overridden {
+		t.Error("Expected the override to be reported")
+	}
+}
+
+func TestTopologyGuardRefusesCycles(t *testing.T) {
+	silenceTestStdout(t)
+	store := NewMemoryDesiredStore()
+	store.SaveDesired(DesiredTopology{"a": {}, "b": {}})
+
+	system := CreateSystem(map[string]*Component{
+		"a": Define("a", &MockComponent{}, "b"),
+		"b": Define("b", &MockComponent{}, "a"),
+	}, WithTopologyGuard(store, false))
+	var guardErr *TopologyGuardError
+	if err := system.Start(); !errors.As(err, &guardErr) || guardErr.Cycle == nil {
+		t.Errorf("Expected the cycle reported by the guard, got %v", err)
+	}
+}
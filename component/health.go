@@ -0,0 +1,156 @@
+package component
+
+import (
+	"fmt"
+)
+
+// HealthStatus summarizes whether a component can do its work
+type HealthStatus string
+
+const (
+	HealthHealthy   HealthStatus = "healthy"
+	HealthDegraded  HealthStatus = "degraded"
+	HealthUnhealthy HealthStatus = "unhealthy"
+)
+
+// severity orders statuses from best to worst
+func (h HealthStatus) severity() int {
+	switch h {
+	case HealthHealthy:
+		return 0
+	case HealthDegraded:
+		return 1
+	default:
+		return 2
+	}
+}
+
+// ComponentHealth is the health of one component, including the effect of
+// its dependencies
+type ComponentHealth struct {
+	Key    string       `json:"key"`
+	Status HealthStatus `json:"status"`
+	State  State        `json:"state"`
+	Error  string       `json:"error,omitempty"`
+
+	// Checked reports whether a HealthChecker of the component was called
+	Checked bool `json:"checked,omitempty"`
+
+	Dependencies []string `json:"dependencies,omitempty"`
+
+	// Cause is the key of the component whose own condition determines this
+	// status, which is the component itself unless a dependency is worse
+	Cause string `json:"cause,omitempty"`
+
+	// Reason explains the status, e.g. "http_server unhealthy because db unhealthy"
+	Reason string `json:"reason,omitempty"`
+}
+
+// HealthReport is the health of every component, ordered like the graph
+type HealthReport struct {
+	Status     HealthStatus      `json:"status"`
+	Components []ComponentHealth `json:"components"`
+}
+
+// Component returns the health of the component under key
+func (r HealthReport) Component(key string) (ComponentHealth, bool) {
+	for _, health := range r.Components {
+		if health.Key == key {
+			return health, true
+		}
+	}
+	return ComponentHealth{}, false
+}
+
+// HealthReport evaluates the health of every component from its lifecycle
+// state and attributes each unhealthy or degraded component to the root
+// cause among its transitive dependencies. It never waits for an in-flight
+// lifecycle operation
+func (s *System) HealthReport() HealthReport {
+	return healthReport(s.snapshot(), nil)
+}
+
+// healthReport evaluates a graph snapshot, marking components whose health
+// check failed as unhealthy
+func healthReport(snapshot *graphSnapshot, checks map[string]error) HealthReport {
+	evaluated := make(map[string]*ComponentHealth, len(snapshot.components))
+
+	var evaluate func(key string) *ComponentHealth
+	evaluate = func(key string) *ComponentHealth {
+		if health, done := evaluated[key]; done {
+			return health
+		}
+
+		component := snapshot.components[key]
+		health := &ComponentHealth{Key: key, State: component.State(), Dependencies: snapshot.dependencies[key]}
+		health.Status = ownHealth(health.State)
+		if err := component.LastError(); err != nil && health.Status != HealthHealthy {
+			health.Error = err.Error()
+		}
+		if checkErr, checked := checks[key]; checked {
+			health.Checked = true
+			if checkErr != nil {
+				health.Status = HealthUnhealthy
+				health.Error = checkErr.Error()
+			}
+		}
+		if health.Status != HealthHealthy {
+			health.Cause = key
+			health.Reason = fmt.Sprintf("%s %s (%s)", key, health.Status, health.State)
+			if health.Checked && health.Error != "" {
+				health.Reason = fmt.Sprintf("%s %s (health check failed)", key, health.Status)
+			}
+		}
+		evaluated[key] = health
+
+		for _, dep := range health.Dependencies {
+			if _, exists := snapshot.components[dep]; !exists {
+				continue
+			}
+			depHealth := evaluate(dep)
+			if blames(health, depHealth) {
+				health.Status = depHealth.Status
+				health.Cause = depHealth.Cause
+				health.Reason = fmt.Sprintf("%s %s because %s %s", key, health.Status, depHealth.Cause, health.Status)
+			}
+		}
+		return health
+	}
+
+	report := HealthReport{Status: HealthHealthy}
+	for _, key := range snapshot.order {
+		health := evaluate(key)
+		report.Components = append(report.Components, *health)
+		if health.Status.severity() > report.Status.severity() {
+			report.Status = health.Status
+		}
+	}
+	return report
+}
+
+// blames reports whether a dependency's condition explains the health of a
+// dependent better than what is attributed so far: it is worse, or as bad
+// while the dependent is only blamed on itself, since a component cannot
+// run properly on top of an unhealthy dependency
+func blames(dependent, dependency *ComponentHealth) bool {
+	switch {
+	case dependency.Status.severity() > dependent.Status.severity():
+		return true
+	case dependency.Status.severity() == dependent.Status.severity():
+		return dependency.Status != HealthHealthy && dependent.Cause == dependent.Key
+	default:
+		return false
+	}
+}
+
+// ownHealth maps a lifecycle state to the health of the component itself
+func ownHealth(state State) HealthStatus {
+	switch state {
+	case StateStarted, StateCompleted:
+		return HealthHealthy
+	case StateDegraded:
+		return HealthDegraded
+	default:
+		return HealthUnhealthy
+	}
+}
@@ -0,0 +1,37 @@
+package component
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestHealthReportAttributesRootCause(t *testing.T) {
+	silenceTestStdout(t)
+
+	system := CreateSystem(map[string]*Component{
+		"db":     Define("db", &MockComponent{}),
+		"repo":   Define("repo", &MockComponent{}, "db"),
+		"api":    Define("api", &MockComponent{}, "repo"),
+		"worker": Define("worker", &MockComponent{}, "repo"),
+	})
+	if err := system.Start(); err != nil {
+		t.Fatalf("Failed to start system: %v", err)
+	}
+	defer system.Stop()
+
+	system.Degrade("db", errors.New("replica lag"))
+	system.Degrade("worker", errors.New("backlog"))
+
+	report := system.HealthReport()
+	if report.Status != HealthDegraded {
+		t.Errorf("Expected a degraded system, got %s", report.Status)
+	}
+	for key, cause := range map[string]string{"db": "db", "repo": "db", "api": "db", "worker": "db"} {
+		if health, _ := report.Component(key); health.Cause != cause {
+			t.Errorf("Expected %s attributed to %s, got %+v", key, cause, health)
+		}
+	}
+	if worker, _ := report.Component("worker"); worker.Error != "backlog" {
+		t.Errorf("Expected the worker's own error to be kept, got %+v", worker)
+	}
+}
@@ -0,0 +1,88 @@
+package component
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// DefaultHealthCheckTimeout bounds the health checks of one Health call when
+// no timeout is configured
+const DefaultHealthCheckTimeout = 5 * time.Second
+
+// HealthChecker is an optional interface for components that can tell
+// whether they are actually working, e.g. by pinging a database, beyond
+// having started
+type HealthChecker interface {
+	Health(ctx context.Context) error
+}
+
+// WithHealthCheckTimeout sets the deadline shared by the checks of one Health call
+func WithHealthCheckTimeout(timeout time.Duration) Option {
+	return func(s *System) {
+		s.healthCheckTimeout = timeout
+	}
+}
+
+// Health calls, in parallel, the HealthChecker of every started or degraded
+// component, preferring its Start result over its instance, and returns the
+// health report with failed checks marking their component unhealthy
+func (s *System) Health() HealthReport {
+	snapshot := s.snapshot()
+	return healthReport(snapshot, s.runChecks(snapshot, func(component *Component) (func(context.Context) error, bool) {
+		checker, ok := runningAs[HealthChecker](component)
+		if !ok {
+			return nil, false
+		}
+		return checker.Health, true
+	}))
+}
+
+// runChecks calls in parallel the check find returns for each component,
+// returning the results by key
+func (s *System) runChecks(snapshot *graphSnapshot, find func(*Component) (func(context.Context) error, bool)) map[string]error {
+	timeout := s.healthCheckTimeout
+	if timeout <= 0 {
+		timeout = DefaultHealthCheckTimeout
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	checks := make(map[string]error)
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for _, key := range snapshot.order {
+		component := snapshot.components[key]
+		check, ok := find(component)
+		if !ok {
+			continue
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			err := checkWithin(ctx, check)
+			mu.Lock()
+			checks[key] = err
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+	return checks
+}
+
+// checkWithin calls a check, giving up when the context is done even if the
+// check does not honour it
+func checkWithin(ctx context.Context, check func(context.Context) error) error {
+	done := make(chan error, 1)
+	go func() {
+		done <- check(ctx)
+	}()
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return fmt.Errorf("check did not return: %w", ctx.Err())
+	}
+}
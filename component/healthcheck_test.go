@@ -0,0 +1,64 @@
+package component
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// CheckedComponent reports HealthErr from its health check, ignoring the
+// context until Hang is closed when set
+type CheckedComponent struct {
+	MockComponent
+	HealthErr error
+	Hang      chan struct{}
+}
+
+func (c *CheckedComponent) Health(ctx context.Context) error {
+	if c.Hang != nil {
+		<-c.Hang
+	}
+	return c.HealthErr
+}
+
+func TestHealthRunsHealthChecks(t *testing.T) {
+	silenceTestStdout(t)
+
+	db := &CheckedComponent{}
+	hang := make(chan struct{})
+	defer close(hang)
+	system := CreateSystem(map[string]*Component{
+		"db":    Define("db", db),
+		"api":   Define("api", &MockComponent{}, "db"),
+		"queue": Define("queue", &CheckedComponent{Hang: hang}),
+	}, WithHealthCheckTimeout(20*time.Millisecond))
+	if err := system.Start(); err != nil {
+		t.Fatalf("Failed to start system: %v", err)
+	}
+	defer system.Stop()
+
+	report := system.Health()
+	if health, _ := report.Component("db"); !health.Checked || health.Status != HealthHealthy {
+		t.Errorf("Expected a checked healthy db, got %+v", health)
+	}
+	if health, _ := report.Component("queue"); health.Status != HealthUnhealthy || health.Error == "" {
+		t.Errorf("Expected a hanging check to time out as unhealthy, got %+v", health)
+	}
+
+	db.HealthErr = errors.New("connection refused")
+	report = system.Health()
+	if health, _ := report.Component("db"); health.Status != HealthUnhealthy || health.Error != "connection refused" {
+		t.Errorf("Expected a failing check to mark db unhealthy, got %+v", health)
+	}
+	if health, _ := report.Component("api"); health.Status != HealthUnhealthy || health.Cause != "db" {
+		t.Errorf("Expected api attributed to db, got %+v", health)
+	}
+	if !system.HasCapability("db", CapabilityHealthChecker) {
+		t.Error("Expected db to report the health checker capability")
+	}
+	// The report from lifecycle state alone does not call checks
+	if health, _ := system.HealthReport().Component("db"); health.Checked || health.Status != HealthHealthy {
+		t.Errorf("Expected HealthReport not to run checks, got %+v", health)
+	}
+}
@@ -0,0 +1,62 @@
+package component
+
+import (
+	"context"
+	"time"
+)
+
+// DefaultHookTimeout bounds a system hook registered without a timeout
+const DefaultHookTimeout = 10 * time.Second
+
+const (
+	EventHookStarted  EventType = "hook_started"
+	EventHookFinished EventType = "hook_finished"
+)
+
+// HookFunc is a system-level callback run around the whole lifecycle, such
+// as registering the instance with a load balancer
+type HookFunc func(ctx context.Context) error
+
+// systemHook is a named hook with its deadline
+type systemHook struct {
+	name    string
+	timeout time.Duration
+	fn      HookFunc
+}
+
+// WithPostStartHook runs hook once the system started, e.g. to register the
+// instance with a load balancer. Hooks run in registration order, each
+// within its timeout; a failure is reported in its EventHookFinished event
+// and does not undo the start
+func WithPostStartHook(name string, timeout time.Duration, hook HookFunc) Option {
+	return func(s *System) {
+		s.postStartHooks = append(s.postStartHooks, systemHook{name: name, timeout: timeout, fn: hook})
+	}
+}
+
+// WithPreStopHook runs hook before any component stops, e.g. to deregister
+// the instance from a load balancer and wait for connections to move away.
+// Hooks run in registration order, each within its timeout; a failure is
+// reported in its EventHookFinished event and does not prevent the stop
+func WithPreStopHook(name string, timeout time.Duration, hook HookFunc) Option {
+	return func(s *System) {
+		s.preStopHooks = append(s.preStopHooks, systemHook{name: name, timeout: timeout, fn: hook})
+	}
+}
+
+// runHooks runs system hooks in order, emitting an event around each
+func (s *System) runHooks(hooks []systemHook, correlationID string, reason *ShutdownReason) {
+	for _, hook := range hooks {
+		timeout := hook.timeout
+		if timeout <= 0 {
+			timeout = DefaultHookTimeout
+		}
+
+		s.emit(Event{Type: EventHookStarted, Hook: hook.name, CorrelationID: correlationID, Reason: reason})
+		startTime := time.Now()
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		err := checkWithin(ctx, hook.fn)
+		cancel()
+		s.emit(Event{Type: EventHookFinished, Hook: hook.name, CorrelationID: correlationID, Duration: time.Since(startTime), Err: err, Reason: reason})
+	}
+}
@@ -0,0 +1,61 @@
+package component
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestSystemHooksAroundLifecycle(t *testing.T) {
+	silenceTestStdout(t)
+
+	var log []string
+	record := func(event Event) {
+		switch event.Type {
+		case EventHookFinished:
+			entry := "hook " + event.Hook
+			if event.Err != nil {
+				entry += " failed"
+			}
+			log = append(log, entry)
+		case EventComponentStarted, EventComponentStopping:
+			log = append(log, string(event.Type)+" "+event.Component)
+		}
+	}
+	hang := make(chan struct{})
+	defer close(hang)
+
+	system := CreateSystem(map[string]*Component{
+		"http_server": Define("http_server", &MockComponent{}),
+	},
+		WithEventListener(record),
+		WithPostStartHook("register", time.Second, func(ctx context.Context) error {
+			if _, ok := ctx.Deadline(); !ok {
+				return errors.New("expected a deadline")
+			}
+			return nil
+		}),
+		WithPreStopHook("deregister", 10*time.Millisecond, func(ctx context.Context) error {
+			<-hang
+			return nil
+		}),
+	)
+
+	if err := system.Start(); err != nil {
+		t.Fatalf("Failed to start system: %v", err)
+	}
+	if err := system.Stop(); err != nil {
+		t.Fatalf("Expected a failed hook not to fail the stop, got %v", err)
+	}
+
+	expected := []string{"component_started http_server", "hook register", "hook deregister failed", "component_stopping http_server"}
+	if len(log) != len(expected) {
+		t.Fatalf("Expected %v, got %v", expected, log)
+	}
+	for i := range expected {
+		if log[i] != expected[i] {
+			t.Fatalf("Expected %v, got %v", expected, log)
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package component
+
+import "strings"
+
+// IdentityContextKey holds the component's own identity in its Context
+const IdentityContextKey = ReservedPrefix + "identity"
+
+// Identity describes the component a Context was built for, so libraries
+// used inside a component can label metrics, log lines or work queues by
+// their owner without the component passing its name around
+type Identity struct {
+	// Key is the full component key, e.g. "billing/invoice_store"
+	Key string
+
+	// ID is the stable identifier derived from the key
+	ID string
+
+	// Namespace is the part of the key before the last NamespaceSeparator,
+	// empty for keys outside a module
+	Namespace string
+
+	// Name is the part of the key after the namespace
+	Name string
+
+	Tags []string
+}
+
+func (i *Identity) Start(ctx Context) (Lifecycle, error) {
+	return i, nil
+}
+
+func (i *Identity) Stop(ctx Context) error {
+	return nil
+}
+
+// HasTag reports whether the identity carries the tag
+func (i Identity) HasTag(tag string) bool {
+	for _, t := range i.Tags {
+		if t == tag {
+			return true
+		}
+	}
+	return false
+}
+
+// Labels returns the identity as label pairs, e.g. for metrics: component,
+// namespace when set, and tags joined with ","
+func (i Identity) Labels() map[string]string {
+	labels := map[string]string{"component": i.Key}
+	if i.Namespace != "" {
+		labels["namespace"] = i.Namespace
+	}
+	if len(i.Tags) > 0 {
+		labels["tags"] = strings.Join(i.Tags, ",")
+	}
+	return labels
+}
+
+// Identity returns the identity of the component
+func (c *Component) Identity() Identity {
+	namespace, name := "", c.key
+	if i := strings.LastIndex(c.key, NamespaceSeparator); i >= 0 {
+		namespace, name = c.key[:i], c.key[i+len(NamespaceSeparator):]
+	}
+	return Identity{
+		Key:       c.key,
+		ID:        c.id,
+		Namespace: namespace,
+		Name:      name,
+		Tags:      c.GetTags(),
+	}
+}
+
+// Identity returns the identity of the component the context was built
+// for. Outside a System it is the zero Identity
+func (ctx Context) Identity() Identity {
+	if identity, ok := ctx[IdentityContextKey].(*Identity); ok {
+		copied := *identity
+		copied.Tags = append([]string(nil), identity.Tags...)
+		return copied
+	}
+	return Identity{}
+}
@@ -0,0 +1,65 @@
+package component
+
+import (
+	"reflect"
+	"testing"
+)
+
+// IdentityComponent records the identity it was started with
+type IdentityComponent struct {
+	MockComponent
+	identity Identity
+}
+
+func (c *IdentityComponent) Start(ctx Context) (Lifecycle, error) {
+	c.identity = ctx.Identity()
+	return c.MockComponent.Start(ctx)
+}
+
+func TestContextIdentity(t *testing.T) {
+	store := &IdentityComponent{}
+	components, err := Compose(Namespace("billing", func() []*Component {
+		return []*Component{Define("invoice_store", store).WithTags("storage", "critical")}
+	}))
+	if err != nil {
+		t.Fatalf("Compose failed: %v", err)
+	}
+	system := CreateSystem(components)
+	if err := system.Start(); err != nil {
+		t.Fatalf("Failed to start system: %v", err)
+	}
+	defer system.Stop()
+
+	expected := Identity{
+		Key:       "billing/invoice_store",
+		ID:        componentID("billing/invoice_store"),
+		Namespace: "billing",
+		Name:      "invoice_store",
+		Tags:      []string{"storage", "critical"},
+	}
+	if !reflect.DeepEqual(store.identity, expected) {
+		t.Errorf("Expected identity %+v, got %+v", expected, store.identity)
+	}
+	if !store.identity.HasTag("critical") {
+		t.Error("Expected the identity to carry the critical tag")
+	}
+
+	labels := store.identity.Labels()
+	expectedLabels := map[string]string{"component": "billing/invoice_store", "namespace": "billing", "tags": "storage,critical"}
+	if !reflect.DeepEqual(labels, expectedLabels) {
+		t.Errorf("Expected labels %v, got %v", expectedLabels, labels)
+	}
+}
+
+func TestIdentityWithoutNamespace(t *testing.T) {
+	identity := Define("db", &MockComponent{}).Identity()
+	if identity.Namespace != "" || identity.Name != "db" {
+		t.Errorf("Expected no namespace and name db, got %+v", identity)
+	}
+	if _, ok := identity.Labels()["namespace"]; ok {
+		t.Error("Expected no namespace label")
+	}
+	if !reflect.DeepEqual(Context(nil).Identity(), Identity{}) {
+		t.Error("Expected the zero Identity outside a System")
+	}
+}
@@ -11,3 +11,10 @@ type Lifecycle interface {
 	// Stop shuts down the component
 	Stop(ctx Context) error
 }
+
+// DependencyHook is an optional interface for components that need to run
+// checks once their dependencies are started but before their own Start
+type DependencyHook interface {
+	// AfterDependenciesStarted is called with the same context passed to Start
+	AfterDependenciesStarted(ctx Context) error
+}
@@ -0,0 +1,141 @@
+package component
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+)
+
+// Logger receives the log lines of lifecycle operations. keysAndValues
+// alternate keys and values, as with log/slog
+type Logger interface {
+	Debug(msg string, keysAndValues ...interface{})
+	Info(msg string, keysAndValues ...interface{})
+	Error(msg string, keysAndValues ...interface{})
+}
+
+// WithLogger sends the lifecycle log lines of the system and its components
+// to logger instead of stdout
+func WithLogger(logger Logger) Option {
+	return func(s *System) {
+		s.logger = logger
+	}
+}
+
+// Logger returns the logger used by the system
+func (s *System) Logger() Logger {
+	if s.logger == nil {
+		return DefaultLogger
+	}
+	return s.logger
+}
+
+// DefaultLogger prints info and error lines to stdout as
+// "message [key=value ...]", dropping debug lines
+var DefaultLogger Logger = NewTextLogger(nil, false)
+
+// NopLogger discards every line
+var NopLogger Logger = nopLogger{}
+
+// TextLogger writes lines as "message [key=value ...]"
+type TextLogger struct {
+	w     io.Writer
+	debug bool
+	mu    sync.Mutex
+}
+
+// NewTextLogger creates a logger writing to w, or to the current os.Stdout
+// when w is nil, including debug lines when debug is set
+func NewTextLogger(w io.Writer, debug bool) *TextLogger {
+	return &TextLogger{w: w, debug: debug}
+}
+
+func (l *TextLogger) Debug(msg string, keysAndValues ...interface{}) {
+	if l.debug {
+		l.write(msg, keysAndValues)
+	}
+}
+
+func (l *TextLogger) Info(msg string, keysAndValues ...interface{}) {
+	l.write(msg, keysAndValues)
+}
+
+func (l *TextLogger) Error(msg string, keysAndValues ...interface{}) {
+	l.write(msg, keysAndValues)
+}
+
+func (l *TextLogger) write(msg string, keysAndValues []interface{}) {
+	var b strings.Builder
+	b.WriteString(msg)
+	for i := 0; i+1 < len(keysAndValues); i += 2 {
+		if i == 0 {
+			b.WriteString(" [")
+		} else {
+			b.WriteString(" ")
+		}
+		fmt.Fprintf(&b, "%v=%v", keysAndValues[i], keysAndValues[i+1])
+	}
+	if len(keysAndValues) > 1 {
+		b.WriteString("]")
+	}
+	b.WriteString("\n")
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	w := l.w
+	if w == nil {
+		w = os.Stdout
+	}
+	io.WriteString(w, b.String())
+}
+
+type nopLogger struct{}
+
+func (nopLogger) Debug(msg string, keysAndValues ...interface{}) {}
+func (nopLogger) Info(msg string, keysAndValues ...interface{})  {}
+func (nopLogger) Error(msg string, keysAndValues ...interface{}) {}
+
+// LoggerContextKey holds the component's scoped logger in its Context
+const LoggerContextKey = ReservedPrefix + "logger"
+
+// componentLogger adds the component key to every line of the system logger
+type componentLogger struct {
+	logger Logger
+	key    string
+}
+
+func (l *componentLogger) Start(ctx Context) (Lifecycle, error) {
+	return l, nil
+}
+
+func (l *componentLogger) Stop(ctx Context) error {
+	return nil
+}
+
+func (l *componentLogger) fields(keysAndValues []interface{}) []interface{} {
+	return append([]interface{}{"component", l.key}, keysAndValues...)
+}
+
+func (l *componentLogger) Debug(msg string, keysAndValues ...interface{}) {
+	l.logger.Debug(msg, l.fields(keysAndValues)...)
+}
+
+func (l *componentLogger) Info(msg string, keysAndValues ...interface{}) {
+	l.logger.Info(msg, l.fields(keysAndValues)...)
+}
+
+func (l *componentLogger) Error(msg string, keysAndValues ...interface{}) {
+	l.logger.Error(msg, l.fields(keysAndValues)...)
+}
+
+// Logger returns the system logger scoped to the component the context was
+// built for, adding its key as the "component" field of every line.
+// Outside a System it is DefaultLogger
+func (ctx Context) Logger() Logger {
+	if logger, ok := ctx[LoggerContextKey].(*componentLogger); ok {
+		return logger
+	}
+	return DefaultLogger
+}
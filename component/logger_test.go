@@ -0,0 +1,154 @@
+package component
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+type recordingLogger struct {
+	lines []string
+}
+
+func (l *recordingLogger) record(level, msg string, keysAndValues []interface{}) {
+	l.lines = append(l.lines, fmt.Sprintf("%s %s %v", level, msg, keysAndValues))
+}
+
+func (l *recordingLogger) Debug(msg string, keysAndValues ...interface{}) {
+	l.record("debug", msg, keysAndValues)
+}
+
+func (l *recordingLogger) Info(msg string, keysAndValues ...interface{}) {
+	l.record("info", msg, keysAndValues)
+}
+
+func (l *recordingLogger) Error(msg string, keysAndValues ...interface{}) {
+	l.record("error", msg, keysAndValues)
+}
+
+func (l *recordingLogger) count(prefix string) int {
+	n := 0
+	for _, line := range l.lines {
+		if strings.HasPrefix(line, prefix) {
+			n++
+		}
+	}
+	return n
+}
+
+func TestWithLogger(t *testing.T) {
+	logger := &recordingLogger{}
+	system := CreateSystem(map[string]*Component{
+		"db":  Define("db", &MockComponent{}),
+		"api": Define("api", &MockComponent{StopError: errors.New("busy")}, "db"),
+	}, WithLogger(logger))
+
+	if err := system.Start(); err != nil {
+		t.Fatalf("Failed to start system: %v", err)
+	}
+	system.Stop()
+
+	if n := logger.count("debug Starting component"); n != 2 {
+		t.Errorf("Expected a debug line per component start, got %d in %v", n, logger.lines)
+	}
+	if n := logger.count("info Component"); n != 3 {
+		t.Errorf("Expected two started lines and one stopped line, got %d in %v", n, logger.lines)
+	}
+	if n := logger.count("error Component api failed to stop"); n != 1 {
+		t.Errorf("Expected the failed stop at error level, got %v", logger.lines)
+	}
+	if n := logger.count("info Total system initialization time"); n != 1 {
+		t.Errorf("Expected the system started line, got %v", logger.lines)
+	}
+}
+
+func TestWithLoggerStartFailure(t *testing.T) {
+	logger := &recordingLogger{}
+	system := CreateSystem(map[string]*Component{
+		"db": Define("db", &MockComponent{StartError: errors.New("refused")}),
+	}, WithLogger(logger))
+
+	if err := system.Start(); err == nil {
+		t.Fatal("Expected the start to fail")
+	}
+	if n := logger.count("error Component db failed to start"); n != 1 {
+		t.Errorf("Expected the failed start at error level, got %v", logger.lines)
+	}
+	if n := logger.count("info Component db started"); n != 0 {
+		t.Errorf("Expected no success line for a failed start, got %v", logger.lines)
+	}
+}
+
+func TestNopLogger(t *testing.T) {
+	system := CreateSystem(map[string]*Component{
+		"db": Define("db", &MockComponent{}),
+	}, WithLogger(NopLogger))
+	if err := system.Start(); err != nil {
+		t.Fatalf("Failed to start system: %v", err)
+	}
+	system.Stop()
+}
+
+func TestTextLogger(t *testing.T) {
+	var buf bytes.Buffer
+	logger := NewTextLogger(&buf, false)
+	logger.Debug("hidden")
+	logger.Info("Component db started", "id", "c1", "correlation_id", "abc")
+	logger.Error("plain")
+
+	want := "Component db started [id=c1 correlation_id=abc]\nplain\n"
+	if buf.String() != want {
+		t.Errorf("Expected %q, got %q", want, buf.String())
+	}
+
+	buf.Reset()
+	NewTextLogger(&buf, true).Debug("shown")
+	if buf.String() != "shown\n" {
+		t.Errorf("Expected debug lines when enabled, got %q", buf.String())
+	}
+}
+
+func TestSlogLoggerSatisfiesLogger(t *testing.T) {
+	var buf bytes.Buffer
+	var logger Logger = slog.New(slog.NewTextHandler(&buf, nil))
+	system := CreateSystem(map[string]*Component{
+		"db": Define("db", &MockComponent{}),
+	}, WithLogger(logger))
+	if err := system.Start(); err != nil {
+		t.Fatalf("Failed to start system: %v", err)
+	}
+	if !strings.Contains(buf.String(), "id=") {
+		t.Errorf("Expected component fields in slog output, got %q", buf.String())
+	}
+}
+
+// LoggingComponent logs through the logger of its Context
+type LoggingComponent struct {
+	MockComponent
+}
+
+func (c *LoggingComponent) Start(ctx Context) (Lifecycle, error) {
+	ctx.Logger().Info("connecting", "attempt", 1)
+	return c.MockComponent.Start(ctx)
+}
+
+func TestContextLogger(t *testing.T) {
+	logger := &recordingLogger{}
+	system := CreateSystem(map[string]*Component{
+		"db": Define("db", &LoggingComponent{}),
+	}, WithLogger(logger))
+	if err := system.Start(); err != nil {
+		t.Fatalf("Failed to start system: %v", err)
+	}
+	defer system.Stop()
+
+	if n := logger.count("info connecting [component db attempt 1]"); n != 1 {
+		t.Errorf("Expected the component line scoped to its key, got %v", logger.lines)
+	}
+	if Context(nil).Logger() != DefaultLogger {
+		t.Error("Expected DefaultLogger outside a System")
+	}
+}
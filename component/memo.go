@@ -0,0 +1,71 @@
+package component
+
+import (
+	"reflect"
+	"sort"
+)
+
+// StartedWith returns the dependency results the component received at its
+// last successful start, keyed like its Context, or nil before one. It
+// keeps them even after a dependency was swapped or restarted, so reloads,
+// health checks and drains can see exactly what the component runs with
+func (c *Component) StartedWith() Context {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.startedWith == nil {
+		return nil
+	}
+	copied := make(Context, len(c.startedWith))
+	for key, dependency := range c.startedWith {
+		copied[key] = dependency
+	}
+	return copied
+}
+
+// memoStart records the dependency results a component started with
+func (c *Component) memoStart(ctx Context, deps []string) {
+	memo := make(Context, len(deps))
+	for _, dep := range deps {
+		memo[dep] = ctx[dep]
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.startedWith = memo
+}
+
+// StaleDependencies returns the dependencies whose current result differs
+// from the one the component started with, in sorted order, e.g. after a
+// dependency was swapped without restarting its dependents
+func (s *System) StaleDependencies(key string) []string {
+	component, exists := s.snapshot().components[key]
+	if !exists {
+		return nil
+	}
+	startedWith := component.StartedWith()
+
+	s.shared.Lock()
+	defer s.shared.Unlock()
+
+	var stale []string
+	for dep, result := range startedWith {
+		if !sameResult(result, s.context[dep]) {
+			stale = append(stale, dep)
+		}
+	}
+	sort.Strings(stale)
+	return stale
+}
+
+// sameResult reports whether two results are the same value: identical
+// pointers, or equal values of a comparable type
+func sameResult(a, b Lifecycle) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	if reflect.TypeOf(a) != reflect.TypeOf(b) || !reflect.TypeOf(a).Comparable() {
+		return false
+	}
+	return a == b
+}
@@ -0,0 +1,68 @@
+package component
+
+import (
+	"reflect"
+	"testing"
+)
+
+// runResult is published anew by every run of a one-shot component
+type runResult struct {
+	MockComponent
+	run int
+}
+
+// CountingJob returns a new result on every run
+type CountingJob struct {
+	runs int
+}
+
+func (j *CountingJob) Start(ctx Context) (Lifecycle, error) {
+	j.runs++
+	return &runResult{run: j.runs}, nil
+}
+
+func (j *CountingJob) Stop(ctx Context) error {
+	return nil
+}
+
+func TestStartedWith(t *testing.T) {
+	silenceTestStdout(t)
+
+	system := CreateSystem(map[string]*Component{
+		"migrate": DefineOneShot("migrate", &CountingJob{}),
+		"api":     Define("api", &MockComponent{}, "migrate"),
+	})
+	api, _ := system.Component("api")
+	if api.StartedWith() != nil {
+		t.Fatal("Expected no memo before the first start")
+	}
+	if err := system.Start(); err != nil {
+		t.Fatalf("Failed to start system: %v", err)
+	}
+	defer system.Stop()
+
+	first, ok := api.StartedWith()["migrate"].(*runResult)
+	if !ok || first.run != 1 {
+		t.Fatalf("Expected api to have started with the first run, got %v", api.StartedWith())
+	}
+	if stale := system.StaleDependencies("api"); len(stale) != 0 {
+		t.Errorf("Expected no stale dependencies, got %v", stale)
+	}
+
+	if err := system.Rerun("migrate"); err != nil {
+		t.Fatalf("Failed to rerun: %v", err)
+	}
+	if still := api.StartedWith()["migrate"].(*runResult); still != first {
+		t.Errorf("Expected the memo to keep the first run, got run %d", still.run)
+	}
+	if stale := system.StaleDependencies("api"); !reflect.DeepEqual(stale, []string{"migrate"}) {
+		t.Errorf("Expected migrate reported stale after the rerun, got %v", stale)
+	}
+}
+
+func TestSameResult(t *testing.T) {
+	a, b := &MockComponent{}, &MockComponent{}
+	if !sameResult(a, a) || sameResult(a, b) || !sameResult(nil, nil) || sameResult(a, nil) {
+		t.Error("Expected results compared by identity")
+	}
+}
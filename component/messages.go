@@ -0,0 +1,102 @@
+package component
+
+import (
+	"fmt"
+)
+
+// MessageID identifies a user-facing lifecycle message
+type MessageID string
+
+// Messages printed by the system and the runner. The arguments each message
+// receives are listed next to it
+const (
+	MsgComponentStarted     MessageID = "component.started"      // key, duration
+	MsgComponentStartFailed MessageID = "component.start_failed" // key, duration
+	MsgComponentStopped     MessageID = "component.stopped"      // key
+	MsgComponentStopFailed  MessageID = "component.stop_failed"  // key
+	MsgSystemStarted        MessageID = "system.started"         // duration
+	MsgPreflightWarning     MessageID = "preflight.warning"      // report
+
+	MsgRunnerStarting          MessageID = "runner.starting"           //
+	MsgRunnerStarted           MessageID = "runner.started"            //
+	MsgRunnerStartFailed       MessageID = "runner.start_failed"       // formatted error
+	MsgRunnerSignal            MessageID = "runner.signal"             // signal
+	MsgRunnerShutdownRequested MessageID = "runner.shutdown_requested" // reason
+	MsgRunnerStopFailed        MessageID = "runner.stop_failed"        // error
+	MsgRunnerStopTimeout       MessageID = "runner.stop_timeout"       // timeout
+	MsgRunnerStopped           MessageID = "runner.stopped"            //
+)
+
+// Catalog renders user-facing lifecycle messages, allowing embedding
+// products to localize status text
+type Catalog interface {
+	Message(id MessageID, args ...interface{}) string
+}
+
+// MessageTemplates is a Catalog of fmt templates. Messages missing from the
+// templates fall back to DefaultMessages
+type MessageTemplates map[MessageID]string
+
+// Message renders the template for id with args
+func (m MessageTemplates) Message(id MessageID, args ...interface{}) string {
+	template, ok := m[id]
+	if !ok {
+		template, ok = DefaultMessages[id]
+	}
+	if !ok {
+		return fmt.Sprint(append([]interface{}{id, " "}, args...)...)
+	}
+	return fmt.Sprintf(template, args...)
+}
+
+// DefaultMessages is the English catalog used unless another is configured
+var DefaultMessages = MessageTemplates{
+	MsgComponentStarted:     "Component %s started successfully in %v",
+	MsgComponentStartFailed: "Component %s failed to start after %v",
+	MsgComponentStopped:     "Component %s stopped successfully",
+	MsgComponentStopFailed:  "Component %s failed to stop",
+	MsgSystemStarted:        "Total system initialization time: %v",
+	MsgPreflightWarning:     "%v",
+
+	MsgRunnerStarting:          "Starting system...",
+	MsgRunnerStarted:           "System started successfully",
+	MsgRunnerStartFailed:       "Failed to start system:\n%s",
+	MsgRunnerSignal:            "%s signal received, shutting down...",
+	MsgRunnerShutdownRequested: "Shutdown requested (%s), shutting down...",
+	MsgRunnerStopFailed:        "Error during system shutdown: %v",
+	MsgRunnerStopTimeout:       "System did not stop within %v",
+	MsgRunnerStopped:           "System stopped successfully",
+}
+
+// PortugueseMessages is a Brazilian Portuguese catalog
+var PortugueseMessages = MessageTemplates{
+	MsgComponentStarted:     "Componente %s iniciado com sucesso em %v",
+	MsgComponentStartFailed: "Componente %s falhou ao iniciar após %v",
+	MsgComponentStopped:     "Componente %s encerrado com sucesso",
+	MsgComponentStopFailed:  "Componente %s falhou ao encerrar",
+	MsgSystemStarted:        "Tempo total de inicialização do sistema: %v",
+
+	MsgRunnerStarting:          "Iniciando o sistema...",
+	MsgRunnerStarted:           "Sistema iniciado com sucesso",
+	MsgRunnerStartFailed:       "Falha ao iniciar o sistema:\n%s",
+	MsgRunnerSignal:            "Sinal %s recebido, encerrando...",
+	MsgRunnerShutdownRequested: "Encerramento solicitado (%s), encerrando...",
+	MsgRunnerStopFailed:        "Erro durante o encerramento do sistema: %v",
+	MsgRunnerStopTimeout:       "O sistema não encerrou em %v",
+	MsgRunnerStopped:           "Sistema encerrado com sucesso",
+}
+
+// WithCatalog sets the catalog used for user-facing lifecycle messages
+func WithCatalog(catalog Catalog) Option {
+	return func(s *System) {
+		s.catalog = catalog
+	}
+}
+
+// Catalog returns the message catalog used by the system
+func (s *System) Catalog() Catalog {
+	if s.catalog == nil {
+		return DefaultMessages
+	}
+	return s.catalog
+}
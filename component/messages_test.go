@@ -0,0 +1,16 @@
+package component
+
+import (
+	"testing"
+)
+
+func TestMessageTemplatesFallback(t *testing.T) {
+	catalog := MessageTemplates{MsgComponentStopped: "%s parado"}
+
+	if msg := catalog.Message(MsgComponentStopped, "db"); msg != "db parado" {
+		t.Errorf("Expected localized message, got %q", msg)
+	}
+	if msg := catalog.Message(MsgRunnerStopped); msg != "System stopped successfully" {
+		t.Errorf("Expected fallback to default message, got %q", msg)
+	}
+}
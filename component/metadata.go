@@ -0,0 +1,46 @@
+package component
+
+import (
+	"reflect"
+)
+
+// Metadata describes a component for tooling, documentation and triage
+type Metadata struct {
+	Description string
+	Owner       string
+	Links       []string
+
+	// Packages lists import paths whose code belongs to the component, in
+	// addition to the package of the instance type
+	Packages []string
+}
+
+// WithMetadata attaches descriptive metadata to the component
+func (c *Component) WithMetadata(metadata Metadata) *Component {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.metadata = metadata
+	return c
+}
+
+// GetMetadata returns the metadata attached to the component
+func (c *Component) GetMetadata() Metadata {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	metadata := c.metadata
+	metadata.Links = append([]string(nil), c.metadata.Links...)
+	metadata.Packages = append([]string(nil), c.metadata.Packages...)
+	return metadata
+}
+
+// instanceType returns the package path and type name of the component instance
+func (c *Component) instanceType() (pkg, name string) {
+	t := reflect.TypeOf(c.instance)
+	if t == nil {
+		return "", ""
+	}
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t.PkgPath(), t.Name()
+}
@@ -0,0 +1,235 @@
+package component
+
+import (
+	"errors"
+	"time"
+)
+
+const (
+	EventHealthUnhealthy EventType = "health_unhealthy"
+	EventHealthDegraded  EventType = "health_degraded"
+	EventHealthRecovered EventType = "health_recovered"
+
+	// EventHealthRestart is emitted once the monitor restarted an unhealthy
+	// component and its dependents, with Err set if the restart failed
+	EventHealthRestart EventType = "health_restart"
+
+	// EventRestartHeld is emitted when a restart is held back because a
+	// dependency of the component is not healthy, with Dependency naming it
+	EventRestartHeld EventType = "restart_held"
+
+	// EventRestartReleased is emitted when a held restart no longer waits
+	// for its dependencies
+	EventRestartReleased EventType = "restart_released"
+)
+
+// HealthPolicy decides how the health monitor reacts to failing checks
+type HealthPolicy struct {
+	// FailureThreshold is the number of consecutive failed checks after
+	// which a component is restarted. Zero means one
+	FailureThreshold int
+
+	// MaxRestarts bounds the restarts of one component while the system
+	// runs. Zero means no limit and a negative value disables restarts,
+	// leaving only the events
+	MaxRestarts int
+
+	// HoldUntilDependenciesHealthy schedules the restart of a component
+	// whose own check fails even while a dependency is to blame, and holds
+	// it until every dependency is healthy again, so dependents do not flap
+	// while a core dependency is down
+	HoldUntilDependenciesHealthy bool
+}
+
+// WithHealthInterval polls System.Health every interval while the system
+// runs, emitting an event whenever the status of a component changes and
+// restarting the components whose own health check keeps failing, together
+// with their dependents
+func WithHealthInterval(interval time.Duration) Option {
+	return func(s *System) {
+		s.healthInterval = interval
+	}
+}
+
+// WithHealthPolicy sets how the health monitor reacts to failing checks
+func WithHealthPolicy(policy HealthPolicy) Option {
+	return func(s *System) {
+		s.healthPolicy = policy
+	}
+}
+
+// healthMonitor polls the health of a running system until done is closed
+type healthMonitor struct {
+	done     chan struct{}
+	statuses map[string]HealthStatus
+	failures map[string]int
+	restarts map[string]int
+	held     map[string]string
+}
+
+// startHealthMonitor starts polling once the system started; the caller must hold s.mu
+func (s *System) startHealthMonitor() {
+	if s.healthInterval <= 0 {
+		return
+	}
+	monitor := &healthMonitor{
+		done:     make(chan struct{}),
+		statuses: make(map[string]HealthStatus),
+		failures: make(map[string]int),
+		restarts: make(map[string]int),
+		held:     make(map[string]string),
+	}
+	s.monitor = monitor
+	go s.runHealthMonitor(monitor)
+}
+
+// stopHealthMonitor stops polling without waiting, as a poll may itself be
+// waiting for s.mu; the caller must hold s.mu
+func (s *System) stopHealthMonitor() {
+	if s.monitor != nil {
+		close(s.monitor.done)
+		s.monitor = nil
+	}
+}
+
+func (s *System) runHealthMonitor(monitor *healthMonitor) {
+	ticker := time.NewTicker(s.healthInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-monitor.done:
+			return
+		case <-ticker.C:
+			s.pollHealth(monitor)
+		}
+	}
+}
+
+// pollHealth checks the system once, reporting transitions and restarting
+// what the policy allows
+func (s *System) pollHealth(monitor *healthMonitor) {
+	report := s.Health()
+	for _, health := range report.Components {
+		if previous, known := monitor.statuses[health.Key]; health.Status != previous && (known || health.Status != HealthHealthy) {
+			s.emitHealthTransition(health)
+		}
+		monitor.statuses[health.Key] = health.Status
+
+		if health.Checked && health.Error != "" && (health.Cause == health.Key || s.healthPolicy.HoldUntilDependenciesHealthy) {
+			monitor.failures[health.Key]++
+		} else {
+			monitor.failures[health.Key] = 0
+		}
+	}
+
+	threshold := s.healthPolicy.FailureThreshold
+	if threshold <= 0 {
+		threshold = 1
+	}
+	for _, health := range report.Components {
+		key := health.Key
+		if monitor.failures[key] < threshold || !s.mayRestart(monitor, key) {
+			s.releaseRestart(monitor, key)
+			continue
+		}
+		if s.healthPolicy.HoldUntilDependenciesHealthy {
+			if dependency := unhealthyDependency(report, health); dependency != "" {
+				s.holdRestart(monitor, key, dependency)
+				continue
+			}
+			s.releaseRestart(monitor, key)
+		}
+		monitor.failures[key] = 0
+		monitor.restarts[key]++
+		s.restartUnhealthy(monitor, key)
+	}
+}
+
+// mayRestart reports whether the policy allows restarting the component again
+func (s *System) mayRestart(monitor *healthMonitor, key string) bool {
+	max := s.healthPolicy.MaxRestarts
+	return max == 0 || (max > 0 && monitor.restarts[key] < max)
+}
+
+// unhealthyDependency returns the first dependency of a component that is
+// not healthy in the report, or ""
+func unhealthyDependency(report HealthReport, health ComponentHealth) string {
+	for _, dep := range health.Dependencies {
+		if depHealth, ok := report.Component(dep); ok && depHealth.Status != HealthHealthy {
+			return dep
+		}
+	}
+	return ""
+}
+
+// holdRestart records a restart waiting for a dependency, reporting the
+// hold when it starts or moves to another dependency
+func (s *System) holdRestart(monitor *healthMonitor, key, dependency string) {
+	if monitor.held[key] == dependency {
+		return
+	}
+	monitor.held[key] = dependency
+	if component, exists := s.snapshot().components[key]; exists {
+		event := componentEvent(EventRestartHeld, component, "")
+		event.Dependency = dependency
+		s.emit(event)
+	}
+}
+
+// releaseRestart lifts the hold of a component, if any
+func (s *System) releaseRestart(monitor *healthMonitor, key string) {
+	dependency, held := monitor.held[key]
+	if !held {
+		return
+	}
+	delete(monitor.held, key)
+	if component, exists := s.snapshot().components[key]; exists {
+		event := componentEvent(EventRestartReleased, component, "")
+		event.Dependency = dependency
+		s.emit(event)
+	}
+}
+
+// emitHealthTransition reports a component whose health status changed
+func (s *System) emitHealthTransition(health ComponentHealth) {
+	component, exists := s.snapshot().components[health.Key]
+	if !exists {
+		return
+	}
+
+	eventType := EventHealthRecovered
+	switch health.Status {
+	case HealthUnhealthy:
+		eventType = EventHealthUnhealthy
+	case HealthDegraded:
+		eventType = EventHealthDegraded
+	}
+	event := componentEvent(eventType, component, "")
+	if health.Status != HealthHealthy {
+		event.Err = errors.New(health.Reason)
+	}
+	s.emit(event)
+}
+
+// restartUnhealthy restarts a component and its running dependents, unless
+// the monitor was stopped while waiting for the lifecycle lock
+func (s *System) restartUnhealthy(monitor *healthMonitor, key string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	select {
+	case <-monitor.done:
+		return
+	default:
+	}
+	component, exists := s.components[key]
+	if !exists {
+		return
+	}
+
+	_, err := s.applyLocked(Changeset{Swap: []*Component{component}})
+	event := componentEvent(EventHealthRestart, component, "")
+	event.Err = err
+	s.emit(event)
+}
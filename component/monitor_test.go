@@ -0,0 +1,183 @@
+package component
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// FlakyComponent fails its health check until it is restarted
+type FlakyComponent struct {
+	Starts  atomic.Int32
+	Failing atomic.Bool
+}
+
+func (f *FlakyComponent) Start(ctx Context) (Lifecycle, error) {
+	f.Starts.Add(1)
+	f.Failing.Store(false)
+	return f, nil
+}
+
+func (f *FlakyComponent) Stop(ctx Context) error {
+	return nil
+}
+
+func (f *FlakyComponent) Health(ctx context.Context) error {
+	if f.Failing.Load() {
+		return errors.New("connection lost")
+	}
+	return nil
+}
+
+func TestHealthMonitorRestartsUnhealthyComponents(t *testing.T) {
+	silenceTestStdout(t)
+
+	db := &FlakyComponent{}
+	api := &FlakyComponent{}
+	events := make(chan Event, 64)
+	system := CreateSystem(map[string]*Component{
+		"db":  Define("db", db),
+		"api": Define("api", api, "db"),
+	}, WithHealthInterval(5*time.Millisecond), WithHealthPolicy(HealthPolicy{FailureThreshold: 2}), WithEventListener(func(event Event) {
+		switch event.Type {
+		case EventHealthUnhealthy, EventHealthRecovered, EventHealthRestart:
+			events <- event
+		}
+	}))
+	if err := system.Start(); err != nil {
+		t.Fatalf("Failed to start system: %v", err)
+	}
+	defer system.Stop()
+
+	db.Failing.Store(true)
+	var seen []string
+	timeout := time.After(2 * time.Second)
+	for len(seen) < 5 {
+		select {
+		case event := <-events:
+			seen = append(seen, string(event.Type)+" "+event.Component)
+			if event.Type == EventHealthRestart && event.Err != nil {
+				t.Fatalf("Expected restart to succeed, got %v", event.Err)
+			}
+		case <-timeout:
+			t.Fatalf("Timed out waiting for health events, got %v", seen)
+		}
+	}
+
+	expected := []string{"health_unhealthy db", "health_unhealthy api", "health_restart db", "health_recovered db", "health_recovered api"}
+	for i := range expected {
+		if seen[i] != expected[i] {
+			t.Fatalf("Expected events %v, got %v", expected, seen)
+		}
+	}
+	if db.Starts.Load() != 2 || api.Starts.Load() != 2 {
+		t.Errorf("Expected db and its dependent restarted once, got %d and %d starts", db.Starts.Load(), api.Starts.Load())
+	}
+}
+
+func TestHealthMonitorWithoutRestarts(t *testing.T) {
+	silenceTestStdout(t)
+
+	db := &FlakyComponent{}
+	unhealthy := make(chan struct{}, 1)
+	system := CreateSystem(map[string]*Component{"db": Define("db", db)},
+		WithHealthInterval(5*time.Millisecond), WithHealthPolicy(HealthPolicy{MaxRestarts: -1}), WithEventListener(func(event Event) {
+			if event.Type == EventHealthUnhealthy {
+				unhealthy <- struct{}{}
+			}
+		}))
+	if err := system.Start(); err != nil {
+		t.Fatalf("Failed to start system: %v", err)
+	}
+
+	db.Failing.Store(true)
+	select {
+	case <-unhealthy:
+	case <-time.After(2 * time.Second):
+		t.Fatal("Timed out waiting for the unhealthy event")
+	}
+	time.Sleep(20 * time.Millisecond)
+	system.Stop()
+	if db.Starts.Load() != 1 {
+		t.Errorf("Expected no restart, got %d starts", db.Starts.Load())
+	}
+}
+
+// StickyComponent fails its health check until told otherwise, even across restarts
+type StickyComponent struct {
+	Failing atomic.Bool
+}
+
+func (c *StickyComponent) Start(ctx Context) (Lifecycle, error) {
+	return c, nil
+}
+
+func (c *StickyComponent) Stop(ctx Context) error {
+	return nil
+}
+
+func (c *StickyComponent) Health(ctx context.Context) error {
+	if c.Failing.Load() {
+		return errors.New("down")
+	}
+	return nil
+}
+
+func TestHealthMonitorHoldsRestartUntilDependenciesHealthy(t *testing.T) {
+	silenceTestStdout(t)
+
+	db := &StickyComponent{}
+	api := &FlakyComponent{}
+	events := make(chan Event, 64)
+	system := CreateSystem(map[string]*Component{
+		"db":  Define("db", db),
+		"api": Define("api", api, "db"),
+	}, WithHealthInterval(5*time.Millisecond), WithHealthPolicy(HealthPolicy{MaxRestarts: 1, HoldUntilDependenciesHealthy: true}), WithEventListener(func(event Event) {
+		switch event.Type {
+		case EventHealthRestart, EventRestartHeld, EventRestartReleased:
+			events <- event
+		}
+	}))
+	if err := system.Start(); err != nil {
+		t.Fatalf("Failed to start system: %v", err)
+	}
+	defer system.Stop()
+
+	next := func() Event {
+		select {
+		case event := <-events:
+			return event
+		case <-time.After(2 * time.Second):
+			t.Fatal("Timed out waiting for a restart event")
+			return Event{}
+		}
+	}
+
+	db.Failing.Store(true)
+	if event := next(); event.Type != EventHealthRestart || event.Component != "db" {
+		t.Fatalf("Expected db restarted once, got %s %s", event.Type, event.Component)
+	}
+
+	api.Failing.Store(true)
+	event := next()
+	if event.Type != EventRestartHeld || event.Component != "api" || event.Dependency != "db" {
+		t.Fatalf("Expected the api restart held on db, got %s %s %s", event.Type, event.Component, event.Dependency)
+	}
+	time.Sleep(30 * time.Millisecond)
+	if starts := api.Starts.Load(); starts != 2 {
+		t.Fatalf("Expected no api restart while db is down, got %d starts", starts)
+	}
+
+	db.Failing.Store(false)
+	if event := next(); event.Type != EventRestartReleased || event.Component != "api" {
+		t.Fatalf("Expected the api restart released, got %s %s", event.Type, event.Component)
+	}
+	if event := next(); event.Type != EventHealthRestart || event.Component != "api" || event.Err != nil {
+		t.Fatalf("Expected api restarted, got %s %s %v", event.Type, event.Component, event.Err)
+	}
+	if starts := api.Starts.Load(); starts != 3 {
+		t.Errorf("Expected api restarted after db recovered, got %d starts", starts)
+	}
+}
@@ -0,0 +1,41 @@
+package component
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// NamespacedSnakeCase accepts lowercase snake_case keys optionally split
+// into namespaces with "/" or ".", e.g. "billing/invoice_store" or "db.read"
+var NamespacedSnakeCase = regexp.MustCompile(`^[a-z][a-z0-9_]*([./][a-z][a-z0-9_]*)*$`)
+
+// WithKeyNaming rejects, at validation time, component and provided keys
+// that do not match the naming convention
+func WithKeyNaming(convention *regexp.Regexp) Option {
+	return func(s *System) {
+		s.keyNaming = convention
+	}
+}
+
+// checkKeyNaming verifies every key against the configured naming
+// convention, reporting every key that does not match
+func (s *System) checkKeyNaming() error {
+	if s.keyNaming == nil {
+		return nil
+	}
+
+	var errs []error
+	for _, name := range s.sortedKeys() {
+		keys := append([]string{name}, s.components[name].GetProvides()...)
+		for _, key := range keys {
+			if !s.keyNaming.MatchString(key) {
+				errs = append(errs, &ComponentError{
+					Key: name,
+					Op:  OpValidate,
+					Err: fmt.Errorf("key %q does not match naming convention %s", key, s.keyNaming),
+				})
+			}
+		}
+	}
+	return joinErrors(errs)
+}
@@ -0,0 +1,45 @@
+package component
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestKeyNamingConvention(t *testing.T) {
+	tests := []struct {
+		key   string
+		valid bool
+	}{
+		{"http_server", true},
+		{"billing/invoice_store", true},
+		{"db.read", true},
+		{"HttpServer", false},
+		{"http-server", false},
+		{"/leading", false},
+	}
+
+	for _, tt := range tests {
+		system := CreateSystem(map[string]*Component{
+			tt.key: Define(tt.key, &MockComponent{}),
+		}, WithKeyNaming(NamespacedSnakeCase))
+
+		err := system.Start()
+		if tt.valid && err != nil {
+			t.Errorf("Expected key %q to be accepted, got %v", tt.key, err)
+		}
+		var componentErr *ComponentError
+		if !tt.valid && (!errors.As(err, &componentErr) || componentErr.Op != OpValidate) {
+			t.Errorf("Expected key %q to be rejected at validation, got %v", tt.key, err)
+		}
+	}
+}
+
+func TestKeyNamingAppliesToProvidedKeys(t *testing.T) {
+	system := CreateSystem(map[string]*Component{
+		"db": Define("db", &Connections{}).Provides("db.read", "DB.write"),
+	}, WithKeyNaming(NamespacedSnakeCase))
+
+	if err := system.Start(); err == nil {
+		t.Fatal("Expected provided key to be rejected")
+	}
+}
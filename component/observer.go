@@ -0,0 +1,121 @@
+package component
+
+import (
+	"sync"
+)
+
+// Observer is attached to a running system to watch it without being a
+// component, such as a metrics exporter or a debug UI loaded on demand
+type Observer interface {
+	// Attached is called once with a read-only view of the system
+	Attached(view SystemView)
+
+	// Observe receives every event emitted while the observer is attached.
+	// It is called synchronously during lifecycle operations, so it must not
+	// block or call lifecycle methods of the system
+	Observe(event Event)
+}
+
+// SystemView is the read-only access to a system given to observers. Its
+// methods never wait for an in-flight lifecycle operation
+type SystemView struct {
+	system *System
+}
+
+// Keys returns the keys of all components in sorted order
+func (v SystemView) Keys() []string {
+	return v.system.sortedKeys()
+}
+
+// State returns the lifecycle state of a component
+func (v SystemView) State(key string) (State, bool) {
+	component, exists := v.system.components[key]
+	if !exists {
+		return 0, false
+	}
+	return component.State(), true
+}
+
+// States returns the lifecycle state of every component
+func (v SystemView) States() map[string]State {
+	states := make(map[string]State, len(v.system.components))
+	for key, component := range v.system.components {
+		states[key] = component.State()
+	}
+	return states
+}
+
+// Dependencies returns the dependencies a component declares
+func (v SystemView) Dependencies(key string) []string {
+	if component, exists := v.system.components[key]; exists {
+		return component.GetDependencies()
+	}
+	return nil
+}
+
+// Tags returns the tags of a component
+func (v SystemView) Tags(key string) []string {
+	if component, exists := v.system.components[key]; exists {
+		return component.GetTags()
+	}
+	return nil
+}
+
+// Metadata returns the metadata of a component
+func (v SystemView) Metadata(key string) Metadata {
+	if component, exists := v.system.components[key]; exists {
+		return component.GetMetadata()
+	}
+	return Metadata{}
+}
+
+// IsStarted reports whether the system is started
+func (v SystemView) IsStarted() bool {
+	return v.system.IsStarted()
+}
+
+// attachment is an observer registered with Attach
+type attachment struct {
+	observer Observer
+}
+
+// observers holds the observers attached at runtime. The slice is replaced
+// on every change so emit can iterate a snapshot without holding the lock
+type observers struct {
+	list []*attachment
+	mu   sync.Mutex
+}
+
+// Attach adds an observer to the system, started or not, and returns a
+// function detaching it
+func (s *System) Attach(observer Observer) (detach func()) {
+	observer.Attached(SystemView{system: s})
+	attached := &attachment{observer: observer}
+
+	s.observers.mu.Lock()
+	s.observers.list = append(append([]*attachment(nil), s.observers.list...), attached)
+	s.observers.mu.Unlock()
+
+	return func() {
+		s.observers.mu.Lock()
+		defer s.observers.mu.Unlock()
+		list := make([]*attachment, 0, len(s.observers.list))
+		for _, a := range s.observers.list {
+			if a != attached {
+				list = append(list, a)
+			}
+		}
+		s.observers.list = list
+	}
+}
+
+// notifyObservers delivers an event to the attached observers
+func (s *System) notifyObservers(event Event) {
+	s.observers.mu.Lock()
+	list := s.observers.list
+	s.observers.mu.Unlock()
+
+	for _, attached := range list {
+		attached.observer.Observe(event)
+	}
+}
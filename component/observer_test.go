@@ -0,0 +1,65 @@
+package component
+
+import (
+	"testing"
+)
+
+// RecordingObserver keeps the view it was given and the events it saw
+type RecordingObserver struct {
+	view   SystemView
+	events []Event
+}
+
+func (r *RecordingObserver) Attached(view SystemView) {
+	r.view = view
+}
+
+func (r *RecordingObserver) Observe(event Event) {
+	if state, ok := r.view.State(event.Component); ok && event.Type == EventComponentStopped && state != StateStopped {
+		panic("view out of sync with events")
+	}
+	r.events = append(r.events, event)
+}
+
+func TestAttachObserverToRunningSystem(t *testing.T) {
+	silenceTestStdout(t)
+
+	system := CreateSystem(map[string]*Component{
+		"db":  Define("db", &MockComponent{}).WithTags("storage"),
+		"api": Define("api", &MockComponent{}, "db"),
+	})
+	if err := system.Start(); err != nil {
+		t.Fatalf("Failed to start system: %v", err)
+	}
+
+	observer := &RecordingObserver{}
+	detach := system.Attach(observer)
+
+	view := observer.view
+	if !view.IsStarted() || len(view.Keys()) != 2 {
+		t.Fatalf("Expected a view of the started system, got keys %v", view.Keys())
+	}
+	if state, _ := view.State("api"); state != StateStarted {
+		t.Errorf("Expected api started, got %s", state)
+	}
+	if deps := view.Dependencies("api"); len(deps) != 1 || deps[0] != "db" {
+		t.Errorf("Expected api to depend on db, got %v", deps)
+	}
+	if tags := view.Tags("db"); len(tags) != 1 || tags[0] != "storage" {
+		t.Errorf("Expected db tags, got %v", tags)
+	}
+
+	if err := system.Stop(); err != nil {
+		t.Fatalf("Failed to stop system: %v", err)
+	}
+	seen := len(observer.events)
+	if seen == 0 || observer.events[seen-1].Type != EventSystemStopped {
+		t.Fatalf("Expected the observer to see the stop, got %d events", seen)
+	}
+
+	detach()
+	system.Start()
+	if len(observer.events) != seen {
+		t.Errorf("Expected no events after detaching, got %d more", len(observer.events)-seen)
+	}
+}
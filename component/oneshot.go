@@ -0,0 +1,83 @@
+package component
+
+import (
+	"fmt"
+)
+
+// EventComponentCompleted is emitted when a one-shot component finishes its work
+const EventComponentCompleted EventType = "component_completed"
+
+// Task adapts a function to a Lifecycle whose Start runs the function and
+// whose Stop does nothing, for use with DefineOneShot
+type Task func(ctx Context) error
+
+// Start runs the task
+func (t Task) Start(ctx Context) (Lifecycle, error) {
+	if err := t(ctx); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// Stop does nothing; a task is finished once Start returns
+func (t Task) Stop(ctx Context) error {
+	return nil
+}
+
+// DefineOneShot creates a component whose Start performs work such as
+// migrations or cache warming and is then considered finished: it is
+// reported as completed, excluded from Stop and can be run again with Rerun
+func DefineOneShot(key string, instance Lifecycle, dependencies ...string) *Component {
+	c := Define(key, instance, dependencies...)
+	c.oneShot = true
+	return c
+}
+
+// IsOneShot reports whether the component was defined with DefineOneShot
+func (c *Component) IsOneShot() bool {
+	return c.oneShot
+}
+
+// IsCompleted reports whether a one-shot component finished its work
+func (c *Component) IsCompleted() bool {
+	return c.State() == StateCompleted
+}
+
+// satisfied reports whether dependents of the component may start
+func (c *Component) satisfied() bool {
+	return c.IsStarted() || c.IsCompleted()
+}
+
+// OneShots returns the state of every one-shot component
+func (s *System) OneShots() map[string]State {
+	states := make(map[string]State)
+	for key, component := range s.snapshot().components {
+		if component.IsOneShot() {
+			states[key] = component.State()
+		}
+	}
+	return states
+}
+
+// Rerun runs a one-shot component again in a started system
+func (s *System) Rerun(key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	component, exists := s.components[key]
+	if !exists {
+		return fmt.Errorf("component %s not found", key)
+	}
+	if !component.IsOneShot() {
+		return fmt.Errorf("component %s is not a one-shot component", key)
+	}
+	if !s.started.Load() {
+		return fmt.Errorf("system is not started")
+	}
+	if component.State() == StateStarting {
+		return fmt.Errorf("component %s is already running", key)
+	}
+
+	correlationID := newCorrelationID()
+	return correlate(correlationID, s.startComponent(component, correlationID))
+}
@@ -0,0 +1,55 @@
+package component
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestOneShotComponent(t *testing.T) {
+	runs := 0
+	server := &MockComponent{}
+	system := CreateSystem(map[string]*Component{
+		"db": Define("db", &MockComponent{}),
+		"migrations": DefineOneShot("migrations", Task(func(ctx Context) error {
+			if ctx["db"] == nil {
+				return errors.New("db not injected")
+			}
+			runs++
+			return nil
+		}), "db"),
+		"http_server": Define("http_server", server, "migrations"),
+	})
+
+	if err := system.Start(); err != nil {
+		t.Fatalf("Failed to start system: %v", err)
+	}
+	if runs != 1 || !server.StartCalled {
+		t.Fatalf("Expected migrations to run once before the server, got %d runs", runs)
+	}
+	if state := system.OneShots()["migrations"]; state != StateCompleted {
+		t.Errorf("Expected migrations completed, got %s", state)
+	}
+
+	if err := system.Rerun("migrations"); err != nil {
+		t.Fatalf("Failed to rerun: %v", err)
+	}
+	if runs != 2 {
+		t.Errorf("Expected a second run, got %d", runs)
+	}
+	if err := system.Rerun("db"); err == nil {
+		t.Error("Expected rerun of a regular component to fail")
+	}
+
+	var stopped []string
+	system.listeners = append(system.listeners, func(e Event) {
+		if e.Type == EventComponentStopped {
+			stopped = append(stopped, e.Component)
+		}
+	})
+	if err := system.Stop(); err != nil {
+		t.Fatalf("Failed to stop system: %v", err)
+	}
+	if len(stopped) != 2 || stopped[0] != "http_server" || stopped[1] != "db" {
+		t.Errorf("Expected one-shot to be excluded from stop, got %v", stopped)
+	}
+}
@@ -0,0 +1,11 @@
+package component
+
+// Option configures a System
+type Option func(*System)
+
+// WithStateStore sets the store used to persist component state between runs
+func WithStateStore(store StateStore) Option {
+	return func(s *System) {
+		s.stateStore = store
+	}
+}
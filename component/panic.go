@@ -0,0 +1,79 @@
+package component
+
+import (
+	"errors"
+	"fmt"
+	"runtime/debug"
+)
+
+// ErrPanic is matched by every PanicError
+var ErrPanic = errors.New("component panicked")
+
+// PanicError is a panic recovered from a component's Start, Stop or
+// dependency hook, with the stack of the goroutine where it happened, as
+// Culprit expects it
+type PanicError struct {
+	Value any
+	Stack []byte
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("panic: %v", e.Value)
+}
+
+func (e *PanicError) Is(target error) bool {
+	return target == ErrPanic
+}
+
+// Unwrap returns the panic value when it is an error
+func (e *PanicError) Unwrap() error {
+	if err, ok := e.Value.(error); ok {
+		return err
+	}
+	return nil
+}
+
+// recoverPanic turns a panic of the calling function into a PanicError
+// stored in err; it must be deferred directly
+func recoverPanic(err *error) {
+	if value := recover(); value != nil {
+		*err = &PanicError{Value: value, Stack: debug.Stack()}
+	}
+}
+
+// callStart calls the instance's Start, recovering a panic as an error
+func (c *Component) callStart(ctx Context) (result Lifecycle, err error) {
+	defer recoverPanic(&err)
+	return c.instance.Start(ctx)
+}
+
+// callStop calls the instance's Stop, or StopWithReason when implemented,
+// recovering a panic as an error
+func (c *Component) callStop(ctx Context, reason ShutdownReason) (err error) {
+	defer recoverPanic(&err)
+	if stopper, ok := c.instance.(ReasonStopper); ok {
+		return stopper.StopWithReason(ctx, reason)
+	}
+	return c.instance.Stop(ctx)
+}
+
+// callDependencyHooks runs the dependency hooks, recovering a panic as an error
+func (c *Component) callDependencyHooks(ctx Context) (err error) {
+	defer recoverPanic(&err)
+	return c.runDependencyHooks(ctx)
+}
+
+// rollbackPanic stops what a Start failing on a panic already started, as
+// the panicking component may have left its dependencies in use; the
+// caller must hold s.mu
+func (s *System) rollbackPanic(err error) {
+	if !errors.Is(err, ErrPanic) {
+		return
+	}
+	detail := "start panicked"
+	var componentErr *ComponentError
+	if errors.As(err, &componentErr) {
+		detail = "component " + componentErr.Key + " panicked"
+	}
+	s.unwindPartialStart(ShutdownReason{Cause: ShutdownFatalError, Detail: detail})
+}
@@ -0,0 +1,74 @@
+package component
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+// PanickingStopper panics with value while stopping
+type PanickingStopper struct {
+	MockComponent
+	value any
+}
+
+func (p *PanickingStopper) Stop(ctx Context) error {
+	panic(p.value)
+}
+
+func TestPanicInStartRollsBack(t *testing.T) {
+	db := &MockComponent{}
+	api := Define("api", &PanickingComponent{}, "db")
+	system := CreateSystem(map[string]*Component{
+		"db":  Define("db", db),
+		"api": api,
+	})
+
+	err := system.Start()
+	var panicErr *PanicError
+	if !errors.As(err, &panicErr) || !errors.Is(err, ErrPanic) || !errors.Is(err, ErrComponentStartFailed) {
+		t.Fatalf("Expected the panic as a start failure, got %v", err)
+	}
+	if panicErr.Value != "nil map write" || !strings.Contains(string(panicErr.Stack), "panic_test.go") {
+		t.Errorf("Expected the panic value and stack, got %v\n%s", panicErr.Value, panicErr.Stack)
+	}
+	if !strings.Contains(err.Error(), "failed to start component api: failed to start component: panic: nil map write") {
+		t.Errorf("Expected the panic in the message, got %v", err)
+	}
+	if api.State() != StateFailed || !db.StopCalled || system.IsStarted() {
+		t.Errorf("Expected api failed and db rolled back, got api %s and db stopped %v", api.State(), db.StopCalled)
+	}
+	if suspect, ok := system.Culprit(panicErr.Stack); !ok || suspect.Component != "api" {
+		t.Errorf("Expected the stack to point at api, got %+v", suspect)
+	}
+}
+
+func TestPanicInStop(t *testing.T) {
+	db := &MockComponent{}
+	api := Define("api", &PanickingStopper{value: io.ErrClosedPipe}, "db")
+	system := CreateSystem(map[string]*Component{
+		"db":  Define("db", db),
+		"api": api,
+	})
+	if err := system.Start(); err != nil {
+		t.Fatalf("Failed to start system: %v", err)
+	}
+
+	err := system.Stop()
+	if !errors.Is(err, ErrPanic) || !errors.Is(err, io.ErrClosedPipe) || !errors.Is(err, ErrComponentStopFailed) {
+		t.Fatalf("Expected the panic as a stop failure wrapping its error, got %v", err)
+	}
+	if api.State() != StateFailed || !db.StopCalled {
+		t.Errorf("Expected api failed and db still stopped, got api %s and db stopped %v", api.State(), db.StopCalled)
+	}
+}
+
+func TestPanicInDependencyHook(t *testing.T) {
+	api := Define("api", &MockComponent{}).AfterDependenciesStarted(func(ctx Context) error {
+		panic("hook")
+	})
+	if _, err := api.Start(Context{}); !errors.Is(err, ErrPanic) || api.State() != StateFailed {
+		t.Errorf("Expected the hook panic as an error, got %v in state %s", err, api.State())
+	}
+}
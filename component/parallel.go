@@ -0,0 +1,39 @@
+package component
+
+// WithParallelStart starts the components of each topological level
+// concurrently, at most n at a time. A level starts once every component of
+// the previous one started, so dependencies are still started first
+func WithParallelStart(n int) Option {
+	return func(s *System) {
+		s.parallelStart = n
+	}
+}
+
+// WithParallelStop stops up to n components concurrently, each once every
+// component depending on it has stopped, mirroring WithParallelStart
+func WithParallelStop(n int) Option {
+	return func(s *System) {
+		s.parallelStop = n
+	}
+}
+
+// levels groups ordered components by topological level: a component's
+// level is one more than the highest level of its dependencies
+func (s *System) levels(orderedComponents []string) [][]string {
+	level := make(map[string]int, len(orderedComponents))
+	var levels [][]string
+	for _, name := range orderedComponents {
+		l := 0
+		for _, dep := range s.resolvedDependencies(s.components[name]) {
+			if level[dep]+1 > l {
+				l = level[dep] + 1
+			}
+		}
+		level[name] = l
+		if l == len(levels) {
+			levels = append(levels, nil)
+		}
+		levels[l] = append(levels[l], name)
+	}
+	return levels
+}
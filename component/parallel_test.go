@@ -0,0 +1,165 @@
+package component
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// ConcurrentComponent tracks how many instances are starting at once
+type ConcurrentComponent struct {
+	MockComponent
+	running, peak *atomic.Int32
+}
+
+func (c *ConcurrentComponent) Start(ctx Context) (Lifecycle, error) {
+	n := c.running.Add(1)
+	for {
+		peak := c.peak.Load()
+		if n <= peak || c.peak.CompareAndSwap(peak, n) {
+			break
+		}
+	}
+	time.Sleep(10 * time.Millisecond)
+	c.running.Add(-1)
+	return c, c.StartError
+}
+
+func TestParallelStart(t *testing.T) {
+	silenceTestStdout(t)
+
+	var running, peak atomic.Int32
+	newConcurrent := func() *ConcurrentComponent {
+		return &ConcurrentComponent{running: &running, peak: &peak}
+	}
+
+	var mu sync.Mutex
+	var started []string
+	system := CreateSystem(map[string]*Component{
+		"config": Define("config", newConcurrent()),
+		"db":     Define("db", newConcurrent(), "config"),
+		"cache":  Define("cache", newConcurrent(), "config"),
+		"queue":  Define("queue", newConcurrent(), "config"),
+		"search": Define("search", newConcurrent(), "config"),
+		"api":    Define("api", newConcurrent(), "db", "cache", "queue", "search"),
+	}, WithParallelStart(2), WithEventListener(func(e Event) {
+		if e.Type == EventComponentStarted {
+			mu.Lock()
+			started = append(started, e.Component)
+			mu.Unlock()
+		}
+	}))
+
+	if err := system.Start(); err != nil {
+		t.Fatalf("Failed to start system: %v", err)
+	}
+
+	if p := peak.Load(); p != 2 {
+		t.Errorf("Expected the four middle components to start two at a time, peak was %d", p)
+	}
+	order := system.EffectiveOrder()
+	if len(order) != 6 || order[0] != "config" || order[5] != "api" {
+		t.Errorf("Expected dependencies first, got %v", order)
+	}
+	for i := range order {
+		if order[i] != started[i] {
+			t.Fatalf("Expected the effective order to match observed starts, got %v and %v", order, started)
+		}
+	}
+
+	if err := system.Stop(); err != nil {
+		t.Fatalf("Failed to stop system: %v", err)
+	}
+}
+
+func TestParallelStartFailureStopsAtLevel(t *testing.T) {
+	silenceTestStdout(t)
+
+	var running, peak atomic.Int32
+	failing := &ConcurrentComponent{running: &running, peak: &peak}
+	failing.StartError = errors.New("unreachable")
+	api := &MockComponent{}
+	system := CreateSystem(map[string]*Component{
+		"db":    Define("db", failing),
+		"cache": Define("cache", &ConcurrentComponent{running: &running, peak: &peak}),
+		"api":   Define("api", api, "db", "cache"),
+	}, WithParallelStart(4))
+
+	err := system.Start()
+	var componentErr *ComponentError
+	if !errors.As(err, &componentErr) || componentErr.Key != "db" {
+		t.Fatalf("Expected db to fail, got %v", err)
+	}
+	if api.StartCalled {
+		t.Error("Expected the next level not to start")
+	}
+	if order := system.EffectiveOrder(); len(order) != 1 || order[0] != "cache" {
+		t.Errorf("Expected only cache recorded as started, got %v", order)
+	}
+}
+
+// SlowStopComponent tracks how many instances are stopping at once
+type SlowStopComponent struct {
+	MockComponent
+	key           string
+	running, peak *atomic.Int32
+	stopped       *[]string
+	mu            *sync.Mutex
+}
+
+func (c *SlowStopComponent) Start(ctx Context) (Lifecycle, error) {
+	return c, nil
+}
+
+func (c *SlowStopComponent) Stop(ctx Context) error {
+	n := c.running.Add(1)
+	for {
+		peak := c.peak.Load()
+		if n <= peak || c.peak.CompareAndSwap(peak, n) {
+			break
+		}
+	}
+	time.Sleep(10 * time.Millisecond)
+	c.running.Add(-1)
+
+	c.mu.Lock()
+	*c.stopped = append(*c.stopped, c.key)
+	c.mu.Unlock()
+	return c.StopError
+}
+
+func TestParallelStop(t *testing.T) {
+	silenceTestStdout(t)
+
+	var running, peak atomic.Int32
+	var mu sync.Mutex
+	var stopped []string
+	slow := func(key string) *SlowStopComponent {
+		return &SlowStopComponent{key: key, running: &running, peak: &peak, stopped: &stopped, mu: &mu}
+	}
+	system := CreateSystem(map[string]*Component{
+		"db":    Define("db", slow("db")),
+		"cache": Define("cache", slow("cache")),
+		"queue": Define("queue", slow("queue")),
+		"api":   Define("api", slow("api"), "db", "cache", "queue"),
+	}, WithParallelStop(3))
+
+	if err := system.Start(); err != nil {
+		t.Fatalf("Failed to start system: %v", err)
+	}
+	if err := system.Stop(); err != nil {
+		t.Fatalf("Failed to stop system: %v", err)
+	}
+
+	if len(stopped) != 4 || stopped[0] != "api" {
+		t.Errorf("Expected api to stop before its dependencies, got %v", stopped)
+	}
+	if p := peak.Load(); p != 3 {
+		t.Errorf("Expected the three dependencies to stop together, peak was %d", p)
+	}
+	if system.IsStarted() {
+		t.Error("Expected the system to be stopped")
+	}
+}
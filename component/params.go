@@ -0,0 +1,48 @@
+package component
+
+// ParamsContextKey holds the component's parameters in its Context
+const ParamsContextKey = ReservedPrefix + "params"
+
+// paramsHolder carries component parameters in a Context
+type paramsHolder struct {
+	value interface{}
+}
+
+func (p *paramsHolder) Start(ctx Context) (Lifecycle, error) {
+	return p, nil
+}
+
+func (p *paramsHolder) Stop(ctx Context) error {
+	return nil
+}
+
+// WithParams attaches parameters delivered to the component at Start,
+// separately from its dependencies, so one component type can be registered
+// several times with different settings
+func (c *Component) WithParams(params interface{}) *Component {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.params = params
+	return c
+}
+
+// GetParams returns the parameters attached to the component
+func (c *Component) GetParams() interface{} {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.params
+}
+
+// Params returns the parameters of the component the context was built for
+func (ctx Context) Params() interface{} {
+	if holder, ok := ctx[ParamsContextKey].(*paramsHolder); ok {
+		return holder.value
+	}
+	return nil
+}
+
+// ParamsAs returns the component parameters as type T
+func ParamsAs[T any](ctx Context) (T, bool) {
+	params, ok := ctx.Params().(T)
+	return params, ok
+}
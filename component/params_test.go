@@ -0,0 +1,40 @@
+package component
+
+import (
+	"testing"
+)
+
+type poolSettings struct {
+	Size int
+}
+
+func TestParamsDeliveredAtStart(t *testing.T) {
+	small := &ContextCapture{}
+	large := &ContextCapture{}
+
+	system := CreateSystem(map[string]*Component{
+		"pool_small": Define("pool_small", small).WithParams(poolSettings{Size: 2}),
+		"pool_large": Define("pool_large", large).WithParams(poolSettings{Size: 64}),
+		"plain":      Define("plain", &MockComponent{}),
+	})
+	if err := system.Start(); err != nil {
+		t.Fatalf("Failed to start system: %v", err)
+	}
+
+	if settings, ok := ParamsAs[poolSettings](small.Ctx); !ok || settings.Size != 2 {
+		t.Errorf("Expected small pool settings, got %v", small.Ctx.Params())
+	}
+	if settings, ok := ParamsAs[poolSettings](large.Ctx); !ok || settings.Size != 64 {
+		t.Errorf("Expected large pool settings, got %v", large.Ctx.Params())
+	}
+}
+
+func TestParamsAbsent(t *testing.T) {
+	ctx := Context{}
+	if ctx.Params() != nil {
+		t.Error("Expected no params in an empty context")
+	}
+	if _, ok := ParamsAs[poolSettings](ctx); ok {
+		t.Error("Expected ParamsAs to fail without params")
+	}
+}
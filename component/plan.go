@@ -0,0 +1,41 @@
+package component
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Plan returns the plan a Start from scratch would execute, validating the
+// wiring like Validate without starting anything. Its String form is meant
+// to be committed and diffed, so reviewers see how a change rewires the
+// start in a pull request
+func (s *System) Plan() (Plan, error) {
+	candidate, order, err := s.dryRun()
+	if err != nil {
+		return Plan{}, err
+	}
+	return candidate.startPlan(order), nil
+}
+
+// String renders the plan batch by batch, one component per line with the
+// components it waits for: "+" for a start and "-" for a stop
+func (p Plan) String() string {
+	verb, sign, waits := "start", "+", "waits for"
+	if p.Op == OpStop {
+		verb, sign, waits = "stop", "-", "after"
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s %d components in %d batches\n", verb, len(p.Steps), len(p.Levels))
+	for i, level := range p.Levels {
+		fmt.Fprintf(&b, "\nbatch %d:\n", i+1)
+		for _, key := range level {
+			fmt.Fprintf(&b, "  %s %s", sign, key)
+			if deps := p.Waits[key]; len(deps) > 0 {
+				fmt.Fprintf(&b, " (%s %s)", waits, strings.Join(deps, ", "))
+			}
+			b.WriteString("\n")
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,51 @@
+package component
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSystemPlan(t *testing.T) {
+	db := &MockComponent{}
+	system := CreateSystem(map[string]*Component{
+		"db":    Define("db", db),
+		"cache": Define("cache", &MockComponent{}),
+		"api":   Define("api", &MockComponent{}, "db", "cache"),
+	})
+
+	plan, err := system.Plan()
+	if err != nil {
+		t.Fatalf("Failed to plan: %v", err)
+	}
+	want := `start 3 components in 2 batches
+
+batch 1:
+  + cache
+  + db
+
+batch 2:
+  + api (waits for db, cache)
+`
+	if plan.String() != want {
+		t.Errorf("Expected:\n%s\ngot:\n%s", want, plan)
+	}
+	if db.StartCalled || system.IsStarted() {
+		t.Error("Expected Plan not to start anything")
+	}
+}
+
+func TestSystemPlanInvalidWiring(t *testing.T) {
+	system := CreateSystem(map[string]*Component{
+		"api": Define("api", &MockComponent{}, "db"),
+	})
+	if _, err := system.Plan(); err == nil || !strings.Contains(err.Error(), "dependency db not found") {
+		t.Errorf("Expected the missing dependency reported, got %v", err)
+	}
+}
+
+func TestStopPlanString(t *testing.T) {
+	plan := Plan{Op: OpStop, Steps: []string{"api", "db"}, Levels: [][]string{{"api"}, {"db"}}, Waits: map[string][]string{"db": {"api"}}}
+	if got := plan.String(); !strings.Contains(got, "stop 2 components") || !strings.Contains(got, "  - db (after api)") {
+		t.Errorf("Expected a stop rendering, got:\n%s", got)
+	}
+}
@@ -0,0 +1,89 @@
+package component
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// DefaultPrefetchTimeout bounds the prefetch phase when no timeout is configured
+const DefaultPrefetchTimeout = 30 * time.Second
+
+const EventComponentPrefetched EventType = "component_prefetched"
+
+// Prefetcher is an optional interface for components that can warm up
+// remote resources (DNS lookups, TLS handshakes, token fetches) before
+// their turn to start. Prefetch runs in parallel for every component before
+// the ordered start, so it must not rely on dependencies
+type Prefetcher interface {
+	Prefetch(ctx context.Context) error
+}
+
+// WithPrefetchTimeout sets the deadline shared by all Prefetch calls
+func WithPrefetchTimeout(timeout time.Duration) Option {
+	return func(s *System) {
+		s.prefetchTimeout = timeout
+	}
+}
+
+// prefetchResult is the outcome of one Prefetch call
+type prefetchResult struct {
+	component *Component
+	duration  time.Duration
+	err       error
+}
+
+// runPrefetch calls Prefetch on every component that will start, in
+// parallel, cancelling the others as soon as one fails
+func (s *System) runPrefetch(orderedComponents []string, correlationID string) error {
+	var results []*prefetchResult
+	for _, name := range orderedComponents {
+		component := s.components[name]
+		if _, ok := component.instance.(Prefetcher); ok && !component.IsStarted() {
+			results = append(results, &prefetchResult{component: component})
+		}
+	}
+	if len(results) == 0 {
+		return nil
+	}
+
+	timeout := s.prefetchTimeout
+	if timeout <= 0 {
+		timeout = DefaultPrefetchTimeout
+	}
+	ctx, cancel := context.WithTimeout(s.bootContext(), timeout)
+	defer cancel()
+
+	var wg sync.WaitGroup
+	for _, result := range results {
+		wg.Add(1)
+		go func(result *prefetchResult) {
+			defer wg.Done()
+			startTime := time.Now()
+			result.err = result.component.instance.(Prefetcher).Prefetch(ctx)
+			result.duration = time.Since(startTime)
+			if result.err != nil {
+				cancel()
+			}
+		}(result)
+	}
+	wg.Wait()
+
+	// Emit in start order once every call returned, so listeners are not
+	// called concurrently
+	var errs []error
+	for _, result := range results {
+		event := componentEvent(EventComponentPrefetched, result.component, correlationID)
+		event.Duration = result.duration
+		event.Err = result.err
+		s.emit(event)
+
+		// Calls cancelled because another prefetch failed are not causes
+		if result.err != nil && !errors.Is(result.err, context.Canceled) {
+			errs = append(errs, &ComponentError{Key: result.component.key, Op: OpStart, Err: fmt.Errorf("prefetch failed: %w", result.err)})
+		}
+	}
+	return errors.Join(errs...)
+}
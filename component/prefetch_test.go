@@ -0,0 +1,101 @@
+package component
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+// WarmingComponent records when it prefetched relative to starting
+type WarmingComponent struct {
+	MockComponent
+	PrefetchErr error
+	Delay       time.Duration
+	Barrier     *sync.WaitGroup
+	log         *[]string
+	mu          *sync.Mutex
+	name        string
+}
+
+func (w *WarmingComponent) Prefetch(ctx context.Context) error {
+	if w.Barrier != nil {
+		// Returns only once every prefetch is running at the same time
+		w.Barrier.Done()
+		w.Barrier.Wait()
+	}
+	select {
+	case <-time.After(w.Delay):
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	w.mu.Lock()
+	*w.log = append(*w.log, "prefetch "+w.name)
+	w.mu.Unlock()
+	return w.PrefetchErr
+}
+
+func (w *WarmingComponent) Start(ctx Context) (Lifecycle, error) {
+	w.mu.Lock()
+	*w.log = append(*w.log, "start "+w.name)
+	w.mu.Unlock()
+	return w, nil
+}
+
+func TestPrefetchRunsInParallelBeforeStart(t *testing.T) {
+	silenceTestStdout(t)
+
+	var log []string
+	var mu sync.Mutex
+	var barrier sync.WaitGroup
+	barrier.Add(2)
+	newWarming := func(name string) *WarmingComponent {
+		return &WarmingComponent{Barrier: &barrier, log: &log, mu: &mu, name: name}
+	}
+	system := CreateSystem(map[string]*Component{
+		"db":    Define("db", newWarming("db")),
+		"cache": Define("cache", newWarming("cache"), "db"),
+	})
+
+	// Serial prefetches would deadlock on the barrier
+	errc := make(chan error, 1)
+	go func() { errc <- system.Start() }()
+	select {
+	case err := <-errc:
+		if err != nil {
+			t.Fatalf("Failed to start system: %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Expected prefetches to run in parallel")
+	}
+
+	if len(log) != 4 || log[2] != "start db" || log[3] != "start cache" {
+		t.Errorf("Expected both prefetches before the ordered start, got %v", log)
+	}
+}
+
+func TestPrefetchFailureAbortsStart(t *testing.T) {
+	silenceTestStdout(t)
+
+	var log []string
+	var mu sync.Mutex
+	system := CreateSystem(map[string]*Component{
+		"token": Define("token", &WarmingComponent{PrefetchErr: errors.New("401"), log: &log, mu: &mu, name: "token"}),
+		"slow":  Define("slow", &WarmingComponent{Delay: time.Second, log: &log, mu: &mu, name: "slow"}),
+	})
+
+	err := system.Start()
+	var componentErr *ComponentError
+	if !errors.As(err, &componentErr) || componentErr.Key != "token" {
+		t.Fatalf("Expected a prefetch error for token, got %v", err)
+	}
+	if errors.Is(err, context.Canceled) {
+		t.Errorf("Expected the cancelled slow prefetch not to be reported, got %v", err)
+	}
+	for _, entry := range log {
+		if entry == "start token" || entry == "start slow" {
+			t.Errorf("Expected no component to start, got %v", log)
+		}
+	}
+}
@@ -0,0 +1,191 @@
+package component
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultPreflightTimeout bounds the preflight phase when no timeout is set
+const DefaultPreflightTimeout = 30 * time.Second
+
+// EventPreflightCompleted is emitted once every preflight check has finished
+const EventPreflightCompleted EventType = "preflight_completed"
+
+// PreflightCheck verifies an environment precondition before any component starts
+type PreflightCheck struct {
+	Name string
+
+	// Critical checks abort the boot when they fail
+	Critical bool
+
+	Check func(ctx context.Context) error
+}
+
+// PreflightResult is the outcome of a single preflight check
+type PreflightResult struct {
+	Name     string
+	Critical bool
+	Err      error
+	Duration time.Duration
+}
+
+// PreflightReport aggregates the results of the preflight phase. It is
+// returned as the Start error when any critical check fails
+type PreflightReport struct {
+	Results []PreflightResult
+}
+
+// Failed returns the results of the checks that did not pass
+func (r *PreflightReport) Failed() []PreflightResult {
+	var failed []PreflightResult
+	for _, result := range r.Results {
+		if result.Err != nil {
+			failed = append(failed, result)
+		}
+	}
+	return failed
+}
+
+// CriticalFailure reports whether any critical check failed
+func (r *PreflightReport) CriticalFailure() bool {
+	for _, result := range r.Failed() {
+		if result.Critical {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *PreflightReport) Error() string {
+	var b strings.Builder
+	b.WriteString("preflight checks failed:")
+	for _, result := range r.Failed() {
+		severity := "warning"
+		if result.Critical {
+			severity = "critical"
+		}
+		fmt.Fprintf(&b, "\n  [%s] %s: %v", severity, result.Name, result.Err)
+	}
+	return b.String()
+}
+
+// Unwrap returns the errors of the failed checks
+func (r *PreflightReport) Unwrap() []error {
+	var errs []error
+	for _, result := range r.Failed() {
+		errs = append(errs, result.Err)
+	}
+	return errs
+}
+
+// WithPreflight registers checks run in parallel before any component starts
+func WithPreflight(checks ...PreflightCheck) Option {
+	return func(s *System) {
+		s.preflight = append(s.preflight, checks...)
+	}
+}
+
+// WithPreflightTimeout sets the deadline shared by all preflight checks
+func WithPreflightTimeout(timeout time.Duration) Option {
+	return func(s *System) {
+		s.preflightTimeout = timeout
+	}
+}
+
+// RequireEnv checks that the given environment variables are set
+func RequireEnv(names ...string) PreflightCheck {
+	return PreflightCheck{
+		Name:     "env " + strings.Join(names, ","),
+		Critical: true,
+		Check: func(ctx context.Context) error {
+			var missing []string
+			for _, name := range names {
+				if _, ok := os.LookupEnv(name); !ok {
+					missing = append(missing, name)
+				}
+			}
+			if len(missing) > 0 {
+				return fmt.Errorf("missing environment variables: %s", strings.Join(missing, ", "))
+			}
+			return nil
+		},
+	}
+}
+
+// Reachable checks that a network endpoint accepts connections
+func Reachable(network, address string) PreflightCheck {
+	return PreflightCheck{
+		Name:     "reachable " + address,
+		Critical: true,
+		Check: func(ctx context.Context) error {
+			var dialer net.Dialer
+			conn, err := dialer.DialContext(ctx, network, address)
+			if err != nil {
+				return err
+			}
+			return conn.Close()
+		},
+	}
+}
+
+// runPreflight runs every registered check in parallel under the deadline
+func (s *System) runPreflight(correlationID string) error {
+	if len(s.preflight) == 0 {
+		return nil
+	}
+
+	timeout := s.preflightTimeout
+	if timeout <= 0 {
+		timeout = DefaultPreflightTimeout
+	}
+	ctx, cancel := context.WithTimeout(s.bootContext(), timeout)
+	defer cancel()
+
+	startTime := time.Now()
+	report := &PreflightReport{Results: make([]PreflightResult, len(s.preflight))}
+
+	var wg sync.WaitGroup
+	for i, check := range s.preflight {
+		wg.Add(1)
+		go func(i int, check PreflightCheck) {
+			defer wg.Done()
+			report.Results[i] = runCheck(ctx, check)
+		}(i, check)
+	}
+	wg.Wait()
+
+	var err error
+	if report.CriticalFailure() {
+		err = report
+	} else if len(report.Failed()) > 0 {
+		s.Logger().Error(s.Catalog().Message(MsgPreflightWarning, report.Error()))
+	}
+
+	s.emit(Event{Type: EventPreflightCompleted, CorrelationID: correlationID, Duration: time.Since(startTime), Err: err})
+	return err
+}
+
+// runCheck runs a single check, abandoning it when the deadline passes
+func runCheck(ctx context.Context, check PreflightCheck) PreflightResult {
+	result := PreflightResult{Name: check.Name, Critical: check.Critical}
+	startTime := time.Now()
+
+	done := make(chan error, 1)
+	go func() {
+		done <- check.Check(ctx)
+	}()
+
+	select {
+	case result.Err = <-done:
+	case <-ctx.Done():
+		result.Err = ctx.Err()
+	}
+
+	result.Duration = time.Since(startTime)
+	return result
+}
@@ -0,0 +1,79 @@
+package component
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestPreflightCriticalFailureAbortsBoot(t *testing.T) {
+	compA := &MockComponent{}
+	system := CreateSystem(map[string]*Component{
+		"compA": Define("compA", compA),
+	}, WithPreflight(
+		PreflightCheck{Name: "disk", Critical: true, Check: func(ctx context.Context) error {
+			return errors.New("not enough space")
+		}},
+		PreflightCheck{Name: "optional", Check: func(ctx context.Context) error {
+			return errors.New("degraded")
+		}},
+		PreflightCheck{Name: "ok", Critical: true, Check: func(ctx context.Context) error {
+			return nil
+		}},
+	))
+
+	err := system.Start()
+	var report *PreflightReport
+	if !errors.As(err, &report) {
+		t.Fatalf("Expected preflight report, got %v", err)
+	}
+	if len(report.Failed()) != 2 {
+		t.Errorf("Expected 2 failed checks, got %d", len(report.Failed()))
+	}
+	if compA.StartCalled {
+		t.Error("No component should start when a critical preflight check fails")
+	}
+}
+
+func TestPreflightNonCriticalFailureContinues(t *testing.T) {
+	compA := &MockComponent{}
+	system := CreateSystem(map[string]*Component{
+		"compA": Define("compA", compA),
+	}, WithPreflight(PreflightCheck{Name: "optional", Check: func(ctx context.Context) error {
+		return errors.New("degraded")
+	}}))
+
+	if err := system.Start(); err != nil {
+		t.Fatalf("Expected boot to continue, got %v", err)
+	}
+	if !compA.StartCalled {
+		t.Error("Expected component to start")
+	}
+}
+
+func TestPreflightDeadline(t *testing.T) {
+	system := CreateSystem(map[string]*Component{
+		"compA": Define("compA", &MockComponent{}),
+	}, WithPreflightTimeout(10*time.Millisecond), WithPreflight(PreflightCheck{
+		Name:     "hang",
+		Critical: true,
+		Check: func(ctx context.Context) error {
+			select {}
+		},
+	}))
+
+	if err := system.Start(); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Expected deadline exceeded, got %v", err)
+	}
+}
+
+func TestRequireEnv(t *testing.T) {
+	t.Setenv("PREFLIGHT_PRESENT", "1")
+	if err := RequireEnv("PREFLIGHT_PRESENT").Check(context.Background()); err != nil {
+		t.Errorf("Expected present variable to pass, got %v", err)
+	}
+	if err := RequireEnv("PREFLIGHT_PRESENT", "PREFLIGHT_MISSING").Check(context.Background()); err == nil {
+		t.Error("Expected missing variable to fail")
+	}
+}
@@ -0,0 +1,91 @@
+package component
+
+import (
+	"fmt"
+	"strings"
+)
+
+// MultiProvider is an optional interface for start results that publish
+// several named results, one for each key declared with Provides
+type MultiProvider interface {
+	Provided() map[string]Lifecycle
+}
+
+// Provides declares additional keys published by the component. Dependents
+// may depend on any of them and the graph treats them as edges to this component
+func (c *Component) Provides(keys ...string) *Component {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.provides = append(c.provides, keys...)
+	return c
+}
+
+// GetProvides returns the additional keys published by the component
+func (c *Component) GetProvides() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]string(nil), c.provides...)
+}
+
+// buildProviders maps every published key to the component providing it,
+// reporting every reserved or conflicting key. Conflicting keys map to the
+// component first in key order
+func (s *System) buildProviders() error {
+	var errs []error
+	providers := make(map[string]string, len(s.components))
+	for _, name := range s.sortedKeys() {
+		if strings.HasPrefix(name, ReservedPrefix) {
+			errs = append(errs, fmt.Errorf("component key %s uses the reserved prefix %s", name, ReservedPrefix))
+		}
+		providers[name] = name
+	}
+
+	for _, name := range s.sortedKeys() {
+		for _, key := range s.components[name].GetProvides() {
+			if strings.HasPrefix(key, ReservedPrefix) {
+				errs = append(errs, fmt.Errorf("key %s provided by component %s uses the reserved prefix %s", key, name, ReservedPrefix))
+				continue
+			}
+			if owner, exists := providers[key]; exists {
+				errs = append(errs, fmt.Errorf("key %s provided by component %s is already provided by %s", key, name, owner))
+				continue
+			}
+			providers[key] = name
+		}
+	}
+
+	s.providers = providers
+	return joinErrors(errs)
+}
+
+// resolve returns the key of the component providing a dependency
+func (s *System) resolve(dep string) (string, bool) {
+	if provider, ok := s.providers[dep]; ok {
+		return provider, true
+	}
+	_, ok := s.components[dep]
+	return dep, ok
+}
+
+// publish stores a component's results in the system context
+func (s *System) publish(component *Component, result Lifecycle) error {
+	provides := component.GetProvides()
+	if len(provides) > 0 {
+		multi, ok := result.(MultiProvider)
+		if !ok {
+			return fmt.Errorf("component %s declares provided keys but its result does not implement MultiProvider", component.key)
+		}
+
+		provided := multi.Provided()
+		for _, key := range provides {
+			value, exists := provided[key]
+			if !exists {
+				return fmt.Errorf("component %s did not provide key %s", component.key, key)
+			}
+			s.context[key] = value
+		}
+	}
+
+	s.context[component.key] = result
+	return nil
+}
@@ -0,0 +1,78 @@
+package component
+
+import (
+	"testing"
+)
+
+// Connections publishes a read and a write connection
+type Connections struct {
+	Read  *MockComponent
+	Write *MockComponent
+}
+
+func (c *Connections) Start(ctx Context) (Lifecycle, error) {
+	c.Read = &MockComponent{Key: "db.read"}
+	c.Write = &MockComponent{Key: "db.write"}
+	return c, nil
+}
+
+func (c *Connections) Stop(ctx Context) error {
+	return nil
+}
+
+func (c *Connections) Provided() map[string]Lifecycle {
+	return map[string]Lifecycle{
+		"db.read":  c.Read,
+		"db.write": c.Write,
+	}
+}
+
+// ContextCapture records the context it was started with
+type ContextCapture struct {
+	MockComponent
+	Ctx Context
+}
+
+func (c *ContextCapture) Start(ctx Context) (Lifecycle, error) {
+	c.Ctx = ctx
+	return c.MockComponent.Start(ctx)
+}
+
+func TestProvidesMultipleKeys(t *testing.T) {
+	conns := &Connections{}
+	reader := &ContextCapture{}
+
+	system := CreateSystem(map[string]*Component{
+		"db":     Define("db", conns).Provides("db.read", "db.write"),
+		"report": Define("report", reader, "db.read"),
+	})
+	if err := system.Start(); err != nil {
+		t.Fatalf("Failed to start system: %v", err)
+	}
+
+	if reader.Ctx["db.read"] != conns.Read {
+		t.Errorf("Expected db.read to resolve to the read connection, got %v", reader.Ctx["db.read"])
+	}
+	if ctx := system.GetContext(); ctx["db.write"] != conns.Write {
+		t.Errorf("Expected db.write in system context, got %v", ctx["db.write"])
+	}
+}
+
+func TestProvidesMissingKey(t *testing.T) {
+	system := CreateSystem(map[string]*Component{
+		"db": Define("db", &Connections{}).Provides("db.read", "db.admin"),
+	})
+	if err := system.Start(); err == nil {
+		t.Fatal("Expected start to fail when a declared key is not provided")
+	}
+}
+
+func TestProvidesDuplicateKey(t *testing.T) {
+	system := CreateSystem(map[string]*Component{
+		"db":      Define("db", &Connections{}).Provides("db.read", "db.write"),
+		"db.read": Define("db.read", &MockComponent{}),
+	})
+	if err := system.Start(); err == nil {
+		t.Fatal("Expected start to fail when a provided key collides with a component key")
+	}
+}
@@ -0,0 +1,90 @@
+package component
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// DefaultQuarantineThreshold is the number of consecutive failed stops after
+// which a component is quarantined
+const DefaultQuarantineThreshold = 3
+
+const (
+	EventComponentQuarantined EventType = "component_quarantined"
+	EventQuarantineCleared    EventType = "quarantine_cleared"
+)
+
+// ErrQuarantined is returned when starting a quarantined component
+var ErrQuarantined = errors.New("component is quarantined after repeated stop failures")
+
+// WithQuarantineThreshold quarantines a component after n consecutive failed
+// stops. A value of zero or less disables quarantine
+func WithQuarantineThreshold(n int) Option {
+	return func(s *System) {
+		if n <= 0 {
+			n = -1
+		}
+		s.quarantineThreshold = n
+	}
+}
+
+// recordStopResult counts consecutive stop failures and quarantines the
+// component once the threshold is reached; the caller must hold s.mu
+func (s *System) recordStopResult(component *Component, correlationID string, err error) {
+	if err == nil {
+		component.stopFailures = 0
+		return
+	}
+
+	component.stopFailures++
+	threshold := s.quarantineThreshold
+	if threshold == 0 {
+		threshold = DefaultQuarantineThreshold
+	}
+	if threshold < 0 || component.stopFailures < threshold {
+		return
+	}
+
+	if component.transition(StateQuarantined) != nil {
+		return
+	}
+	event := componentEvent(EventComponentQuarantined, component, correlationID)
+	event.Err = fmt.Errorf("%d consecutive stop failures: %w", component.stopFailures, err)
+	s.emit(event)
+}
+
+// Quarantined returns the keys of quarantined components
+func (s *System) Quarantined() []string {
+	var keys []string
+	for key, component := range s.snapshot().components {
+		if component.State() == StateQuarantined {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// ClearQuarantine releases a quarantined component so the next Start or
+// Restart starts it again. It is meant as an explicit operator action once
+// the leaked resources have been dealt with
+func (s *System) ClearQuarantine(key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	component, exists := s.components[key]
+	if !exists {
+		return fmt.Errorf("component %s not found", key)
+	}
+	if component.State() != StateQuarantined {
+		return fmt.Errorf("component %s is %s, not quarantined", key, component.State())
+	}
+
+	if err := component.transition(StateStopped); err != nil {
+		return err
+	}
+	component.stopFailures = 0
+	s.emit(componentEvent(EventQuarantineCleared, component, ""))
+	return nil
+}
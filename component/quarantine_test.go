@@ -0,0 +1,72 @@
+package component
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestQuarantineAfterRepeatedStopFailures(t *testing.T) {
+	silenceTestStdout(t)
+
+	var quarantined []Event
+	leaky := &MockComponent{StopError: errors.New("goroutine leaked")}
+	system := CreateSystem(map[string]*Component{
+		"worker": Define("worker", leaky),
+	}, WithQuarantineThreshold(2), WithEventListener(func(e Event) {
+		if e.Type == EventComponentQuarantined {
+			quarantined = append(quarantined, e)
+		}
+	}))
+
+	for i := 0; i < 2; i++ {
+		if err := system.Start(); err != nil {
+			t.Fatalf("Failed to start system: %v", err)
+		}
+		if err := system.Stop(); err == nil {
+			t.Fatal("Expected stop to fail")
+		}
+	}
+
+	if len(quarantined) != 1 {
+		t.Fatalf("Expected one quarantine event, got %d", len(quarantined))
+	}
+	if keys := system.Quarantined(); len(keys) != 1 || keys[0] != "worker" {
+		t.Errorf("Expected worker quarantined, got %v", keys)
+	}
+	if err := system.Start(); !errors.Is(err, ErrQuarantined) {
+		t.Fatalf("Expected start to refuse the quarantined component, got %v", err)
+	}
+
+	if err := system.ClearQuarantine("worker"); err != nil {
+		t.Fatalf("Failed to clear quarantine: %v", err)
+	}
+	if err := system.ClearQuarantine("worker"); err == nil {
+		t.Error("Expected clearing a component that is not quarantined to fail")
+	}
+	leaky.StopError = nil
+	if err := system.Start(); err != nil {
+		t.Fatalf("Failed to start after clearing quarantine: %v", err)
+	}
+	if err := system.Stop(); err != nil {
+		t.Fatalf("Failed to stop: %v", err)
+	}
+}
+
+func TestQuarantineResetsOnSuccessfulStop(t *testing.T) {
+	silenceTestStdout(t)
+
+	flaky := &MockComponent{}
+	system := CreateSystem(map[string]*Component{
+		"worker": Define("worker", flaky),
+	}, WithQuarantineThreshold(2))
+
+	for _, stopErr := range []error{errors.New("timeout"), nil, errors.New("timeout")} {
+		flaky.StopError = stopErr
+		system.Start()
+		system.Stop()
+	}
+
+	if keys := system.Quarantined(); len(keys) != 0 {
+		t.Errorf("Expected failures separated by a clean stop not to quarantine, got %v", keys)
+	}
+}
@@ -0,0 +1,107 @@
+package component
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// ReadinessChecker is an optional interface for components that are alive
+// once Start returns but can only serve later, e.g. an HTTP server warming
+// its routes. A component without it is ready whenever it runs
+type ReadinessChecker interface {
+	Ready(ctx context.Context) error
+}
+
+// LivenessChecker is an optional interface for components that can tell
+// whether they are still working at all, such as detecting a deadlocked
+// worker. A component without it is alive when its HealthChecker passes,
+// or whenever it runs
+type LivenessChecker interface {
+	Alive(ctx context.Context) error
+}
+
+// ProbeError reports a component failing a readiness or liveness probe
+type ProbeError struct {
+	Key string
+	Err error
+}
+
+func (e *ProbeError) Error() string {
+	return fmt.Sprintf("component %s: %v", e.Key, e.Err)
+}
+
+func (e *ProbeError) Unwrap() error {
+	return e.Err
+}
+
+// ErrNotRunning is reported by Ready for a component that is not running
+var ErrNotRunning = errors.New("not running")
+
+// Ready reports whether the system can take traffic: it is started and
+// every component runs, or completed, and passes its readiness check. The
+// error joins a ProbeError per component that is not ready
+func (s *System) Ready() error {
+	if !s.started.Load() {
+		return errors.New("system is not started")
+	}
+
+	snapshot := s.snapshot()
+	checks := s.runChecks(snapshot, func(component *Component) (func(context.Context) error, bool) {
+		checker, ok := runningAs[ReadinessChecker](component)
+		if !ok {
+			return nil, false
+		}
+		return checker.Ready, true
+	})
+
+	var errs []error
+	for _, key := range snapshot.order {
+		component := snapshot.components[key]
+		switch state := component.State(); {
+		case state != StateStarted && state != StateDegraded && state != StateCompleted:
+			errs = append(errs, &ProbeError{Key: key, Err: fmt.Errorf("%w (%s)", ErrNotRunning, state)})
+		case checks[key] != nil:
+			errs = append(errs, &ProbeError{Key: key, Err: checks[key]})
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Alive reports whether the running components are still working, so
+// tooling can decide to restart the process. A system that is starting or
+// stopped is alive. The error joins a ProbeError per failing component
+func (s *System) Alive() error {
+	snapshot := s.snapshot()
+	checks := s.runChecks(snapshot, func(component *Component) (func(context.Context) error, bool) {
+		if checker, ok := runningAs[LivenessChecker](component); ok {
+			return checker.Alive, true
+		}
+		if checker, ok := runningAs[HealthChecker](component); ok {
+			return checker.Health, true
+		}
+		return nil, false
+	})
+
+	var errs []error
+	for _, key := range snapshot.order {
+		if err := checks[key]; err != nil {
+			errs = append(errs, &ProbeError{Key: key, Err: err})
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// runningAs returns the Start result, or else the instance, of a running
+// component when it implements T
+func runningAs[T any](component *Component) (T, bool) {
+	var zero T
+	if state := component.State(); state != StateStarted && state != StateDegraded {
+		return zero, false
+	}
+	if implementation, ok := component.Result().(T); ok {
+		return implementation, true
+	}
+	implementation, ok := component.instance.(T)
+	return implementation, ok
+}
@@ -0,0 +1,99 @@
+package component
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// WarmingServer is alive once started but ready only after warming up
+type WarmingServer struct {
+	MockComponent
+	Warm     atomic.Bool
+	Deadlock atomic.Bool
+}
+
+func (w *WarmingServer) Ready(ctx context.Context) error {
+	if !w.Warm.Load() {
+		return errors.New("routes warming up")
+	}
+	return nil
+}
+
+func (w *WarmingServer) Alive(ctx context.Context) error {
+	if w.Deadlock.Load() {
+		return errors.New("event loop stuck")
+	}
+	return nil
+}
+
+func TestReadySeparateFromAlive(t *testing.T) {
+	silenceTestStdout(t)
+
+	server := &WarmingServer{}
+	system := CreateSystem(map[string]*Component{
+		"db":          Define("db", &MockComponent{}),
+		"http_server": Define("http_server", server, "db"),
+	})
+	if err := system.Ready(); err == nil {
+		t.Error("Expected a stopped system not to be ready")
+	}
+	if err := system.Start(); err != nil {
+		t.Fatalf("Failed to start system: %v", err)
+	}
+	defer system.Stop()
+
+	err := system.Ready()
+	var probeErr *ProbeError
+	if !errors.As(err, &probeErr) || probeErr.Key != "http_server" {
+		t.Errorf("Expected http_server not ready, got %v", err)
+	}
+	if err := system.Alive(); err != nil {
+		t.Errorf("Expected a warming server to be alive, got %v", err)
+	}
+
+	server.Warm.Store(true)
+	if err := system.Ready(); err != nil {
+		t.Errorf("Expected ready once warm, got %v", err)
+	}
+
+	server.Deadlock.Store(true)
+	if err := system.Alive(); !errors.As(err, &probeErr) || probeErr.Key != "http_server" {
+		t.Errorf("Expected http_server not alive, got %v", err)
+	}
+	if !system.HasCapability("http_server", CapabilityReadinessChecker) || !system.HasCapability("http_server", CapabilityLivenessChecker) {
+		t.Error("Expected readiness and liveness capabilities")
+	}
+}
+
+func TestReadyDoesNotWaitForComponentLock(t *testing.T) {
+	silenceTestStdout(t)
+	server := &WarmingServer{}
+	server.Warm.Store(true)
+	system := CreateSystem(map[string]*Component{
+		"http_server": Define("http_server", server),
+	})
+	if err := system.Start(); err != nil {
+		t.Fatalf("Failed to start system: %v", err)
+	}
+	defer system.Stop()
+
+	// Hold the lock a Start or Stop of the component would hold
+	component, _ := system.Component("http_server")
+	component.mu.Lock()
+	done := make(chan error, 1)
+	go func() {
+		done <- system.Ready()
+	}()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("Expected the system ready, got %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Error("Expected Ready not to wait for the component lock")
+	}
+	component.mu.Unlock()
+}
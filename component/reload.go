@@ -0,0 +1,140 @@
+package component
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+// GraphDiff lists how a component map differs from the registered one
+type GraphDiff struct {
+	Added   []string
+	Removed []string
+
+	// Changed components are registered under the same key with a different
+	// definition
+	Changed []string
+}
+
+// Empty reports whether the maps define the same graph
+func (d GraphDiff) Empty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Changed) == 0
+}
+
+// Changeset returns the changes turning the registered graph into components
+func (d GraphDiff) Changeset(components map[string]*Component) Changeset {
+	changes := Changeset{Remove: d.Removed}
+	for _, key := range d.Added {
+		changes.Add = append(changes.Add, components[key])
+	}
+	for _, key := range d.Changed {
+		changes.Swap = append(changes.Swap, components[key])
+	}
+	return changes
+}
+
+// Diff compares a component map with the registered components. Definitions
+// are compared by their declared inputs: an instance of the same type, as
+// its Redefiner judges it when implemented, with the same dependencies,
+// provided keys, params, tags, metadata, requirements, aliases, retry
+// policy and hooks, is unchanged even if it is a different pointer
+func (s *System) Diff(components map[string]*Component) (GraphDiff, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.diff(components)
+}
+
+// diff compares a component map with the registered one; the caller must hold s.mu
+func (s *System) diff(components map[string]*Component) (GraphDiff, error) {
+	var diff GraphDiff
+	for key, component := range components {
+		if component.key != key {
+			return GraphDiff{}, fmt.Errorf("component %s registered under key %s", component.key, key)
+		}
+		current, exists := s.components[key]
+		switch {
+		case !exists:
+			diff.Added = append(diff.Added, key)
+		case !sameDefinition(current, component):
+			diff.Changed = append(diff.Changed, key)
+		}
+	}
+	for key := range s.components {
+		if _, exists := components[key]; !exists {
+			diff.Removed = append(diff.Removed, key)
+		}
+	}
+
+	sort.Strings(diff.Added)
+	sort.Strings(diff.Removed)
+	sort.Strings(diff.Changed)
+	return diff, nil
+}
+
+// Redefiner is an optional interface for instances whose configuration a
+// reload should compare, e.g. a server built with a different address.
+// Instances that do not implement it are compared by type only, so the
+// state they build in Start never makes a definition look changed
+type Redefiner interface {
+	SameDefinition(other Lifecycle) bool
+}
+
+// definition is the declarative part of a component a reload compares
+type definition struct {
+	oneShot  bool
+	requires []Capability
+	aliases  map[string]string
+	retry    startRetry
+	hooks    int
+}
+
+// definition returns the declarative fields guarded by c.mu
+func (c *Component) definition() definition {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return definition{oneShot: c.oneShot, requires: c.requires, aliases: c.aliases, retry: c.retry, hooks: len(c.hooks)}
+}
+
+// sameDefinition reports whether two components are defined identically
+func sameDefinition(a, b *Component) bool {
+	if a == b {
+		return true
+	}
+	return sameInstance(a.instance, b.instance) &&
+		reflect.DeepEqual(a.definition(), b.definition()) &&
+		reflect.DeepEqual(a.GetDependencies(), b.GetDependencies()) &&
+		reflect.DeepEqual(a.GetProvides(), b.GetProvides()) &&
+		reflect.DeepEqual(a.GetParams(), b.GetParams()) &&
+		reflect.DeepEqual(a.GetTags(), b.GetTags()) &&
+		reflect.DeepEqual(a.GetMetadata(), b.GetMetadata())
+}
+
+// sameInstance reports whether two instances stand for the same definition
+func sameInstance(a, b Lifecycle) bool {
+	if reflect.TypeOf(a) != reflect.TypeOf(b) {
+		return false
+	}
+	if redefiner, ok := a.(Redefiner); ok {
+		return redefiner.SameDefinition(b)
+	}
+	return true
+}
+
+// Reload replaces the registered components with a new map, applying only
+// the difference: changed and removed components stop together with their
+// dependents, then the new graph starts as with Apply. Unchanged components
+// keep running with their registered definition
+func (s *System) Reload(components map[string]*Component) (GraphDiff, *ApplyReport, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	diff, err := s.diff(components)
+	if err != nil {
+		return diff, nil, err
+	}
+	if diff.Empty() {
+		return diff, &ApplyReport{Plan: &RestartPlan{}}, nil
+	}
+	report, err := s.applyLocked(diff.Changeset(components))
+	return diff, report, err
+}
@@ -0,0 +1,142 @@
+package component
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+// ConfiguredComponent is built from configuration and keeps no runtime state
+type ConfiguredComponent struct {
+	Address string
+}
+
+func (c *ConfiguredComponent) Start(ctx Context) (Lifecycle, error) {
+	return c, nil
+}
+
+func (c *ConfiguredComponent) Stop(ctx Context) error {
+	return nil
+}
+
+func (c *ConfiguredComponent) SameDefinition(other Lifecycle) bool {
+	return c.Address == other.(*ConfiguredComponent).Address
+}
+
+// ConnectionPool opens its connections in Start
+type ConnectionPool struct {
+	Size  int
+	conns []int
+}
+
+func (p *ConnectionPool) Start(ctx Context) (Lifecycle, error) {
+	for i := 0; i < p.Size; i++ {
+		p.conns = append(p.conns, i)
+	}
+	return p, nil
+}
+
+func (p *ConnectionPool) Stop(ctx Context) error {
+	p.conns = nil
+	return nil
+}
+
+func configuredGraph(apiAddress string) map[string]*Component {
+	return map[string]*Component{
+		"db":    Define("db", &ConfiguredComponent{Address: "db:5432"}),
+		"cache": Define("cache", &ConfiguredComponent{Address: "cache:6379"}),
+		"api":   Define("api", &ConfiguredComponent{Address: apiAddress}, "db"),
+	}
+}
+
+func TestReloadAppliesOnlyTheDiff(t *testing.T) {
+	silenceTestStdout(t)
+	system := CreateSystem(configuredGraph(":8080"))
+	if err := system.Start(); err != nil {
+		t.Fatalf("Failed to start system: %v", err)
+	}
+	defer system.Stop()
+
+	next := configuredGraph(":9090")
+	delete(next, "cache")
+	next["worker"] = Define("worker", &ConfiguredComponent{}, "db")
+
+	diff, report, err := system.Reload(next)
+	if err != nil {
+		t.Fatalf("Expected reload to succeed, got %v", err)
+	}
+	expected := GraphDiff{Added: []string{"worker"}, Removed: []string{"cache"}, Changed: []string{"api"}}
+	if !reflect.DeepEqual(diff, expected) {
+		t.Errorf("Expected diff %+v, got %+v", expected, diff)
+	}
+	for _, step := range report.Executed {
+		if step.Key == "db" {
+			t.Errorf("Expected unchanged db to keep running, got step %v", step)
+		}
+	}
+	var keys []string
+	for _, node := range system.Topology().Nodes {
+		keys = append(keys, node.Key)
+	}
+	if !reflect.DeepEqual(keys, []string{"api", "db", "worker"}) {
+		t.Errorf("Expected reloaded keys, got %v", keys)
+	}
+	if address := system.GetContext()["api"].(*ConfiguredComponent).Address; address != ":9090" {
+		t.Errorf("Expected api to run the new definition, got %s", address)
+	}
+}
+
+func TestReloadSameGraphIsNoop(t *testing.T) {
+	silenceTestStdout(t)
+	system := CreateSystem(configuredGraph(":8080"))
+	if err := system.Start(); err != nil {
+		t.Fatalf("Failed to start system: %v", err)
+	}
+	defer system.Stop()
+
+	diff, report, err := system.Reload(configuredGraph(":8080"))
+	if err != nil || !diff.Empty() || len(report.Executed) != 0 {
+		t.Errorf("Expected empty reload, got %+v %+v %v", diff, report, err)
+	}
+}
+
+func TestReloadIgnoresRuntimeStateOfInstances(t *testing.T) {
+	silenceTestStdout(t)
+	system := CreateSystem(map[string]*Component{
+		"pool": Define("pool", &ConnectionPool{Size: 2}),
+	})
+	if err := system.Start(); err != nil {
+		t.Fatalf("Failed to start system: %v", err)
+	}
+	defer system.Stop()
+
+	diff, err := system.Diff(map[string]*Component{
+		"pool": Define("pool", &ConnectionPool{Size: 2}),
+	})
+	if err != nil || !diff.Empty() {
+		t.Errorf("Expected the open connections not to count as a change, got %+v %v", diff, err)
+	}
+
+	diff, err = system.Diff(map[string]*Component{
+		"pool": Define("pool", &ConfiguredComponent{}),
+	})
+	if err != nil || !reflect.DeepEqual(diff.Changed, []string{"pool"}) {
+		t.Errorf("Expected a different instance type to change the pool, got %+v %v", diff, err)
+	}
+}
+
+func TestReloadRestartsOnRetryPolicyChange(t *testing.T) {
+	silenceTestStdout(t)
+	system := CreateSystem(configuredGraph(":8080"))
+	if err := system.Start(); err != nil {
+		t.Fatalf("Failed to start system: %v", err)
+	}
+	defer system.Stop()
+
+	next := configuredGraph(":8080")
+	next["db"].WithStartRetry(3, time.Millisecond)
+	diff, _, err := system.Reload(next)
+	if err != nil || !reflect.DeepEqual(diff.Changed, []string{"db"}) {
+		t.Errorf("Expected the new retry policy to change db, got %+v %v", diff, err)
+	}
+}
@@ -0,0 +1,150 @@
+package component
+
+import (
+	"fmt"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+// StartupReport describes the last System.Start: how long every component
+// took, the order they started in and the batches allowed to start
+// together, so callers can log, assert on or export a boot
+type StartupReport struct {
+	CorrelationID string        `json:"correlation_id"`
+	StartedAt     time.Time     `json:"started_at"`
+	Duration      time.Duration `json:"duration_ns"`
+
+	// Order is the effective start order of the components that started
+	Order []string `json:"order"`
+
+	// Batches are the topological levels of the start. Parallel reports
+	// whether the components of a batch started concurrently
+	Batches  [][]string `json:"batches"`
+	Parallel bool       `json:"parallel"`
+
+	// Components lists every component whose start was attempted, in the
+	// order the attempts finished
+	Components []ComponentStartup `json:"components"`
+
+	// Error is the error Start returned, if any
+	Error string `json:"error,omitempty"`
+}
+
+// ComponentStartup is the start of one component within a StartupReport
+type ComponentStartup struct {
+	Key string `json:"key"`
+
+	// Offset is when the start began, relative to StartedAt
+	Offset   time.Duration `json:"offset_ns"`
+	Duration time.Duration `json:"duration_ns"`
+	Batch    int           `json:"batch"`
+	Error    string        `json:"error,omitempty"`
+}
+
+// Failures returns the components that failed to start
+func (r StartupReport) Failures() []ComponentStartup {
+	var failures []ComponentStartup
+	for _, c := range r.Components {
+		if c.Error != "" {
+			failures = append(failures, c)
+		}
+	}
+	return failures
+}
+
+// Component returns the startup of the component under key
+func (r StartupReport) Component(key string) (ComponentStartup, bool) {
+	for _, c := range r.Components {
+		if c.Key == key {
+			return c, true
+		}
+	}
+	return ComponentStartup{}, false
+}
+
+func (r StartupReport) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "started in %v [correlation_id=%s]\n", r.Duration, r.CorrelationID)
+	w := tabwriter.NewWriter(&b, 0, 0, 2, ' ', 0)
+	for _, c := range r.Components {
+		fmt.Fprintf(w, "  %s\tbatch %d\t+%v\t%v", c.Key, c.Batch, c.Offset, c.Duration)
+		if c.Error != "" {
+			fmt.Fprintf(w, "\t%s", c.Error)
+		}
+		fmt.Fprintln(w)
+	}
+	w.Flush()
+	if r.Error != "" {
+		fmt.Fprintf(&b, "error: %s\n", r.Error)
+	}
+	return b.String()
+}
+
+// Report returns the StartupReport of the last Start, or an empty report
+// before the first one. During a Start it describes the progress so far
+func (s *System) Report() StartupReport {
+	s.shared.Lock()
+	defer s.shared.Unlock()
+
+	report := s.report
+	report.Order = append([]string(nil), report.Order...)
+	report.Batches = append([][]string(nil), report.Batches...)
+	report.Components = append([]ComponentStartup(nil), report.Components...)
+	return report
+}
+
+// beginReport starts the report of a Start
+func (s *System) beginReport(correlationID string, startedAt time.Time) {
+	s.shared.Lock()
+	defer s.shared.Unlock()
+	s.report = StartupReport{CorrelationID: correlationID, StartedAt: startedAt}
+	s.reportBatch = nil
+}
+
+// reportPlan records the batches of the start plan
+func (s *System) reportPlan(plan Plan, executor Executor) {
+	s.shared.Lock()
+	defer s.shared.Unlock()
+
+	_, sequential := executor.(SequentialExecutor)
+	s.report.Parallel = !sequential
+	s.report.Batches = plan.Levels
+	s.reportBatch = make(map[string]int, len(plan.Steps))
+	for batch, level := range plan.Levels {
+		for _, key := range level {
+			s.reportBatch[key] = batch
+		}
+	}
+}
+
+// reportComponent records the start of a component within a Start; the
+// caller must hold s.shared
+func (s *System) reportComponent(component *Component, startTime time.Time, event Event) {
+	if s.reportBatch == nil {
+		return
+	}
+	startup := ComponentStartup{
+		Key:      component.key,
+		Offset:   startTime.Sub(s.report.StartedAt),
+		Duration: event.Duration,
+		Batch:    s.reportBatch[component.key],
+	}
+	if event.Err != nil {
+		startup.Error = event.Err.Error()
+	}
+	s.report.Components = append(s.report.Components, startup)
+}
+
+// finishReport completes the report of a Start
+func (s *System) finishReport(duration time.Duration, err error) {
+	s.shared.Lock()
+	defer s.shared.Unlock()
+
+	s.report.Duration = duration
+	s.report.Order = append([]string(nil), s.startOrder...)
+	if err != nil {
+		s.report.Error = err.Error()
+	}
+	s.reportBatch = nil
+}
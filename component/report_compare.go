@@ -0,0 +1,218 @@
+package component
+
+import (
+	"fmt"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+// Thresholds select the duration changes a ReportComparison highlights. A
+// change is highlighted when it reaches every threshold set, so a tiny
+// component doubling from 1µs to 2µs is not flagged when Absolute is set
+type Thresholds struct {
+	// Ratio is a change relative to the earlier duration, e.g. 0.2 for 20%
+	Ratio float64
+
+	// Absolute is a change in duration
+	Absolute time.Duration
+}
+
+// ReportComparison is the difference between two boots, e.g. before and
+// after a release
+type ReportComparison struct {
+	Duration DurationDelta `json:"duration"`
+
+	// Components lists the components started by either boot, in the start
+	// order of the later one followed by those only the earlier one started
+	Components []ComponentDelta `json:"components"`
+
+	// Added and Removed are the components only the later or only the
+	// earlier boot attempted to start
+	Added   []string `json:"added,omitempty"`
+	Removed []string `json:"removed,omitempty"`
+
+	// Moved lists the components started by both boots at a different
+	// position in the start order
+	Moved []string `json:"moved,omitempty"`
+}
+
+// DurationDelta is the change of one duration between two boots
+type DurationDelta struct {
+	Before time.Duration `json:"before_ns"`
+	After  time.Duration `json:"after_ns"`
+	Delta  time.Duration `json:"delta_ns"`
+
+	// Ratio is Delta relative to Before, zero when Before is zero
+	Ratio       float64 `json:"ratio"`
+	Highlighted bool    `json:"highlighted"`
+}
+
+// ComponentDelta is the change of one component between two boots. The
+// positions are indexes in the start orders, -1 when the component did not
+// start
+type ComponentDelta struct {
+	Key string `json:"key"`
+	DurationDelta
+	BeforePosition int `json:"before_position"`
+	AfterPosition  int `json:"after_position"`
+}
+
+// CompareReports compares two startup reports, highlighting the duration
+// changes that reach the thresholds
+func CompareReports(before, after StartupReport, thresholds Thresholds) ReportComparison {
+	comparison := ReportComparison{Duration: thresholds.delta(before.Duration, after.Duration)}
+
+	var keys []string
+	seen := make(map[string]bool)
+	for _, report := range []StartupReport{after, before} {
+		for _, key := range append(append([]string(nil), report.Order...), startupKeys(report)...) {
+			if !seen[key] {
+				seen[key] = true
+				keys = append(keys, key)
+			}
+		}
+	}
+
+	beforePositions, afterPositions := positions(before.Order), positions(after.Order)
+	for _, key := range keys {
+		beforeStartup, inBefore := before.Component(key)
+		afterStartup, inAfter := after.Component(key)
+		switch {
+		case !inBefore:
+			comparison.Added = append(comparison.Added, key)
+		case !inAfter:
+			comparison.Removed = append(comparison.Removed, key)
+		}
+
+		delta := ComponentDelta{
+			Key:            key,
+			DurationDelta:  thresholds.delta(beforeStartup.Duration, afterStartup.Duration),
+			BeforePosition: position(beforePositions, key),
+			AfterPosition:  position(afterPositions, key),
+		}
+		if inBefore && inAfter && delta.BeforePosition >= 0 && delta.AfterPosition >= 0 && delta.BeforePosition != delta.AfterPosition {
+			comparison.Moved = append(comparison.Moved, key)
+		}
+		comparison.Components = append(comparison.Components, delta)
+	}
+	return comparison
+}
+
+// Highlighted returns the component changes reaching the thresholds
+func (c ReportComparison) Highlighted() []ComponentDelta {
+	var highlighted []ComponentDelta
+	for _, delta := range c.Components {
+		if delta.Highlighted {
+			highlighted = append(highlighted, delta)
+		}
+	}
+	return highlighted
+}
+
+// Regressions returns the highlighted components that got slower
+func (c ReportComparison) Regressions() []ComponentDelta {
+	var regressions []ComponentDelta
+	for _, delta := range c.Highlighted() {
+		if delta.Delta > 0 {
+			regressions = append(regressions, delta)
+		}
+	}
+	return regressions
+}
+
+func (c ReportComparison) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "boot %v -> %v (%s)%s\n", c.Duration.Before, c.Duration.After, c.Duration.change(), marker(c.Duration.Highlighted))
+	var table strings.Builder
+	w := tabwriter.NewWriter(&table, 0, 0, 2, ' ', 0)
+	for _, delta := range c.Components {
+		fmt.Fprintf(w, "  %s\t%v -> %v\t%s\t%s%s\n", delta.Key, delta.Before, delta.After, delta.change(), delta.moveOf(), marker(delta.Highlighted))
+	}
+	w.Flush()
+	for _, line := range strings.SplitAfter(table.String(), "\n") {
+		b.WriteString(strings.TrimRight(line, " \n"))
+		if line != "" {
+			b.WriteString("\n")
+		}
+	}
+	if len(c.Added) > 0 {
+		fmt.Fprintf(&b, "added: %s\n", strings.Join(c.Added, ", "))
+	}
+	if len(c.Removed) > 0 {
+		fmt.Fprintf(&b, "removed: %s\n", strings.Join(c.Removed, ", "))
+	}
+	return b.String()
+}
+
+// delta measures the change between two durations
+func (t Thresholds) delta(before, after time.Duration) DurationDelta {
+	delta := DurationDelta{Before: before, After: after, Delta: after - before}
+	if before > 0 {
+		delta.Ratio = float64(delta.Delta) / float64(before)
+	}
+
+	magnitude, ratio := delta.Delta, delta.Ratio
+	if magnitude < 0 {
+		magnitude, ratio = -magnitude, -ratio
+	}
+	delta.Highlighted = (t.Ratio > 0 || t.Absolute > 0) &&
+		(t.Ratio <= 0 || ratio >= t.Ratio || before == 0) &&
+		(t.Absolute <= 0 || magnitude >= t.Absolute) &&
+		magnitude > 0
+	return delta
+}
+
+// change renders the delta and ratio of a change, e.g. "+12ms +40%"
+func (d DurationDelta) change() string {
+	sign := "+"
+	if d.Delta < 0 {
+		sign = ""
+	}
+	if d.Before == 0 {
+		return sign + d.Delta.String()
+	}
+	return fmt.Sprintf("%s%v %s%.0f%%", sign, d.Delta, sign, d.Ratio*100)
+}
+
+// moveOf renders a change of start position, e.g. "moved 3 -> 1"
+func (d ComponentDelta) moveOf() string {
+	if d.BeforePosition < 0 || d.AfterPosition < 0 || d.BeforePosition == d.AfterPosition {
+		return ""
+	}
+	return fmt.Sprintf("moved %d -> %d", d.BeforePosition+1, d.AfterPosition+1)
+}
+
+// marker flags highlighted lines
+func marker(highlighted bool) string {
+	if highlighted {
+		return "  !"
+	}
+	return ""
+}
+
+// startupKeys returns the keys of the components a report attempted to start
+func startupKeys(report StartupReport) []string {
+	keys := make([]string, 0, len(report.Components))
+	for _, c := range report.Components {
+		keys = append(keys, c.Key)
+	}
+	return keys
+}
+
+// positions indexes the keys of a start order
+func positions(order []string) map[string]int {
+	indexes := make(map[string]int, len(order))
+	for i, key := range order {
+		indexes[key] = i
+	}
+	return indexes
+}
+
+// position returns the index of key in a start order, or -1
+func position(indexes map[string]int, key string) int {
+	if i, ok := indexes[key]; ok {
+		return i
+	}
+	return -1
+}
@@ -0,0 +1,88 @@
+package component
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func bootReport(duration time.Duration, order []string, durations map[string]time.Duration) StartupReport {
+	report := StartupReport{Duration: duration, Order: order}
+	for _, key := range order {
+		report.Components = append(report.Components, ComponentStartup{Key: key, Duration: durations[key]})
+	}
+	return report
+}
+
+func TestCompareReports(t *testing.T) {
+	before := bootReport(100*time.Millisecond, []string{"config", "db", "cache", "api"}, map[string]time.Duration{
+		"config": time.Millisecond,
+		"db":     40 * time.Millisecond,
+		"cache":  20 * time.Millisecond,
+		"api":    10 * time.Millisecond,
+	})
+	after := bootReport(150*time.Millisecond, []string{"config", "cache", "db", "search", "api"}, map[string]time.Duration{
+		"config": 2 * time.Millisecond,
+		"db":     80 * time.Millisecond,
+		"cache":  21 * time.Millisecond,
+		"search": 30 * time.Millisecond,
+		"api":    5 * time.Millisecond,
+	})
+
+	comparison := CompareReports(before, after, Thresholds{Ratio: 0.2, Absolute: 5 * time.Millisecond})
+
+	var keys []string
+	for _, delta := range comparison.Components {
+		keys = append(keys, delta.Key)
+	}
+	if want := []string{"config", "cache", "db", "search", "api"}; !reflect.DeepEqual(keys, want) {
+		t.Errorf("Expected components %v, got %v", want, keys)
+	}
+	if !reflect.DeepEqual(comparison.Added, []string{"search"}) || len(comparison.Removed) != 0 {
+		t.Errorf("Expected search added, got added %v removed %v", comparison.Added, comparison.Removed)
+	}
+	if want := []string{"cache", "db", "api"}; !reflect.DeepEqual(comparison.Moved, want) {
+		t.Errorf("Expected moved %v, got %v", want, comparison.Moved)
+	}
+
+	var highlighted []string
+	for _, delta := range comparison.Highlighted() {
+		highlighted = append(highlighted, delta.Key)
+	}
+	// config doubled but by less than the absolute threshold; cache moved
+	// by less than the ratio
+	if want := []string{"db", "search", "api"}; !reflect.DeepEqual(highlighted, want) {
+		t.Errorf("Expected highlighted %v, got %v", want, highlighted)
+	}
+	regressions := comparison.Regressions()
+	if len(regressions) != 2 || regressions[0].Key != "db" || regressions[0].Delta != 40*time.Millisecond || regressions[0].Ratio != 1 {
+		t.Errorf("Expected db and search to regress, db by 40ms, got %+v", regressions)
+	}
+	if !comparison.Duration.Highlighted || comparison.Duration.Delta != 50*time.Millisecond {
+		t.Errorf("Expected the boot to regress by 50ms, got %+v", comparison.Duration)
+	}
+
+	expected := "boot 100ms -> 150ms (+50ms +50%)  !\n" +
+		"  config  1ms -> 2ms    +1ms +100%\n" +
+		"  cache   20ms -> 21ms  +1ms +5%     moved 3 -> 2\n" +
+		"  db      40ms -> 80ms  +40ms +100%  moved 2 -> 3  !\n" +
+		"  search  0s -> 30ms    +30ms          !\n" +
+		"  api     10ms -> 5ms   -5ms -50%    moved 4 -> 5  !\n" +
+		"added: search\n"
+	if got := comparison.String(); got != expected {
+		t.Errorf("Expected:\n%s\ngot:\n%s", expected, got)
+	}
+}
+
+func TestCompareReportsRemovedComponent(t *testing.T) {
+	before := bootReport(0, []string{"db", "legacy"}, map[string]time.Duration{"db": time.Millisecond, "legacy": time.Millisecond})
+	after := bootReport(0, []string{"db"}, map[string]time.Duration{"db": time.Millisecond})
+
+	comparison := CompareReports(before, after, Thresholds{})
+	if !reflect.DeepEqual(comparison.Removed, []string{"legacy"}) {
+		t.Errorf("Expected legacy removed, got %v", comparison.Removed)
+	}
+	if legacy := comparison.Components[1]; legacy.Key != "legacy" || legacy.AfterPosition != -1 || legacy.Highlighted {
+		t.Errorf("Expected legacy last, unstarted and not highlighted without thresholds, got %+v", legacy)
+	}
+}
@@ -0,0 +1,73 @@
+package component
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestStartupReport(t *testing.T) {
+	silenceTestStdout(t)
+
+	system := CreateSystem(map[string]*Component{
+		"db":    Define("db", &MockComponent{}),
+		"cache": Define("cache", &MockComponent{}),
+		"api":   Define("api", &MockComponent{}, "db", "cache"),
+	})
+	if report := system.Report(); report.CorrelationID != "" || len(report.Components) != 0 {
+		t.Fatalf("Expected an empty report before the first start, got %+v", report)
+	}
+	if err := system.Start(); err != nil {
+		t.Fatalf("Failed to start system: %v", err)
+	}
+	defer system.Stop()
+
+	report := system.Report()
+	if report.CorrelationID == "" || report.StartedAt.IsZero() || report.Duration <= 0 || report.Error != "" {
+		t.Errorf("Expected a successful report, got %+v", report)
+	}
+	if report.Parallel {
+		t.Error("Expected a sequential start")
+	}
+	if want := [][]string{{"cache", "db"}, {"api"}}; !reflect.DeepEqual(report.Batches, want) {
+		t.Errorf("Expected batches %v, got %v", want, report.Batches)
+	}
+	if want := []string{"cache", "db", "api"}; !reflect.DeepEqual(report.Order, want) {
+		t.Errorf("Expected order %v, got %v", want, report.Order)
+	}
+	api, ok := report.Component("api")
+	if !ok || api.Batch != 1 || api.Offset <= 0 {
+		t.Errorf("Expected api in the second batch after the others, got %+v", api)
+	}
+	if len(report.Failures()) != 0 {
+		t.Errorf("Expected no failures, got %v", report.Failures())
+	}
+	if !strings.Contains(report.String(), "api    batch 1") {
+		t.Errorf("Expected a rendered line per component, got:\n%s", report)
+	}
+}
+
+func TestStartupReportFailure(t *testing.T) {
+	silenceTestStdout(t)
+
+	system := CreateSystem(map[string]*Component{
+		"db":  Define("db", &MockComponent{}),
+		"api": Define("api", &MockComponent{StartError: errors.New("port in use")}, "db"),
+	}, WithParallelStart(2))
+	if err := system.Start(); err == nil {
+		t.Fatal("Expected the start to fail")
+	}
+
+	report := system.Report()
+	if !report.Parallel {
+		t.Error("Expected a parallel start")
+	}
+	failures := report.Failures()
+	if len(failures) != 1 || failures[0].Key != "api" || !strings.Contains(failures[0].Error, "port in use") {
+		t.Errorf("Expected api reported as failed, got %+v", failures)
+	}
+	if !strings.Contains(report.Error, "port in use") {
+		t.Errorf("Expected the start error in the report, got %q", report.Error)
+	}
+}
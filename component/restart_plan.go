@@ -0,0 +1,131 @@
+package component
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// componentTimings are the durations of the last start and stop of a component
+type componentTimings struct {
+	start, stop time.Duration
+}
+
+// recordTiming keeps the duration of a successful operation for planning;
+// the caller must hold s.mu
+func (s *System) recordTiming(component *Component, op string, duration time.Duration) {
+	if s.timings == nil {
+		s.timings = make(map[string]componentTimings)
+	}
+	timings := s.timings[component.key]
+	if op == OpStart {
+		timings.start = duration
+	} else {
+		timings.stop = duration
+	}
+	s.timings[component.key] = timings
+}
+
+// PlanStep is one operation of a restart plan
+type PlanStep struct {
+	Op  string
+	Key string
+
+	// Cause explains why the component is part of the plan
+	Cause string
+
+	// Estimate is the duration of the same operation the last time it ran;
+	// Estimated is false when the operation never ran
+	Estimate  time.Duration
+	Estimated bool
+}
+
+// RestartPlan lists the operations a restart would perform, in order
+type RestartPlan struct {
+	Steps    []PlanStep
+	Estimate time.Duration
+}
+
+// String renders the plan one step per line
+func (p *RestartPlan) String() string {
+	var b strings.Builder
+	for i, step := range p.Steps {
+		estimate := "unknown"
+		if step.Estimated {
+			estimate = step.Estimate.String()
+		}
+		fmt.Fprintf(&b, "%d. %s %s (%s, est. %s)\n", i+1, step.Op, step.Key, step.Cause, estimate)
+	}
+	fmt.Fprintf(&b, "estimated total: %v\n", p.Estimate)
+	return b.String()
+}
+
+// PlanRestart returns the plan for restarting the components under keys
+// without executing it: every running component depending on them, directly
+// or transitively, stops first in reverse start order, then all of them
+// start again in dependency order. Estimates come from the last run
+func (s *System) PlanRestart(keys ...string) (*RestartPlan, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, key := range keys {
+		if _, exists := s.components[key]; !exists {
+			return nil, fmt.Errorf("component %s not found", key)
+		}
+	}
+
+	order, err := s.validate()
+	if err != nil {
+		return nil, err
+	}
+	causes := s.affectedBy(keys, order)
+
+	plan := &RestartPlan{}
+	for i := len(order) - 1; i >= 0; i-- {
+		name := order[i]
+		if cause, affected := causes[name]; affected && s.components[name].IsStarted() {
+			plan.add(OpStop, name, cause, s.timings[name].stop)
+		}
+	}
+	for _, name := range order {
+		cause, affected := causes[name]
+		if !affected {
+			continue
+		}
+		// Completed one-shots only run again when asked for explicitly
+		if s.components[name].IsCompleted() && cause != "requested" {
+			continue
+		}
+		plan.add(OpStart, name, cause, s.timings[name].start)
+	}
+	return plan, nil
+}
+
+// add appends a step using the recorded duration as its estimate
+func (p *RestartPlan) add(op, key, cause string, last time.Duration) {
+	p.Steps = append(p.Steps, PlanStep{Op: op, Key: key, Cause: cause, Estimate: last, Estimated: last > 0})
+	p.Estimate += last
+}
+
+// affectedBy returns the requested components and their transitive
+// dependents, mapped to why each is affected; the caller must hold s.mu
+func (s *System) affectedBy(keys []string, order []string) map[string]string {
+	causes := make(map[string]string)
+	for _, key := range keys {
+		causes[key] = "requested"
+	}
+
+	// Dependencies always precede dependents in order, so one pass suffices
+	for _, name := range order {
+		if _, affected := causes[name]; affected {
+			continue
+		}
+		for _, dep := range s.resolvedDependencies(s.components[name]) {
+			if _, affected := causes[dep]; affected {
+				causes[name] = "depends on " + dep
+				break
+			}
+		}
+	}
+	return causes
+}
@@ -0,0 +1,60 @@
+package component
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestPlanRestart(t *testing.T) {
+	silenceTestStdout(t)
+
+	system := CreateSystem(map[string]*Component{
+		"config":     Define("config", &MockComponent{}),
+		"db":         Define("db", &MockComponent{}, "config"),
+		"migrations": DefineOneShot("migrations", Task(func(ctx Context) error { return nil }), "db"),
+		"cache":      Define("cache", &MockComponent{}, "config"),
+		"api":        Define("api", &MockComponent{}, "db", "migrations"),
+	})
+
+	plan, err := system.PlanRestart("db")
+	if err != nil {
+		t.Fatalf("Failed to plan: %v", err)
+	}
+	if got := planSteps(plan); got != "start db, start migrations, start api" {
+		t.Errorf("Expected only starts before the system runs, got %s", got)
+	}
+
+	if err := system.Start(); err != nil {
+		t.Fatalf("Failed to start system: %v", err)
+	}
+	defer system.Stop()
+
+	plan, err = system.PlanRestart("db")
+	if err != nil {
+		t.Fatalf("Failed to plan: %v", err)
+	}
+	if got := planSteps(plan); got != "stop api, stop db, start db, start api" {
+		t.Errorf("Unexpected plan %s", got)
+	}
+	if plan.Steps[0].Cause != "depends on db" || plan.Steps[1].Cause != "requested" {
+		t.Errorf("Unexpected causes: %+v", plan.Steps)
+	}
+	if !plan.Steps[2].Estimated || plan.Steps[0].Estimated {
+		t.Error("Expected start estimates from history and no stop history yet")
+	}
+	if !strings.Contains(plan.String(), "1. stop api (depends on db, est. unknown)") {
+		t.Errorf("Unexpected rendering:\n%s", plan)
+	}
+
+	if _, err := system.PlanRestart("missing"); err == nil {
+		t.Error("Expected planning an unknown component to fail")
+	}
+}
+
+func planSteps(plan *RestartPlan) string {
+	var steps []string
+	for _, step := range plan.Steps {
+		steps = append(steps, step.Op+" "+step.Key)
+	}
+	return strings.Join(steps, ", ")
+}
@@ -0,0 +1,63 @@
+package component
+
+import (
+	"errors"
+	"time"
+)
+
+// EventComponentRetrying is emitted when a failed Start is retried, with
+// Err the failure, Attempt the failed attempt and Duration the backoff
+// before the next one
+const EventComponentRetrying EventType = "component_retrying"
+
+// startRetry is the retry policy of a component's Start
+type startRetry struct {
+	attempts int
+	backoff  time.Duration
+}
+
+// WithStartRetry makes up to attempts attempts at starting the component
+// before its failure aborts the system Start, waiting backoff before the
+// first retry and doubling the wait after every further failure. It suits
+// transient failures such as a database not yet accepting connections.
+// Panics are not retried, and the wait ends early with the Start's deadline
+func (c *Component) WithStartRetry(attempts int, backoff time.Duration) *Component {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.retry = startRetry{attempts: attempts, backoff: backoff}
+	return c
+}
+
+// startRetry returns the retry policy of the component
+func (c *Component) startRetry() startRetry {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.retry
+}
+
+// startWithRetry starts the component, retrying failures as its policy allows
+func (s *System) startWithRetry(component *Component, ctx Context, correlationID string) (Lifecycle, error) {
+	retry := component.startRetry()
+	backoff := retry.backoff
+	for attempt := 1; ; attempt++ {
+		lifecycle, err := component.start(ctx, s.operation(correlationID, ShutdownReason{}))
+		if err == nil || attempt >= retry.attempts || errors.Is(err, ErrPanic) {
+			return lifecycle, err
+		}
+
+		event := componentEvent(EventComponentRetrying, component, correlationID)
+		event.Err = err
+		event.Attempt = attempt
+		event.Duration = backoff
+		s.emit(event)
+
+		timer := time.NewTimer(backoff)
+		select {
+		case <-s.bootContext().Done():
+			timer.Stop()
+			return nil, err
+		case <-timer.C:
+		}
+		backoff *= 2
+	}
+}
@@ -0,0 +1,97 @@
+package component
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// UnreachableComponent fails its first failures starts, like a database
+// not yet accepting connections
+type UnreachableComponent struct {
+	MockComponent
+	failures int
+	attempts int
+}
+
+func (f *UnreachableComponent) Start(ctx Context) (Lifecycle, error) {
+	f.attempts++
+	if f.attempts <= f.failures {
+		return nil, errors.New("connection refused")
+	}
+	return f, nil
+}
+
+func TestStartRetry(t *testing.T) {
+	var retries []Event
+	db := &UnreachableComponent{failures: 2}
+	system := CreateSystem(map[string]*Component{
+		"db":  Define("db", db).WithStartRetry(3, 5*time.Millisecond),
+		"api": Define("api", &MockComponent{}, "db"),
+	}, WithEventListener(func(event Event) {
+		if event.Type == EventComponentRetrying {
+			retries = append(retries, event)
+		}
+	}))
+
+	if err := system.Start(); err != nil {
+		t.Fatalf("Expected the retries to start db, got %v", err)
+	}
+	defer system.Stop()
+
+	var attempts []int
+	var backoffs []time.Duration
+	for _, event := range retries {
+		attempts = append(attempts, event.Attempt)
+		backoffs = append(backoffs, event.Duration)
+	}
+	if !reflect.DeepEqual(attempts, []int{1, 2}) || !reflect.DeepEqual(backoffs, []time.Duration{5 * time.Millisecond, 10 * time.Millisecond}) {
+		t.Errorf("Expected two retries with a doubling backoff, got attempts %v after %v", attempts, backoffs)
+	}
+	if retries[0].Component != "db" || retries[0].Err == nil {
+		t.Errorf("Expected the retry to carry the failure, got %+v", retries[0])
+	}
+}
+
+func TestStartRetryGivesUp(t *testing.T) {
+	db := &UnreachableComponent{failures: 5}
+	api := &MockComponent{}
+	system := CreateSystem(map[string]*Component{
+		"db":  Define("db", db).WithStartRetry(2, time.Millisecond),
+		"api": Define("api", api, "db"),
+	})
+
+	if err := system.Start(); !errors.Is(err, ErrComponentStartFailed) {
+		t.Fatalf("Expected db to fail once its attempts are used up, got %v", err)
+	}
+	if db.attempts != 2 || api.StartCalled {
+		t.Errorf("Expected two attempts and api left alone, got %d attempts", db.attempts)
+	}
+}
+
+func TestStartRetryStopsAtDeadline(t *testing.T) {
+	db := &UnreachableComponent{failures: 5}
+	system := CreateSystem(map[string]*Component{
+		"db": Define("db", db).WithStartRetry(5, time.Hour),
+	})
+
+	begin := time.Now()
+	if err := system.StartWithDeadline(20 * time.Millisecond); err == nil {
+		t.Fatal("Expected the start to fail at its deadline")
+	}
+	// EffectiveOrder waits for the boot finishing in the background
+	system.EffectiveOrder()
+	if elapsed := time.Since(begin); elapsed > time.Second || db.attempts != 1 {
+		t.Errorf("Expected the backoff to end at the deadline, got %v and %d attempts", elapsed, db.attempts)
+	}
+}
+
+func TestStartRetrySkipsPanics(t *testing.T) {
+	system := CreateSystem(map[string]*Component{
+		"broken": Define("broken", &PanickingComponent{}).WithStartRetry(3, time.Hour),
+	})
+	if err := system.Start(); !errors.Is(err, ErrPanic) {
+		t.Errorf("Expected the panic reported without retrying, got %v", err)
+	}
+}
@@ -0,0 +1,143 @@
+package component
+
+import (
+	"context"
+	"errors"
+	"runtime/pprof"
+	"runtime/trace"
+	"sync"
+)
+
+// ComponentLabel is the pprof label carrying the key of the component owning
+// a goroutine started with RunGroup.GoComponent
+const ComponentLabel = "component"
+
+// RunGroup runs application goroutines with the same guarantees as
+// components: they are cancelled and awaited when the system stops, and the
+// first error any of them returns triggers a supervised shutdown
+type RunGroup struct {
+	system *System
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+	once   sync.Once
+	err    error
+	mu     sync.Mutex
+}
+
+// RunGroup returns the run group of the current system run. A new group is
+// created after each Stop
+func (s *System) RunGroup() *RunGroup {
+	s.groupMu.Lock()
+	defer s.groupMu.Unlock()
+
+	if s.group == nil {
+		ctx, cancel := context.WithCancel(context.Background())
+		s.group = &RunGroup{system: s, ctx: ctx, cancel: cancel}
+	}
+	return s.group
+}
+
+// Go runs fn in a goroutine. Its context is cancelled when the system stops
+// or another goroutine of the group fails. fn must not call Stop synchronously
+func (g *RunGroup) Go(fn func(ctx context.Context) error) {
+	g.wg.Add(1)
+	go func() {
+		defer g.wg.Done()
+		if err := fn(g.ctx); err != nil && !(errors.Is(err, context.Canceled) && g.ctx.Err() != nil) {
+			g.fail(err)
+		}
+	}()
+}
+
+// GoComponent runs fn like Go on behalf of the component key. The goroutine,
+// and the goroutines it starts, carry the ComponentLabel pprof label and fn
+// runs in an execution trace region named after the key, so CPU profiles and
+// traces attribute its activity to the component
+func (g *RunGroup) GoComponent(key string, fn func(ctx context.Context) error) {
+	g.Go(func(ctx context.Context) error {
+		var err error
+		pprof.Do(ctx, pprof.Labels(ComponentLabel, key), func(ctx context.Context) {
+			trace.WithRegion(ctx, key, func() {
+				err = fn(ctx)
+			})
+		})
+		return err
+	})
+}
+
+// Context returns the context shared by the goroutines of the group
+func (g *RunGroup) Context() context.Context {
+	return g.ctx
+}
+
+// Wait blocks until every goroutine of the group returns and returns the
+// first error, if any
+func (g *RunGroup) Wait() error {
+	g.wg.Wait()
+	return g.Err()
+}
+
+// Err returns the first error returned by a goroutine of the group
+func (g *RunGroup) Err() error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.err
+}
+
+// fail records the first error, cancels the group and escalates to a shutdown
+func (g *RunGroup) fail(err error) {
+	g.once.Do(func() {
+		g.mu.Lock()
+		g.err = err
+		g.mu.Unlock()
+
+		g.cancel()
+		g.system.RequestShutdown(ShutdownReason{Cause: ShutdownSupervisor, Detail: err.Error(), Err: err})
+	})
+}
+
+// drainRunGroup cancels the current run group and waits for its goroutines
+func (s *System) drainRunGroup() {
+	s.groupMu.Lock()
+	group := s.group
+	s.group = nil
+	s.groupMu.Unlock()
+
+	if group != nil {
+		group.cancel()
+		group.wg.Wait()
+	}
+}
+
+// RequestShutdown asks the driver of the system, such as the runner, to stop
+// it for reason. Without a driver the system stops itself asynchronously
+func (s *System) RequestShutdown(reason ShutdownReason) {
+	s.groupMu.Lock()
+	requests := s.shutdownRequests
+	s.groupMu.Unlock()
+
+	if requests == nil {
+		go s.StopWithReason(reason)
+		return
+	}
+
+	select {
+	case requests <- reason:
+	default:
+		// A shutdown request is already pending
+	}
+}
+
+// ShutdownRequests returns the channel shutdown requests are delivered on.
+// Calling it registers the caller as the driver responsible for stopping
+// the system when a request arrives
+func (s *System) ShutdownRequests() <-chan ShutdownReason {
+	s.groupMu.Lock()
+	defer s.groupMu.Unlock()
+
+	if s.shutdownRequests == nil {
+		s.shutdownRequests = make(chan ShutdownReason, 1)
+	}
+	return s.shutdownRequests
+}
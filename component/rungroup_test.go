@@ -0,0 +1,121 @@
+package component
+
+import (
+	"context"
+	"errors"
+	"runtime/pprof"
+	"testing"
+	"time"
+)
+
+func TestRunGroupCancelledOnStop(t *testing.T) {
+	system := CreateSystem(map[string]*Component{
+		"compA": Define("compA", &MockComponent{}),
+	})
+	if err := system.Start(); err != nil {
+		t.Fatalf("Failed to start system: %v", err)
+	}
+
+	exited := make(chan struct{})
+	system.RunGroup().Go(func(ctx context.Context) error {
+		<-ctx.Done()
+		close(exited)
+		return ctx.Err()
+	})
+
+	if err := system.Stop(); err != nil {
+		t.Fatalf("Failed to stop system: %v", err)
+	}
+	select {
+	case <-exited:
+	default:
+		t.Fatal("Expected worker to exit before Stop returned")
+	}
+}
+
+func TestRunGroupErrorTriggersShutdown(t *testing.T) {
+	comp := &MockComponent{}
+	system := CreateSystem(map[string]*Component{
+		"compA": Define("compA", comp),
+	})
+	requests := system.ShutdownRequests()
+	if err := system.Start(); err != nil {
+		t.Fatalf("Failed to start system: %v", err)
+	}
+
+	group := system.RunGroup()
+	sibling := make(chan error, 1)
+	group.Go(func(ctx context.Context) error {
+		<-ctx.Done()
+		sibling <- ctx.Err()
+		return nil
+	})
+	group.Go(func(ctx context.Context) error {
+		return errors.New("worker crashed")
+	})
+
+	select {
+	case reason := <-requests:
+		if reason.Cause != ShutdownSupervisor || reason.Err == nil {
+			t.Errorf("Expected supervisor shutdown carrying the error, got %v", reason)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Expected a shutdown request")
+	}
+
+	if err := <-sibling; !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected sibling to be cancelled, got %v", err)
+	}
+	if err := group.Wait(); err == nil || err.Error() != "worker crashed" {
+		t.Errorf("Expected first error, got %v", err)
+	}
+}
+
+func TestRunGroupStopsSystemWithoutDriver(t *testing.T) {
+	comp := &CountingComponent{}
+	system := CreateSystem(map[string]*Component{
+		"compA": Define("compA", comp),
+	})
+	if err := system.Start(); err != nil {
+		t.Fatalf("Failed to start system: %v", err)
+	}
+
+	system.RunGroup().Go(func(ctx context.Context) error {
+		return errors.New("worker crashed")
+	})
+
+	deadline := time.Now().Add(time.Second)
+	for system.IsStarted() && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	if system.IsStarted() {
+		t.Fatal("Expected system to stop itself after a worker failure")
+	}
+}
+
+func TestRunGroupLabelsComponentGoroutines(t *testing.T) {
+	system := CreateSystem(map[string]*Component{
+		"compA": Define("compA", &MockComponent{}),
+	})
+
+	labels := make(chan string, 2)
+	group := system.RunGroup()
+	group.GoComponent("compA", func(ctx context.Context) error {
+		label, _ := pprof.Label(ctx, ComponentLabel)
+		labels <- label
+		return nil
+	})
+	group.Go(func(ctx context.Context) error {
+		label, _ := pprof.Label(ctx, ComponentLabel)
+		labels <- label
+		return nil
+	})
+	if err := group.Wait(); err != nil {
+		t.Fatalf("Expected goroutines to succeed, got %v", err)
+	}
+
+	got := map[string]bool{<-labels: true, <-labels: true}
+	if !got["compA"] || !got[""] {
+		t.Errorf("Expected only the component goroutine to be labeled, got %v", got)
+	}
+}
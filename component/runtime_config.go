@@ -0,0 +1,238 @@
+package component
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"sync"
+	"time"
+)
+
+// RuntimeConfig holds operational settings changed while the system runs,
+// e.g. by an admin action. Zero values keep the setting of the options
+// the system was created with
+type RuntimeConfig struct {
+	ParallelStart int           `json:"parallel_start,omitempty"`
+	ParallelStop  int           `json:"parallel_stop,omitempty"`
+	StopDeadline  time.Duration `json:"stop_deadline,omitempty"`
+
+	// Disabled components are not started, nor are the components
+	// depending on them
+	Disabled []string `json:"disabled,omitempty"`
+}
+
+// RuntimeConfigStore persists the runtime configuration so operational
+// changes survive restarts
+type RuntimeConfigStore interface {
+	SaveRuntimeConfig(config RuntimeConfig) error
+
+	// LoadRuntimeConfig returns the saved configuration, if any
+	LoadRuntimeConfig() (RuntimeConfig, bool, error)
+}
+
+// WithRuntimeConfigStore loads the runtime configuration saved in the store
+// at the first Start and saves it on every UpdateRuntimeConfig
+func WithRuntimeConfigStore(store RuntimeConfigStore) Option {
+	return func(s *System) {
+		s.runtimeStore = store
+	}
+}
+
+// runtimeSettings holds the runtime configuration of a system
+type runtimeSettings struct {
+	config RuntimeConfig
+	loaded bool
+	mu     sync.Mutex
+}
+
+// RuntimeConfig returns the runtime configuration changes in effect
+func (s *System) RuntimeConfig() RuntimeConfig {
+	s.runtime.mu.Lock()
+	defer s.runtime.mu.Unlock()
+	return s.runtime.config.clone()
+}
+
+// UpdateRuntimeConfig changes the runtime configuration and saves it to the
+// store, if any. It does not wait for an in-flight lifecycle operation:
+// parallelism and the stop deadline apply from the next Start or Stop, and
+// disabled components from the next Start
+func (s *System) UpdateRuntimeConfig(update func(config *RuntimeConfig)) error {
+	s.runtime.mu.Lock()
+	defer s.runtime.mu.Unlock()
+	if err := s.loadRuntimeConfigLocked(); err != nil {
+		return err
+	}
+
+	config := s.runtime.config.clone()
+	update(&config)
+	if err := s.checkRuntimeConfig(config); err != nil {
+		return err
+	}
+	if s.runtimeStore != nil {
+		if err := s.runtimeStore.SaveRuntimeConfig(config); err != nil {
+			return fmt.Errorf("failed to save runtime config: %w", err)
+		}
+	}
+	s.runtime.config = config
+	return nil
+}
+
+// checkRuntimeConfig rejects negative settings and unknown components
+func (s *System) checkRuntimeConfig(config RuntimeConfig) error {
+	if config.ParallelStart < 0 || config.ParallelStop < 0 || config.StopDeadline < 0 {
+		return fmt.Errorf("runtime config settings must not be negative")
+	}
+	for _, key := range config.Disabled {
+		if _, exists := s.snapshot().components[key]; !exists {
+			return fmt.Errorf("cannot disable component %s: not found", key)
+		}
+	}
+	return nil
+}
+
+// loadRuntimeConfig loads the saved runtime configuration once
+func (s *System) loadRuntimeConfig() error {
+	s.runtime.mu.Lock()
+	defer s.runtime.mu.Unlock()
+	return s.loadRuntimeConfigLocked()
+}
+
+// loadRuntimeConfigLocked loads the saved runtime configuration once; the
+// caller must hold s.runtime.mu
+func (s *System) loadRuntimeConfigLocked() error {
+	if s.runtime.loaded || s.runtimeStore == nil {
+		return nil
+	}
+	config, found, err := s.runtimeStore.LoadRuntimeConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load runtime config: %w", err)
+	}
+	if found {
+		s.runtime.config = config
+	}
+	s.runtime.loaded = true
+	return nil
+}
+
+// effectiveRuntime returns the option settings overridden by the runtime
+// configuration
+func (s *System) effectiveRuntime() RuntimeConfig {
+	s.runtime.mu.Lock()
+	defer s.runtime.mu.Unlock()
+
+	effective := s.runtime.config.clone()
+	if effective.ParallelStart == 0 {
+		effective.ParallelStart = s.parallelStart
+	}
+	if effective.ParallelStop == 0 {
+		effective.ParallelStop = s.parallelStop
+	}
+	if effective.StopDeadline == 0 {
+		effective.StopDeadline = s.stopTimeout
+	}
+	return effective
+}
+
+// withoutDisabled removes the disabled components and the components
+// depending on them from a start order, recording them as skipped; the
+// caller must hold s.mu
+func (s *System) withoutDisabled(order []string) []string {
+	enabled, skipped := s.filterDisabled(s, order)
+	s.skipped = skipped
+	return enabled
+}
+
+// filterDisabled removes the disabled components and the components
+// depending on them from a start order of graph, returning them as skipped
+func (s *System) filterDisabled(graph *System, order []string) ([]string, map[string]bool) {
+	disabled := s.effectiveRuntime().Disabled
+	if len(disabled) == 0 {
+		return order, nil
+	}
+
+	skipped := make(map[string]bool)
+	var enabled []string
+	for _, name := range order {
+		skip := slices.Contains(disabled, name)
+		for _, dep := range graph.resolvedDependencies(graph.components[name]) {
+			skip = skip || skipped[dep]
+		}
+		if skip {
+			skipped[name] = true
+			s.Logger().Info("Component "+name+" is disabled", graph.components[name].logFields("")...)
+			continue
+		}
+		enabled = append(enabled, name)
+	}
+	return enabled, skipped
+}
+
+// clone copies a runtime configuration
+func (c RuntimeConfig) clone() RuntimeConfig {
+	c.Disabled = append([]string(nil), c.Disabled...)
+	return c
+}
+
+// MemoryRuntimeConfigStore keeps the runtime configuration in memory
+type MemoryRuntimeConfigStore struct {
+	config *RuntimeConfig
+	mu     sync.Mutex
+}
+
+// NewMemoryRuntimeConfigStore creates an empty in-memory runtime config store
+func NewMemoryRuntimeConfigStore() *MemoryRuntimeConfigStore {
+	return &MemoryRuntimeConfigStore{}
+}
+
+func (m *MemoryRuntimeConfigStore) SaveRuntimeConfig(config RuntimeConfig) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	saved := config.clone()
+	m.config = &saved
+	return nil
+}
+
+func (m *MemoryRuntimeConfigStore) LoadRuntimeConfig() (RuntimeConfig, bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.config == nil {
+		return RuntimeConfig{}, false, nil
+	}
+	return m.config.clone(), true, nil
+}
+
+// FileRuntimeConfigStore keeps the runtime configuration as a JSON file
+type FileRuntimeConfigStore struct {
+	path string
+}
+
+// NewFileRuntimeConfigStore creates a runtime config store backed by the
+// file at path
+func NewFileRuntimeConfigStore(path string) *FileRuntimeConfigStore {
+	return &FileRuntimeConfigStore{path: path}
+}
+
+func (f *FileRuntimeConfigStore) SaveRuntimeConfig(config RuntimeConfig) error {
+	data, err := json.MarshalIndent(config, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(f.path, data, 0o600)
+}
+
+func (f *FileRuntimeConfigStore) LoadRuntimeConfig() (RuntimeConfig, bool, error) {
+	data, err := os.ReadFile(f.path)
+	if os.IsNotExist(err) {
+		return RuntimeConfig{}, false, nil
+	}
+	if err != nil {
+		return RuntimeConfig{}, false, err
+	}
+
+	var config RuntimeConfig
+	if err := json.Unmarshal(data, &config); err != nil {
+		return RuntimeConfig{}, false, fmt.Errorf("invalid runtime config file %s: %w", f.path, err)
+	}
+	return config, true, nil
+}
@@ -0,0 +1,135 @@
+package component
+
+import (
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRuntimeConfigSurvivesRestart(t *testing.T) {
+	store := NewFileRuntimeConfigStore(filepath.Join(t.TempDir(), "runtime.json"))
+	components := func() (map[string]*Component, *MockComponent) {
+		worker := &MockComponent{}
+		return map[string]*Component{
+			"db":      Define("db", &MockComponent{}),
+			"worker":  Define("worker", worker, "db"),
+			"reports": Define("reports", &MockComponent{}, "worker"),
+		}, worker
+	}
+
+	first, _ := components()
+	system := CreateSystem(first, WithRuntimeConfigStore(store))
+	if err := system.UpdateRuntimeConfig(func(config *RuntimeConfig) {
+		config.ParallelStart = 2
+		config.StopDeadline = time.Second
+		config.Disabled = append(config.Disabled, "worker")
+	}); err != nil {
+		t.Fatalf("UpdateRuntimeConfig failed: %v", err)
+	}
+
+	next, worker := components()
+	restarted := CreateSystem(next, WithRuntimeConfigStore(store), WithParallelStop(3))
+	if err := restarted.Start(); err != nil {
+		t.Fatalf("Failed to start system: %v", err)
+	}
+	defer restarted.Stop()
+
+	if worker.StartCalled {
+		t.Error("Expected the disabled worker not to start")
+	}
+	if state := next["reports"].State(); state == StateStarted {
+		t.Error("Expected the component depending on the disabled worker not to start")
+	}
+	if !next["db"].IsStarted() {
+		t.Error("Expected db to start")
+	}
+
+	expected := RuntimeConfig{ParallelStart: 2, ParallelStop: 3, StopDeadline: time.Second, Disabled: []string{"worker"}}
+	if effective := restarted.effectiveRuntime(); !reflect.DeepEqual(effective, expected) {
+		t.Errorf("Expected effective settings %+v, got %+v", expected, effective)
+	}
+}
+
+func TestRuntimeConfigRejectsInvalidChanges(t *testing.T) {
+	store := NewMemoryRuntimeConfigStore()
+	system := CreateSystem(map[string]*Component{
+		"db": Define("db", &MockComponent{}),
+	}, WithRuntimeConfigStore(store))
+
+	err := system.UpdateRuntimeConfig(func(config *RuntimeConfig) {
+		config.Disabled = []string{"cache"}
+	})
+	if err == nil || !strings.Contains(err.Error(), "cannot disable component cache") {
+		t.Errorf("Expected an unknown component to be rejected, got %v", err)
+	}
+	if err := system.UpdateRuntimeConfig(func(config *RuntimeConfig) { config.ParallelStop = -1 }); err == nil {
+		t.Error("Expected a negative setting to be rejected")
+	}
+	if _, found, _ := store.LoadRuntimeConfig(); found {
+		t.Error("Expected rejected changes not to be saved")
+	}
+}
+
+func TestDisabledComponentsDoNotFailProbes(t *testing.T) {
+	silenceTestStdout(t)
+	system := CreateSystem(map[string]*Component{
+		"db":     Define("db", &MockComponent{}),
+		"worker": Define("worker", &MockComponent{}, "db"),
+	})
+	if err := system.UpdateRuntimeConfig(func(config *RuntimeConfig) {
+		config.Disabled = []string{"worker"}
+	}); err != nil {
+		t.Fatalf("UpdateRuntimeConfig failed: %v", err)
+	}
+	if err := system.Start(); err != nil {
+		t.Fatalf("Failed to start system: %v", err)
+	}
+	defer system.Stop()
+
+	if err := system.Ready(); err != nil {
+		t.Errorf("Expected the system ready without its disabled worker, got %v", err)
+	}
+	if report := system.HealthReport(); report.Status != HealthHealthy {
+		t.Errorf("Expected the system healthy without its disabled worker, got %+v", report)
+	}
+}
+
+func TestApplyKeepsDisabledComponentsDown(t *testing.T) {
+	silenceTestStdout(t)
+	worker := &MockComponent{}
+	system := CreateSystem(map[string]*Component{
+		"db":     Define("db", &MockComponent{}),
+		"worker": Define("worker", worker, "db"),
+	})
+	if err := system.UpdateRuntimeConfig(func(config *RuntimeConfig) {
+		config.Disabled = []string{"worker"}
+	}); err != nil {
+		t.Fatalf("UpdateRuntimeConfig failed: %v", err)
+	}
+	if err := system.Start(); err != nil {
+		t.Fatalf("Failed to start system: %v", err)
+	}
+	defer system.Stop()
+
+	if _, err := system.Apply(Changeset{Swap: []*Component{Define("db", &MockComponent{})}}); err != nil {
+		t.Fatalf("Apply failed: %v", err)
+	}
+	if _, _, err := system.Reload(map[string]*Component{
+		"db":     Define("db", &ConfiguredComponent{}),
+		"worker": Define("worker", worker, "db"),
+	}); err != nil {
+		t.Fatalf("Reload failed: %v", err)
+	}
+
+	if worker.StartCalled {
+		t.Error("Expected the disabled worker to stay down across Apply and Reload")
+	}
+	if err := system.Ready(); err != nil {
+		t.Errorf("Expected the system ready without its disabled worker, got %v", err)
+	}
+	if order := system.EffectiveOrder(); !reflect.DeepEqual(order, []string{"db"}) {
+		t.Errorf("Expected only db running, got %v", order)
+	}
+}
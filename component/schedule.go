@@ -0,0 +1,302 @@
+package component
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	EventScheduledRun     EventType = "scheduled_run"
+	EventScheduledSkipped EventType = "scheduled_skipped"
+)
+
+// Schedule computes when a scheduled task runs next
+type Schedule interface {
+	// Next returns the first run time strictly after t, or the zero time
+	// when the schedule has no further runs
+	Next(t time.Time) time.Time
+}
+
+// interval runs a task at a fixed period
+type interval time.Duration
+
+// Every returns a schedule running a task every d, starting d after the
+// system starts
+func Every(d time.Duration) Schedule {
+	return interval(d)
+}
+
+func (i interval) Next(t time.Time) time.Time {
+	return t.Add(time.Duration(i))
+}
+
+// cronSchedule is a parsed five-field cron expression
+type cronSchedule struct {
+	minute, hour, dom, month, dow map[int]bool
+	location                      *time.Location
+}
+
+// Cron parses a standard five-field cron expression ("minute hour
+// day-of-month month day-of-week") supporting "*", lists, ranges and steps,
+// evaluated in the local time zone
+func Cron(expr string) (Schedule, error) {
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("cron expression %q must have 5 fields", expr)
+	}
+
+	bounds := [5][2]int{{0, 59}, {0, 23}, {1, 31}, {1, 12}, {0, 6}}
+	sets := make([]map[int]bool, 5)
+	for i, field := range fields {
+		set, err := parseCronField(field, bounds[i][0], bounds[i][1])
+		if err != nil {
+			return nil, fmt.Errorf("cron expression %q: %w", expr, err)
+		}
+		sets[i] = set
+	}
+
+	return &cronSchedule{
+		minute:   sets[0],
+		hour:     sets[1],
+		dom:      sets[2],
+		month:    sets[3],
+		dow:      sets[4],
+		location: time.Local,
+	}, nil
+}
+
+// parseCronField expands one cron field into the set of values it matches
+func parseCronField(field string, min, max int) (map[int]bool, error) {
+	set := make(map[int]bool)
+	for _, part := range strings.Split(field, ",") {
+		step := 1
+		if i := strings.Index(part, "/"); i >= 0 {
+			n, err := strconv.Atoi(part[i+1:])
+			if err != nil || n <= 0 {
+				return nil, fmt.Errorf("invalid step in %q", part)
+			}
+			step = n
+			part = part[:i]
+		}
+
+		low, high := min, max
+		switch {
+		case part == "*":
+		case strings.Contains(part, "-"):
+			bounds := strings.SplitN(part, "-", 2)
+			var err1, err2 error
+			low, err1 = strconv.Atoi(bounds[0])
+			high, err2 = strconv.Atoi(bounds[1])
+			if err1 != nil || err2 != nil {
+				return nil, fmt.Errorf("invalid range %q", part)
+			}
+		default:
+			n, err := strconv.Atoi(part)
+			if err != nil {
+				return nil, fmt.Errorf("invalid value %q", part)
+			}
+			low, high = n, n
+			if step > 1 {
+				high = max
+			}
+		}
+
+		if low < min || high > max || low > high {
+			return nil, fmt.Errorf("value out of range in %q", part)
+		}
+		for v := low; v <= high; v += step {
+			set[v] = true
+		}
+	}
+	return set, nil
+}
+
+func (c *cronSchedule) Next(t time.Time) time.Time {
+	t = t.In(c.location).Truncate(time.Minute).Add(time.Minute)
+	limit := t.AddDate(5, 0, 0)
+
+	for t.Before(limit) {
+		switch {
+		case !c.month[int(t.Month())]:
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, c.location)
+		case !c.dom[t.Day()] || !c.dow[int(t.Weekday())]:
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, c.location)
+		case !c.hour[t.Hour()]:
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, c.location)
+		case !c.minute[t.Minute()]:
+			t = t.Add(time.Minute)
+		default:
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+// OverlapPolicy decides what happens when a run is due while the previous
+// one is still running
+type OverlapPolicy int
+
+const (
+	// SkipIfRunning drops the due run
+	SkipIfRunning OverlapPolicy = iota
+
+	// WaitForPrevious runs as soon as the previous run finishes
+	WaitForPrevious
+
+	// AllowOverlap starts the due run concurrently
+	AllowOverlap
+)
+
+// ScheduleStats counts the runs of a scheduled component
+type ScheduleStats struct {
+	Runs     int
+	Failures int
+	Skipped  int
+	LastRun  time.Time
+	LastErr  error
+}
+
+// scheduledTask is the instance of a scheduled component: its Start launches
+// the scheduler loop and each run starts the wrapped task
+type scheduledTask struct {
+	task     Lifecycle
+	schedule Schedule
+	policy   OverlapPolicy
+
+	system    *System
+	component *Component
+
+	stop    chan struct{}
+	wg      sync.WaitGroup
+	running sync.Mutex
+	stats   ScheduleStats
+	mu      sync.Mutex
+}
+
+// Scheduled turns a one-shot component into a recurring task run by the
+// system on the schedule. The returned component keeps the key and
+// dependencies; it is started while the system runs and its runs emit
+// scheduled_run events
+func Scheduled(c *Component, schedule Schedule, policy OverlapPolicy) *Component {
+	scheduled := Define(c.key, &scheduledTask{task: c.instance, schedule: schedule, policy: policy}, c.GetDependencies()...)
+	scheduled.Provides(c.GetProvides()...)
+	scheduled.WithTags(c.GetTags()...)
+	scheduled.WithMetadata(c.GetMetadata())
+	if params := c.GetParams(); params != nil {
+		scheduled.WithParams(params)
+	}
+	return scheduled
+}
+
+// attach binds the task to the system that schedules it
+func (t *scheduledTask) attach(s *System, c *Component) {
+	t.system = s
+	t.component = c
+}
+
+func (t *scheduledTask) Start(ctx Context) (Lifecycle, error) {
+	t.stop = make(chan struct{})
+	t.wg.Add(1)
+	go t.loop(ctx, t.stop)
+	return t, nil
+}
+
+func (t *scheduledTask) Stop(ctx Context) error {
+	close(t.stop)
+	t.wg.Wait()
+	return nil
+}
+
+// loop waits for each due time and dispatches a run according to the policy
+func (t *scheduledTask) loop(ctx Context, stop chan struct{}) {
+	defer t.wg.Done()
+
+	next := t.schedule.Next(time.Now())
+	for !next.IsZero() {
+		timer := time.NewTimer(time.Until(next))
+		select {
+		case <-stop:
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+
+		switch t.policy {
+		case AllowOverlap:
+			t.wg.Add(1)
+			go func() {
+				defer t.wg.Done()
+				t.run(ctx)
+			}()
+		case WaitForPrevious:
+			t.running.Lock()
+			t.run(ctx)
+			t.running.Unlock()
+		default:
+			if t.running.TryLock() {
+				t.wg.Add(1)
+				go func() {
+					defer t.wg.Done()
+					defer t.running.Unlock()
+					t.run(ctx)
+				}()
+			} else {
+				t.record(nil, 0, true)
+			}
+		}
+
+		next = t.schedule.Next(time.Now())
+	}
+}
+
+// run performs one execution of the wrapped task
+func (t *scheduledTask) run(ctx Context) {
+	startTime := time.Now()
+	_, err := t.task.Start(ctx)
+	t.record(err, time.Since(startTime), false)
+}
+
+// record updates the statistics and emits the per-run event
+func (t *scheduledTask) record(err error, duration time.Duration, skipped bool) {
+	t.mu.Lock()
+	if skipped {
+		t.stats.Skipped++
+	} else {
+		t.stats.Runs++
+		t.stats.LastRun = time.Now()
+		t.stats.LastErr = err
+		if err != nil {
+			t.stats.Failures++
+		}
+	}
+	t.mu.Unlock()
+
+	if t.system == nil {
+		return
+	}
+	event := componentEvent(EventScheduledRun, t.component, "")
+	if skipped {
+		event.Type = EventScheduledSkipped
+	}
+	event.Duration = duration
+	event.Err = err
+	t.system.emit(event)
+}
+
+// ScheduleStats returns the run statistics of a scheduled component
+func (s *System) ScheduleStats(key string) (ScheduleStats, bool) {
+	component, exists := s.snapshot().components[key]
+	if !exists {
+		return ScheduleStats{}, false
+	}
+	task, ok := component.instance.(*scheduledTask)
+	if !ok {
+		return ScheduleStats{}, false
+	}
+	task.mu.Lock()
+	defer task.mu.Unlock()
+	return task.stats, true
+}
@@ -0,0 +1,114 @@
+package component
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestScheduledComponentRuns(t *testing.T) {
+	silenceTestStdout(t)
+
+	var runs atomic.Int32
+	var mu sync.Mutex
+	var events []Event
+	task := DefineOneShot("cleanup", Task(func(ctx Context) error {
+		if ctx["db"] == nil {
+			return errors.New("db not injected")
+		}
+		runs.Add(1)
+		return nil
+	}), "db")
+
+	system := CreateSystem(map[string]*Component{
+		"db":      Define("db", &MockComponent{}),
+		"cleanup": Scheduled(task, Every(5*time.Millisecond), SkipIfRunning),
+	}, WithEventListener(func(e Event) {
+		if e.Type == EventScheduledRun {
+			mu.Lock()
+			events = append(events, e)
+			mu.Unlock()
+		}
+	}))
+
+	if err := system.Start(); err != nil {
+		t.Fatalf("Failed to start system: %v", err)
+	}
+	time.Sleep(40 * time.Millisecond)
+	if err := system.Stop(); err != nil {
+		t.Fatalf("Failed to stop system: %v", err)
+	}
+
+	afterStop := runs.Load()
+	if afterStop < 2 {
+		t.Fatalf("Expected several runs, got %d", afterStop)
+	}
+	time.Sleep(20 * time.Millisecond)
+	if runs.Load() != afterStop {
+		t.Error("Expected no runs after stop")
+	}
+
+	stats, ok := system.ScheduleStats("cleanup")
+	if !ok || stats.Runs != int(afterStop) || stats.Failures != 0 {
+		t.Errorf("Unexpected stats %+v", stats)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if len(events) != int(afterStop) || events[0].Component != "cleanup" {
+		t.Errorf("Expected one event per run, got %d events for %d runs", len(events), afterStop)
+	}
+}
+
+func TestScheduledSkipIfRunning(t *testing.T) {
+	silenceTestStdout(t)
+
+	release := make(chan struct{})
+	task := DefineOneShot("report", Task(func(ctx Context) error {
+		<-release
+		return nil
+	}))
+	system := CreateSystem(map[string]*Component{
+		"report": Scheduled(task, Every(2*time.Millisecond), SkipIfRunning),
+	})
+
+	if err := system.Start(); err != nil {
+		t.Fatalf("Failed to start system: %v", err)
+	}
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+	system.Stop()
+
+	stats, _ := system.ScheduleStats("report")
+	if stats.Runs != 1 || stats.Skipped == 0 {
+		t.Errorf("Expected overlapping runs to be skipped, got %+v", stats)
+	}
+}
+
+func TestCronNext(t *testing.T) {
+	schedule, err := Cron("30 2 * * 1")
+	if err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+	schedule.(*cronSchedule).location = time.UTC
+
+	// 2026-10-14 is a Wednesday; the next Monday is 2026-10-19
+	next := schedule.Next(time.Date(2026, 10, 14, 12, 0, 0, 0, time.UTC))
+	if want := time.Date(2026, 10, 19, 2, 30, 0, 0, time.UTC); !next.Equal(want) {
+		t.Errorf("Expected %v, got %v", want, next)
+	}
+
+	every, _ := Cron("*/15 * * * *")
+	every.(*cronSchedule).location = time.UTC
+	next = every.Next(time.Date(2026, 1, 1, 10, 7, 0, 0, time.UTC))
+	if want := time.Date(2026, 1, 1, 10, 15, 0, 0, time.UTC); !next.Equal(want) {
+		t.Errorf("Expected %v, got %v", want, next)
+	}
+
+	for _, expr := range []string{"* * *", "61 * * * *", "*/0 * * * *", "a * * * *"} {
+		if _, err := Cron(expr); err == nil {
+			t.Errorf("Expected %q to be rejected", expr)
+		}
+	}
+}
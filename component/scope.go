@@ -0,0 +1,160 @@
+package component
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// ScopedFactory is an optional interface for start results that can derive
+// a request-scoped variant when one of their dependencies is overridden,
+// e.g. a repository bound to tenant-specific config. Scoped receives the
+// dependencies as seen by the scope and must not modify the shared result
+type ScopedFactory interface {
+	Scoped(ctx Context) (Lifecycle, error)
+}
+
+// ErrNotScopable is returned when resolving a component that depends on an
+// overridden key but does not implement ScopedFactory
+var ErrNotScopable = errors.New("component depends on an override but cannot be scoped")
+
+// Scope resolves the components of a started system with per-request
+// overrides. Components unaffected by the overrides resolve to the shared
+// results; affected ones are derived once per scope through ScopedFactory.
+// A scope is cheap to create and safe for concurrent use
+type Scope struct {
+	system    *System
+	snapshot  *graphSnapshot
+	parent    *Scope
+	overrides Context
+	derived   map[string]Lifecycle
+	mu        sync.Mutex
+}
+
+// Scope returns a resolver of the started system in which the given keys
+// resolve to the overrides
+func (s *System) Scope(overrides Context) (*Scope, error) {
+	if !s.started.Load() {
+		return nil, errors.New("system is not started")
+	}
+	return &Scope{system: s, snapshot: s.snapshot(), overrides: overrides, derived: make(map[string]Lifecycle)}, nil
+}
+
+// With returns a child scope adding overrides to those of the scope
+func (sc *Scope) With(overrides Context) *Scope {
+	return &Scope{system: sc.system, snapshot: sc.snapshot, parent: sc, overrides: overrides, derived: make(map[string]Lifecycle)}
+}
+
+// Resolve returns the value published under key as seen by the scope
+func (sc *Scope) Resolve(key string) (Lifecycle, error) {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+	value, _, err := sc.resolve(key, make(map[string]bool))
+	return value, err
+}
+
+// ResolveAs returns the value published under key as type T
+func ResolveAs[T any](sc *Scope, key string) (T, error) {
+	var zero T
+	value, err := sc.Resolve(key)
+	if err != nil {
+		return zero, err
+	}
+	typed, ok := value.(T)
+	if !ok {
+		return zero, fmt.Errorf("component %s is %T, not %T", key, value, zero)
+	}
+	return typed, nil
+}
+
+// override returns the override of key in the scope or its parents
+func (sc *Scope) override(key string) (Lifecycle, bool) {
+	for scope := sc; scope != nil; scope = scope.parent {
+		if value, ok := scope.overrides[key]; ok {
+			return value, true
+		}
+	}
+	return nil, false
+}
+
+// resolve returns the value of key and whether the scope changed it; the
+// caller must hold sc.mu
+func (sc *Scope) resolve(key string, visiting map[string]bool) (Lifecycle, bool, error) {
+	if value, ok := sc.override(key); ok {
+		return value, true, nil
+	}
+
+	provider, ok := sc.snapshot.providers[key]
+	if !ok {
+		provider = key
+	}
+	component, exists := sc.snapshot.components[provider]
+	if !exists {
+		return nil, false, fmt.Errorf("component %s not found", key)
+	}
+	if result, derived := sc.derived[provider]; derived {
+		value, err := scopedValue(component, result, key)
+		return value, true, err
+	}
+	if visiting[provider] {
+		return nil, false, fmt.Errorf("cyclic dependency on %s", provider)
+	}
+	visiting[provider] = true
+	defer delete(visiting, provider)
+
+	deps := sc.snapshot.contextKeys[provider]
+	ctx := make(Context, len(deps))
+	affected := false
+	for _, dep := range deps {
+		value, changed, err := sc.resolve(dep, visiting)
+		if err != nil {
+			return nil, false, err
+		}
+		ctx[dep] = value
+		affected = affected || changed
+	}
+	if !affected {
+		return sc.system.published(key), false, nil
+	}
+
+	factory, ok := component.Result().(ScopedFactory)
+	if !ok {
+		return nil, false, &ComponentError{Key: provider, Op: OpResolve, Err: ErrNotScopable}
+	}
+	aliasContext(component, deps, ctx)
+	ctx[EnvContextKey] = NewEnv(provider)
+	if params := component.GetParams(); params != nil {
+		ctx[ParamsContextKey] = &paramsHolder{value: params}
+	}
+	result, err := factory.Scoped(ctx)
+	if err != nil {
+		return nil, false, &ComponentError{Key: provider, Op: OpResolve, Err: err}
+	}
+	sc.derived[provider] = result
+
+	value, err := scopedValue(component, result, key)
+	return value, true, err
+}
+
+// scopedValue picks the value published under key from a derived result
+func scopedValue(component *Component, result Lifecycle, key string) (Lifecycle, error) {
+	if key == component.key {
+		return result, nil
+	}
+	multi, ok := result.(MultiProvider)
+	if !ok {
+		return nil, fmt.Errorf("scoped result of component %s does not implement MultiProvider", component.key)
+	}
+	value, exists := multi.Provided()[key]
+	if !exists {
+		return nil, fmt.Errorf("scoped result of component %s did not provide key %s", component.key, key)
+	}
+	return value, nil
+}
+
+// published returns the shared value under key in the system context
+func (s *System) published(key string) Lifecycle {
+	s.shared.Lock()
+	defer s.shared.Unlock()
+	return s.context[key]
+}
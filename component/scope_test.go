@@ -0,0 +1,77 @@
+package component
+
+import (
+	"errors"
+	"testing"
+)
+
+// TenantConfig is the configuration a request may override
+type TenantConfig struct {
+	MockComponent
+	Schema string
+}
+
+func (c *TenantConfig) Start(ctx Context) (Lifecycle, error) {
+	return c, nil
+}
+
+// TenantRepo queries the schema of the config it was built with
+type TenantRepo struct {
+	MockComponent
+	Schema string
+}
+
+func (r *TenantRepo) Start(ctx Context) (Lifecycle, error) {
+	config, _ := DependencyAs[*TenantConfig](ctx, "config")
+	return &TenantRepo{Schema: config.Schema}, nil
+}
+
+func (r *TenantRepo) Scoped(ctx Context) (Lifecycle, error) {
+	return r.Start(ctx)
+}
+
+func TestScopeResolvesWithOverrides(t *testing.T) {
+	silenceTestStdout(t)
+
+	system := CreateSystem(map[string]*Component{
+		"config": Define("config", &TenantConfig{Schema: "public"}),
+		"cache":  Define("cache", &MockComponent{}),
+		"repo":   Define("repo", &TenantRepo{}, "config"),
+		"api":    Define("api", &MockComponent{}, "repo"),
+	})
+	if _, err := system.Scope(nil); err == nil {
+		t.Error("Expected scoping a stopped system to fail")
+	}
+	if err := system.Start(); err != nil {
+		t.Fatalf("Failed to start system: %v", err)
+	}
+	defer system.Stop()
+
+	scope, err := system.Scope(Context{"config": &TenantConfig{Schema: "tenant_42"}})
+	if err != nil {
+		t.Fatalf("Expected scope, got %v", err)
+	}
+	repo, err := ResolveAs[*TenantRepo](scope, "repo")
+	if err != nil || repo.Schema != "tenant_42" {
+		t.Fatalf("Expected repo scoped to tenant_42, got %v %v", repo, err)
+	}
+	if again, _ := scope.Resolve("repo"); again != repo {
+		t.Error("Expected a scope to derive a component once")
+	}
+	if shared := system.GetContext()["repo"].(*TenantRepo); shared.Schema != "public" {
+		t.Errorf("Expected shared repo untouched, got %s", shared.Schema)
+	}
+	if cache, _ := scope.Resolve("cache"); cache != system.GetContext()["cache"] {
+		t.Error("Expected unaffected components to resolve to the shared result")
+	}
+
+	// api depends on the overridden config through repo but cannot be scoped
+	if _, err := scope.Resolve("api"); !errors.Is(err, ErrNotScopable) {
+		t.Errorf("Expected ErrNotScopable, got %v", err)
+	}
+
+	child := scope.With(Context{"config": &TenantConfig{Schema: "tenant_7"}})
+	if repo, _ := ResolveAs[*TenantRepo](child, "repo"); repo.Schema != "tenant_7" {
+		t.Errorf("Expected child override to win, got %s", repo.Schema)
+	}
+}
@@ -0,0 +1,135 @@
+package component
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// LastRun returns the events of the last Start and the Stop that followed
+// it, the range rendered by the sequence diagram writers
+func LastRun(events []Event) []Event {
+	for i := len(events) - 1; i >= 0; i-- {
+		if events[i].Type == EventSystemStarting {
+			return events[i:]
+		}
+	}
+	return events
+}
+
+// sequenceSyntax holds the notation of a sequence diagram language
+type sequenceSyntax struct {
+	header, footer string
+	participant    string // alias, label
+	call, reply    string // from, to, text
+	failure        string // from, to, text
+	note           string // participant, text
+}
+
+var mermaidSyntax = sequenceSyntax{
+	header:      "sequenceDiagram\n",
+	participant: "    participant %s as %s\n",
+	call:        "    %s->>%s: %s\n",
+	reply:       "    %s-->>%s: %s\n",
+	failure:     "    %s--x%s: %s\n",
+	note:        "    Note over %s: %s\n",
+}
+
+var plantUMLSyntax = sequenceSyntax{
+	header:      "@startuml\n",
+	footer:      "@enduml\n",
+	participant: "participant %[2]q as %[1]s\n",
+	call:        "%s -> %s : %s\n",
+	reply:       "%s --> %s : %s\n",
+	failure:     "%s -->x %s : %s\n",
+	note:        "note over %s : %s\n",
+}
+
+// WriteMermaidSequence renders events, typically LastRun of a recorded log,
+// as a Mermaid sequence diagram of the operations with their durations and failures
+func WriteMermaidSequence(w io.Writer, events []Event) error {
+	return writeSequence(w, events, mermaidSyntax)
+}
+
+// WritePlantUMLSequence renders events like WriteMermaidSequence in PlantUML notation
+func WritePlantUMLSequence(w io.Writer, events []Event) error {
+	return writeSequence(w, events, plantUMLSyntax)
+}
+
+func writeSequence(w io.Writer, events []Event, syntax sequenceSyntax) error {
+	var b strings.Builder
+	b.WriteString(syntax.header)
+
+	// Declare participants in order of appearance, aliased so keys with
+	// punctuation do not break the notation
+	aliases := make(map[string]string)
+	fmt.Fprintf(&b, syntax.participant, "system", "System")
+	for _, event := range events {
+		if event.Component != "" && aliases[event.Component] == "" {
+			aliases[event.Component] = fmt.Sprintf("c%d", len(aliases))
+			fmt.Fprintf(&b, syntax.participant, aliases[event.Component], event.Component)
+		}
+	}
+
+	for _, event := range events {
+		alias := aliases[event.Component]
+		switch event.Type {
+		case EventSystemStarting:
+			fmt.Fprintf(&b, syntax.note, "system", diagramText("start "+event.CorrelationID))
+		case EventSystemStopping:
+			fmt.Fprintf(&b, syntax.note, "system", diagramText("stop "+event.CorrelationID+reasonSuffix(event)))
+		case EventSystemStarted, EventSystemStopped:
+			text := string(event.Type) + durationSuffix(event)
+			if event.Err != nil {
+				text += ": " + event.Err.Error()
+			}
+			fmt.Fprintf(&b, syntax.note, "system", diagramText(text))
+		case EventComponentStarting:
+			fmt.Fprintf(&b, syntax.call, "system", alias, "Start")
+		case EventComponentStopping:
+			fmt.Fprintf(&b, syntax.call, "system", alias, diagramText("Stop"+reasonSuffix(event)))
+		case EventComponentStarted, EventComponentCompleted, EventComponentStopped, EventComponentFailed:
+			if event.Err != nil {
+				fmt.Fprintf(&b, syntax.failure, alias, "system", diagramText("failed"+durationSuffix(event)+": "+event.Err.Error()))
+				continue
+			}
+			text := strings.TrimPrefix(string(event.Type), "component_") + durationSuffix(event)
+			fmt.Fprintf(&b, syntax.reply, alias, "system", text)
+		default:
+			if alias == "" {
+				continue
+			}
+			text := string(event.Type)
+			if event.Err != nil {
+				text += ": " + event.Err.Error()
+			}
+			fmt.Fprintf(&b, syntax.note, alias, diagramText(text))
+		}
+	}
+
+	b.WriteString(syntax.footer)
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// durationSuffix formats the duration of an event for a diagram label
+func durationSuffix(event Event) string {
+	if event.Duration <= 0 {
+		return ""
+	}
+	return fmt.Sprintf(" (%v)", event.Duration.Round(time.Microsecond))
+}
+
+// reasonSuffix formats the shutdown reason of an event for a diagram label
+func reasonSuffix(event Event) string {
+	if event.Reason == nil {
+		return ""
+	}
+	return " (" + event.Reason.String() + ")"
+}
+
+// diagramText keeps a label on one line and free of statement separators
+func diagramText(text string) string {
+	return strings.NewReplacer("\n", " ", ";", ",", "#", "").Replace(text)
+}
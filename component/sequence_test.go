@@ -0,0 +1,58 @@
+package component
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestSequenceDiagrams(t *testing.T) {
+	silenceTestStdout(t)
+
+	recorder := NewEventRecorder()
+	system := CreateSystem(map[string]*Component{
+		"db":          Define("db", &MockComponent{}),
+		"http/server": Define("http/server", &MockComponent{StopError: errors.New("port busy; retry")}, "db"),
+	}, WithEventListener(recorder.Record))
+
+	system.Start()
+	system.Stop()
+	system.Start()
+	system.Stop()
+
+	run := LastRun(recorder.Events())
+	if run[0].Type != EventSystemStarting || len(run) != len(recorder.Events())/2 {
+		t.Fatalf("Expected the last start and stop only, got %d of %d events", len(run), len(recorder.Events()))
+	}
+
+	var mermaid strings.Builder
+	if err := WriteMermaidSequence(&mermaid, run); err != nil {
+		t.Fatalf("Failed to write diagram: %v", err)
+	}
+	out := mermaid.String()
+	for _, want := range []string{
+		"sequenceDiagram\n",
+		"participant c0 as db\n",
+		"participant c1 as http/server\n",
+		"system->>c0: Start\n",
+		"c0-->>system: started (",
+		"system->>c1: Stop (api)\n",
+		"c1--xsystem: failed (",
+		"port busy, retry",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Expected %q in diagram:\n%s", want, out)
+		}
+	}
+	if strings.Index(out, "system->>c1: Start") > strings.Index(out, "system->>c1: Stop") {
+		t.Error("Expected operations in event order")
+	}
+
+	var plantUML strings.Builder
+	WritePlantUMLSequence(&plantUML, run)
+	out = plantUML.String()
+	if !strings.HasPrefix(out, "@startuml\n") || !strings.HasSuffix(out, "@enduml\n") ||
+		!strings.Contains(out, "participant \"http/server\" as c1\n") || !strings.Contains(out, "c1 -->x system : failed") {
+		t.Errorf("Unexpected PlantUML diagram:\n%s", out)
+	}
+}
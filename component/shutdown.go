@@ -0,0 +1,57 @@
+package component
+
+import (
+	"os"
+)
+
+// ShutdownCause identifies what triggered a system shutdown
+type ShutdownCause string
+
+const (
+	// ShutdownAPI is a shutdown requested by calling Stop
+	ShutdownAPI ShutdownCause = "api"
+
+	// ShutdownSignal is a shutdown triggered by an OS signal
+	ShutdownSignal ShutdownCause = "signal"
+
+	// ShutdownSupervisor is a shutdown escalated by a supervisor
+	ShutdownSupervisor ShutdownCause = "supervisor"
+
+	// ShutdownFatalError is a shutdown caused by an unrecoverable component error
+	ShutdownFatalError ShutdownCause = "fatal_error"
+)
+
+// ShutdownReason describes why the system is stopping
+type ShutdownReason struct {
+	Cause ShutdownCause
+
+	// Detail carries extra information such as the signal name
+	Detail string
+
+	// Err is the error that caused a fatal shutdown, if any
+	Err error
+}
+
+// SignalReason builds the shutdown reason for an OS signal
+func SignalReason(sig os.Signal) ShutdownReason {
+	return ShutdownReason{Cause: ShutdownSignal, Detail: sig.String()}
+}
+
+// FatalReason builds the shutdown reason for an unrecoverable error
+func FatalReason(err error) ShutdownReason {
+	return ShutdownReason{Cause: ShutdownFatalError, Detail: err.Error(), Err: err}
+}
+
+func (r ShutdownReason) String() string {
+	if r.Detail == "" {
+		return string(r.Cause)
+	}
+	return string(r.Cause) + ": " + r.Detail
+}
+
+// ReasonStopper is an optional interface for components that want to know
+// why the system is stopping, e.g. to choose between fast and thorough cleanup.
+// When implemented it is called instead of Stop
+type ReasonStopper interface {
+	StopWithReason(ctx Context, reason ShutdownReason) error
+}
@@ -0,0 +1,148 @@
+package component
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// ShutdownStage selects the components stopped together in one stage of a
+// staged shutdown
+type ShutdownStage struct {
+	// Tags select the components of the stage. Components depending on
+	// them stop in the same stage, so no component outlives a dependency
+	Tags []string
+
+	// Grace is extra stop time given to the stage when the system stops
+	// within a deadline, e.g. for workers draining their queues. It
+	// extends the system deadline and the share of each component of the
+	// stage
+	Grace time.Duration
+}
+
+// WithShutdownStages stops the components in stages: first the components
+// selected by the first stage, then by the second and so on, and finally
+// every other component. Each stage completes before the next one begins
+// and stops its own components in reverse dependency order, as
+// ShutdownOrder reports: the reverse of EffectiveOrder restricted to the
+// stage when stopping sequentially
+func WithShutdownStages(stages ...ShutdownStage) Option {
+	return func(s *System) {
+		s.shutdownStages = stages
+	}
+}
+
+// WithTwoStageShutdown applies the common production sequence: components
+// tagged "ingress" stop first, so no new work arrives, then components tagged
+// "worker" stop with drain extra time to finish what they hold, then the rest
+func WithTwoStageShutdown(drain time.Duration) Option {
+	return WithShutdownStages(
+		ShutdownStage{Tags: []string{"ingress"}},
+		ShutdownStage{Tags: []string{"worker"}, Grace: drain},
+	)
+}
+
+// stopStages stops the started components stage by stage. Components keep
+// stopping past failures, as do the stages
+func (s *System) stopStages(plan Plan, stop func(key string) error) error {
+	if len(s.shutdownStages) == 0 {
+		return s.stopExecutor().Execute(plan, stop)
+	}
+
+	defer func() { s.stopGrace = nil }()
+	var errs []error
+	for i, staged := range s.stagePlans(plan) {
+		var grace time.Duration
+		var tags []string
+		if i < len(s.shutdownStages) {
+			grace, tags = s.shutdownStages[i].Grace, s.shutdownStages[i].Tags
+		}
+		if len(staged.Steps) == 0 {
+			continue
+		}
+
+		s.Logger().Debug("Stopping shutdown stage", "stage", i+1, "tags", strings.Join(tags, ","), "components", strings.Join(staged.Steps, ","))
+		s.stopGrace = nil
+		if grace > 0 && !s.stopDeadline.IsZero() {
+			s.stopDeadline = s.stopDeadline.Add(grace)
+			s.stopGrace = make(map[string]time.Duration, len(staged.Steps))
+			for _, key := range staged.Steps {
+				s.stopGrace[key] = grace
+			}
+		}
+		if err := s.stopExecutor().Execute(staged, stop); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// stagePlans splits a stop plan into one plan per shutdown stage, followed
+// by the plan of the unstaged components. A staged component pulls the
+// components depending on it into its stage
+func (s *System) stagePlans(plan Plan) []Plan {
+	stageOf := make(map[string]int, len(plan.Steps))
+	for i, stage := range s.shutdownStages {
+		for _, key := range plan.Steps {
+			if _, assigned := stageOf[key]; assigned || !hasAnyTag(s.components[key], stage.Tags) {
+				continue
+			}
+			s.assignStage(key, i, stageOf, plan.Waits)
+		}
+	}
+
+	plans := make([]Plan, len(s.shutdownStages)+1)
+	for i := range plans {
+		plans[i] = Plan{Op: plan.Op, Waits: make(map[string][]string)}
+	}
+	stage := func(key string) int {
+		if i, assigned := stageOf[key]; assigned {
+			return i
+		}
+		return len(s.shutdownStages)
+	}
+
+	for _, key := range plan.Steps {
+		i := stage(key)
+		plans[i].Steps = append(plans[i].Steps, key)
+		for _, dependent := range plan.Waits[key] {
+			if stage(dependent) == i {
+				plans[i].Waits[key] = append(plans[i].Waits[key], dependent)
+			}
+		}
+	}
+	for _, level := range plan.Levels {
+		staged := make([][]string, len(plans))
+		for _, key := range level {
+			staged[stage(key)] = append(staged[stage(key)], key)
+		}
+		for i, keys := range staged {
+			if len(keys) > 0 {
+				plans[i].Levels = append(plans[i].Levels, keys)
+			}
+		}
+	}
+	return plans
+}
+
+// assignStage puts a component and, transitively, the unassigned components
+// waiting on it into a stage
+func (s *System) assignStage(key string, stage int, stageOf map[string]int, waits map[string][]string) {
+	if _, assigned := stageOf[key]; assigned {
+		return
+	}
+	stageOf[key] = stage
+	for _, dependent := range waits[key] {
+		s.assignStage(dependent, stage, stageOf, waits)
+	}
+}
+
+// hasAnyTag reports whether the component carries one of the tags
+func hasAnyTag(component *Component, tags []string) bool {
+	for _, tag := range tags {
+		if component.HasTag(tag) {
+			return true
+		}
+	}
+	return false
+}
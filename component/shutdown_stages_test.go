@@ -0,0 +1,102 @@
+package component
+
+import (
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+// DrainingComponent takes a while to stop
+type DrainingComponent struct {
+	MockComponent
+	drain time.Duration
+}
+
+func (c *DrainingComponent) Stop(ctx Context) error {
+	time.Sleep(c.drain)
+	return nil
+}
+
+func stagedSystem(opts ...Option) (*System, *[]string) {
+	var stopped []string
+	var mu sync.Mutex
+	listener := WithEventListener(func(event Event) {
+		if event.Type == EventComponentStopped {
+			mu.Lock()
+			defer mu.Unlock()
+			stopped = append(stopped, event.Component)
+		}
+	})
+	system := CreateSystem(map[string]*Component{
+		"db":       Define("db", &MockComponent{}),
+		"queue":    Define("queue", &MockComponent{}, "db"),
+		"consumer": Define("consumer", &MockComponent{}, "queue").WithTags("worker"),
+		"api":      Define("api", &MockComponent{}, "db", "queue").WithTags("ingress"),
+		"plugin":   Define("plugin", &MockComponent{}, "api"),
+		"metrics":  Define("metrics", &MockComponent{}),
+	}, append([]Option{listener}, opts...)...)
+	return system, &stopped
+}
+
+func TestShutdownStages(t *testing.T) {
+	system, stopped := stagedSystem(WithTwoStageShutdown(0))
+	if err := system.Start(); err != nil {
+		t.Fatalf("Failed to start system: %v", err)
+	}
+	if err := system.Stop(); err != nil {
+		t.Fatalf("Failed to stop system: %v", err)
+	}
+
+	expected := []string{"plugin", "api", "consumer", "queue", "metrics", "db"}
+	if !reflect.DeepEqual(*stopped, expected) {
+		t.Errorf("Expected stop order %v, got %v", expected, *stopped)
+	}
+}
+
+func TestShutdownStagesParallel(t *testing.T) {
+	system, stopped := stagedSystem(WithTwoStageShutdown(0), WithParallelStop(4))
+	if err := system.Start(); err != nil {
+		t.Fatalf("Failed to start system: %v", err)
+	}
+	if err := system.Stop(); err != nil {
+		t.Fatalf("Failed to stop system: %v", err)
+	}
+
+	position := make(map[string]int)
+	for i, key := range *stopped {
+		position[key] = i
+	}
+	if len(position) != 6 {
+		t.Fatalf("Expected every component to stop, got %v", *stopped)
+	}
+	if position["plugin"] > position["api"] || position["api"] > position["consumer"] || position["consumer"] > position["queue"] {
+		t.Errorf("Expected ingress, then workers, then the rest, got %v", *stopped)
+	}
+}
+
+func TestShutdownStageGrace(t *testing.T) {
+	components := func() map[string]*Component {
+		return map[string]*Component{
+			"queue":    Define("queue", &MockComponent{}),
+			"consumer": Define("consumer", &DrainingComponent{drain: 60 * time.Millisecond}, "queue").WithTags("worker"),
+		}
+	}
+
+	tight := CreateSystem(components(), WithStopDeadline(20*time.Millisecond))
+	if err := tight.Start(); err != nil {
+		t.Fatalf("Failed to start system: %v", err)
+	}
+	if err := tight.Stop(); !errors.Is(err, ErrStopDeadlineExceeded) {
+		t.Errorf("Expected the drain to overrun the deadline, got %v", err)
+	}
+
+	graced := CreateSystem(components(), WithStopDeadline(20*time.Millisecond), WithTwoStageShutdown(time.Second))
+	if err := graced.Start(); err != nil {
+		t.Fatalf("Failed to start system: %v", err)
+	}
+	if err := graced.Stop(); err != nil {
+		t.Errorf("Expected the worker grace to cover the drain, got %v", err)
+	}
+}
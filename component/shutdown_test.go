@@ -0,0 +1,65 @@
+package component
+
+import (
+	"syscall"
+	"testing"
+)
+
+// ReasonAwareComponent records the shutdown reason it was stopped with
+type ReasonAwareComponent struct {
+	MockComponent
+	Reason ShutdownReason
+}
+
+func (r *ReasonAwareComponent) StopWithReason(ctx Context, reason ShutdownReason) error {
+	r.Reason = reason
+	return r.MockComponent.Stop(ctx)
+}
+
+func TestStopWithReason(t *testing.T) {
+	aware := &ReasonAwareComponent{}
+	plain := &MockComponent{}
+
+	var reasons []ShutdownReason
+	system := CreateSystem(map[string]*Component{
+		"aware": Define("aware", aware),
+		"plain": Define("plain", plain, "aware"),
+	}, WithEventListener(func(e Event) {
+		if e.Type == EventComponentStopped {
+			reasons = append(reasons, *e.Reason)
+		}
+	}))
+	if err := system.Start(); err != nil {
+		t.Fatalf("Failed to start system: %v", err)
+	}
+
+	if err := system.StopWithReason(SignalReason(syscall.SIGTERM)); err != nil {
+		t.Fatalf("Failed to stop system: %v", err)
+	}
+
+	if aware.Reason.Cause != ShutdownSignal || aware.Reason.Detail != syscall.SIGTERM.String() {
+		t.Errorf("Expected signal reason, got %v", aware.Reason)
+	}
+	if !plain.StopCalled {
+		t.Error("Expected components without StopWithReason to be stopped with Stop")
+	}
+	if len(reasons) != 2 || reasons[0].Cause != ShutdownSignal {
+		t.Errorf("Expected stop events to carry the reason, got %v", reasons)
+	}
+}
+
+func TestStopDefaultsToAPIReason(t *testing.T) {
+	aware := &ReasonAwareComponent{}
+	system := CreateSystem(map[string]*Component{
+		"aware": Define("aware", aware),
+	})
+	if err := system.Start(); err != nil {
+		t.Fatalf("Failed to start system: %v", err)
+	}
+	if err := system.Stop(); err != nil {
+		t.Fatalf("Failed to stop system: %v", err)
+	}
+	if aware.Reason.Cause != ShutdownAPI {
+		t.Errorf("Expected api reason, got %v", aware.Reason)
+	}
+}
@@ -0,0 +1,58 @@
+package component
+
+import (
+	"context"
+	"log/slog"
+)
+
+// WithSlog logs every lifecycle event as a structured record, with the
+// component key and id, correlation ID, duration, current state and error
+// as attributes. Events carrying an error are logged at error level, the
+// others at info level. Pass the same logger to WithLogger to route the
+// plain lifecycle lines there too
+func WithSlog(logger *slog.Logger) Option {
+	return func(s *System) {
+		s.listeners = append(s.listeners, func(event Event) {
+			s.logEvent(logger, event)
+		})
+	}
+}
+
+// logEvent writes one event to a slog logger
+func (s *System) logEvent(logger *slog.Logger, event Event) {
+	level := slog.LevelInfo
+	if event.Err != nil {
+		level = slog.LevelError
+	}
+	ctx := context.Background()
+	if !logger.Enabled(ctx, level) {
+		return
+	}
+
+	attrs := make([]slog.Attr, 0, 8)
+	if event.Component != "" {
+		attrs = append(attrs, slog.String("component", event.Component), slog.String("component_id", event.ComponentID))
+		if component, exists := s.snapshot().components[event.Component]; exists {
+			attrs = append(attrs, slog.String("state", component.State().String()))
+		}
+	}
+	if event.CorrelationID != "" {
+		attrs = append(attrs, slog.String("correlation_id", event.CorrelationID))
+	}
+	if event.Duration > 0 {
+		attrs = append(attrs, slog.Duration("duration", event.Duration))
+	}
+	if event.Reason != nil {
+		attrs = append(attrs, slog.String("reason", event.Reason.String()))
+	}
+	if event.Hook != "" {
+		attrs = append(attrs, slog.String("hook", event.Hook))
+	}
+	if event.Dependency != "" {
+		attrs = append(attrs, slog.String("dependency", event.Dependency))
+	}
+	if event.Err != nil {
+		attrs = append(attrs, slog.Any("error", event.Err))
+	}
+	logger.LogAttrs(ctx, level, string(event.Type), attrs...)
+}
@@ -0,0 +1,70 @@
+package component
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"testing"
+)
+
+func TestWithSlog(t *testing.T) {
+	silenceTestStdout(t)
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, nil))
+	system := CreateSystem(map[string]*Component{
+		"db":  Define("db", &MockComponent{}),
+		"api": Define("api", &MockComponent{StartError: errors.New("port in use")}, "db"),
+	}, WithSlog(logger))
+	system.Start()
+
+	var records []map[string]interface{}
+	decoder := json.NewDecoder(&buf)
+	for decoder.More() {
+		var record map[string]interface{}
+		if err := decoder.Decode(&record); err != nil {
+			t.Fatalf("Failed to decode record: %v", err)
+		}
+		records = append(records, record)
+	}
+
+	find := func(msg, key string) map[string]interface{} {
+		for _, record := range records {
+			if record["msg"] == msg && (key == "" || record["component"] == key) {
+				return record
+			}
+		}
+		t.Fatalf("Expected a %s record for %q, got %v", msg, key, records)
+		return nil
+	}
+
+	started := find(string(EventComponentStarted), "db")
+	if started["level"] != "INFO" || started["state"] != "started" || started["correlation_id"] == nil || started["duration"] == nil {
+		t.Errorf("Expected an info record with state, correlation ID and duration, got %v", started)
+	}
+	failed := find(string(EventComponentFailed), "api")
+	if failed["level"] != "ERROR" || failed["error"] == nil || failed["state"] != "failed" {
+		t.Errorf("Expected an error record with the failure, got %v", failed)
+	}
+	if system := find(string(EventSystemStarting), ""); system["component"] != nil {
+		t.Errorf("Expected no component attributes on system events, got %v", system)
+	}
+}
+
+func TestWithSlogRespectsLevel(t *testing.T) {
+	silenceTestStdout(t)
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelError}))
+	system := CreateSystem(map[string]*Component{
+		"db": Define("db", &MockComponent{}),
+	}, WithSlog(logger))
+	if err := system.Start(); err != nil {
+		t.Fatalf("Failed to start system: %v", err)
+	}
+	system.Stop()
+	if buf.Len() != 0 {
+		t.Errorf("Expected no records below error level, got %q", buf.String())
+	}
+}
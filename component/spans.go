@@ -0,0 +1,113 @@
+package component
+
+import "context"
+
+// TracerName is the instrumentation name the system requests its tracer under
+const TracerName = "github.com/leandroolgomes/golang-dependency-graph/component"
+
+// TracerProvider creates the spans of lifecycle operations. It mirrors the
+// shape of OpenTelemetry's trace.TracerProvider so an adapter over one takes
+// a few lines; componentotel provides one exporting OTLP
+type TracerProvider interface {
+	Tracer(name string) Tracer
+}
+
+// Tracer starts spans, returning a context carrying the span as parent of
+// the spans started from it
+type Tracer interface {
+	Start(ctx context.Context, name string, attributes ...SpanAttribute) (context.Context, Span)
+}
+
+// Span is an operation in progress
+type Span interface {
+	RecordError(err error)
+	End()
+}
+
+// SpanAttribute annotates a span
+type SpanAttribute struct {
+	Key   string
+	Value string
+}
+
+// WithTracerProvider emits a span for every system start and stop, with a
+// child span per component start or stop, so the boot sequence shows as a
+// waterfall. Starts inherit the span of the context given to StartContext
+func WithTracerProvider(provider TracerProvider) Option {
+	return func(s *System) {
+		s.spans.tracer = provider.Tracer(TracerName)
+	}
+}
+
+// lifecycleSpans are the spans open for the operation in progress, guarded
+// by emitMu
+type lifecycleSpans struct {
+	tracer     Tracer
+	system     Span
+	systemCtx  context.Context
+	components map[string]Span
+}
+
+// traceEvent opens and closes spans from lifecycle events; the caller must
+// hold emitMu
+func (s *System) traceEvent(event Event) {
+	spans := &s.spans
+	if spans.tracer == nil {
+		return
+	}
+
+	switch event.Type {
+	case EventSystemStarting, EventSystemStopping:
+		parent, name := context.Background(), "system stop"
+		if event.Type == EventSystemStarting {
+			parent, name = s.bootContext(), "system start"
+		}
+		spans.systemCtx, spans.system = spans.tracer.Start(parent, name, spanAttributes(event)...)
+
+	case EventSystemStarted, EventSystemStopped:
+		if spans.system != nil {
+			endSpan(spans.system, event.Err)
+			spans.system, spans.systemCtx = nil, nil
+		}
+
+	case EventComponentStarting, EventComponentStopping:
+		parent, name := spans.systemCtx, "stop "+event.Component
+		if parent == nil {
+			parent = context.Background()
+		}
+		if event.Type == EventComponentStarting {
+			name = "start " + event.Component
+		}
+		if spans.components == nil {
+			spans.components = make(map[string]Span)
+		}
+		_, spans.components[event.Component] = spans.tracer.Start(parent, name, spanAttributes(event)...)
+
+	case EventComponentStarted, EventComponentCompleted, EventComponentFailed, EventComponentStopped:
+		if span, ok := spans.components[event.Component]; ok {
+			endSpan(span, event.Err)
+			delete(spans.components, event.Component)
+		}
+	}
+}
+
+// spanAttributes describes the operation an event starts
+func spanAttributes(event Event) []SpanAttribute {
+	attributes := []SpanAttribute{{Key: "correlation_id", Value: event.CorrelationID}}
+	if event.Component != "" {
+		attributes = append(attributes,
+			SpanAttribute{Key: "component.key", Value: event.Component},
+			SpanAttribute{Key: "component.id", Value: event.ComponentID})
+	}
+	if event.Reason != nil {
+		attributes = append(attributes, SpanAttribute{Key: "shutdown.cause", Value: string(event.Reason.Cause)})
+	}
+	return attributes
+}
+
+func endSpan(span Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+	}
+	span.End()
+}
@@ -0,0 +1,70 @@
+package component
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+// recordedSpan is a span of the fake tracer
+type recordedSpan struct {
+	name   string
+	parent string
+	err    error
+	ended  bool
+}
+
+type spanKey struct{}
+
+// fakeTracer records spans with the name of their parent
+type fakeTracer struct {
+	spans []*recordedSpan
+}
+
+func (f *fakeTracer) Tracer(name string) Tracer {
+	return f
+}
+
+func (f *fakeTracer) Start(ctx context.Context, name string, attributes ...SpanAttribute) (context.Context, Span) {
+	parent, _ := ctx.Value(spanKey{}).(string)
+	span := &recordedSpan{name: name, parent: parent}
+	f.spans = append(f.spans, span)
+	return context.WithValue(ctx, spanKey{}, name), span
+}
+
+func (s *recordedSpan) RecordError(err error) { s.err = err }
+func (s *recordedSpan) End()                  { s.ended = true }
+
+func TestTracerProviderSpans(t *testing.T) {
+	tracer := &fakeTracer{}
+	system := CreateSystem(map[string]*Component{
+		"db":  Define("db", &MockComponent{}),
+		"api": Define("api", &MockComponent{StopError: errors.New("drain failed")}, "db"),
+	}, WithTracerProvider(tracer))
+
+	parent := context.WithValue(context.Background(), spanKey{}, "deploy")
+	if err := system.StartContext(parent); err != nil {
+		t.Fatalf("Failed to start system: %v", err)
+	}
+	system.Stop()
+
+	want := []recordedSpan{
+		{name: "system start", parent: "deploy"},
+		{name: "start db", parent: "system start"},
+		{name: "start api", parent: "system start"},
+		{name: "system stop"},
+		{name: "stop api", parent: "system stop"},
+		{name: "stop db", parent: "system stop"},
+	}
+	if len(tracer.spans) != len(want) {
+		t.Fatalf("Expected %d spans, got %d", len(want), len(tracer.spans))
+	}
+	for i, span := range tracer.spans {
+		if span.name != want[i].name || span.parent != want[i].parent || !span.ended {
+			t.Errorf("Expected span %d to be %+v, got %+v", i, want[i], *span)
+		}
+	}
+	if tracer.spans[4].err == nil || tracer.spans[3].err == nil {
+		t.Error("Expected the stop failure on the api and system stop spans")
+	}
+}
@@ -0,0 +1,185 @@
+package component
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// EventComponentSlowStart is emitted once for a component whose Start runs
+// past the slow-start threshold, with the stack it was most often blocked on
+const EventComponentSlowStart EventType = "component_slow_start"
+
+// slowStartSamples is how many stacks are sampled before a still running
+// Start is reported
+const slowStartSamples = 10
+
+// componentStartFrame is the frame calling a component's Start; the frames
+// below it belong to the system and are left out of sampled stacks
+var componentStartFrame = reflect.TypeOf(Component{}).PkgPath() + ".(*Component).start("
+
+// WithSlowStartSampling samples the goroutine stack of a component's Start
+// every interval once it runs longer than threshold, and emits
+// EventComponentSlowStart with the most frequent stack in Event.Stack after
+// ten samples or when Start returns, whichever comes first. Sampling every
+// goroutine briefly stops the world, so it is meant for diagnosing slow
+// starts rather than for every deployment. An interval of zero samples ten
+// times per threshold
+func WithSlowStartSampling(threshold, interval time.Duration) Option {
+	return func(s *System) {
+		if interval <= 0 {
+			interval = threshold / slowStartSamples
+		}
+		s.slowStart = threshold
+		s.slowStartInterval = max(interval, time.Millisecond)
+	}
+}
+
+// sampleStart samples the calling goroutine while a component starts past
+// the slow-start threshold; the returned func ends the sampling and waits
+// for the slow-start event, if any, to be emitted
+func (s *System) sampleStart(component *Component, correlationID string) func() {
+	if s.slowStart <= 0 {
+		return func() {}
+	}
+
+	id := currentGoroutineID()
+	startTime := time.Now()
+	done := make(chan struct{})
+	finished := make(chan struct{})
+	go func() {
+		defer close(finished)
+
+		timer := time.NewTimer(s.slowStart)
+		defer timer.Stop()
+		select {
+		case <-done:
+			return
+		case <-timer.C:
+		}
+
+		ticker := time.NewTicker(s.slowStartInterval)
+		defer ticker.Stop()
+		sampler := &stackSampler{goroutine: id, counts: make(map[string]int)}
+		sampler.sample()
+	sampling:
+		for sampler.samples < slowStartSamples {
+			select {
+			case <-done:
+				break sampling
+			case <-ticker.C:
+				sampler.sample()
+			}
+		}
+
+		event := componentEvent(EventComponentSlowStart, component, correlationID)
+		event.Duration = time.Since(startTime)
+		event.Stack = sampler.dominant()
+		s.emit(event)
+	}()
+
+	return func() {
+		close(done)
+		<-finished
+	}
+}
+
+// stackSampler counts the distinct stacks one goroutine was seen in
+type stackSampler struct {
+	goroutine int64
+	samples   int
+	counts    map[string]int
+	order     []string
+}
+
+// sample records the current stack of the goroutine, if it still exists
+func (s *stackSampler) sample() {
+	s.samples++
+	stack, ok := goroutineStack(allStacks(), s.goroutine)
+	if !ok {
+		return
+	}
+	if s.counts[stack] == 0 {
+		s.order = append(s.order, stack)
+	}
+	s.counts[stack]++
+}
+
+// dominant returns the most frequent stack, the first seen on a tie
+func (s *stackSampler) dominant() string {
+	var best string
+	for _, stack := range s.order {
+		if s.counts[stack] > s.counts[best] {
+			best = stack
+		}
+	}
+	return best
+}
+
+// allStacks returns the stacks of every goroutine
+func allStacks() []byte {
+	buf := make([]byte, 64<<10)
+	for {
+		n := runtime.Stack(buf, true)
+		if n < len(buf) {
+			return buf[:n]
+		}
+		buf = make([]byte, 2*len(buf))
+	}
+}
+
+// currentGoroutineID returns the ID of the calling goroutine, read from the
+// header of its stack
+func currentGoroutineID() int64 {
+	buf := make([]byte, 64)
+	buf = buf[:runtime.Stack(buf, false)]
+	fields := bytes.Fields(buf)
+	if len(fields) < 2 {
+		return 0
+	}
+	id, _ := strconv.ParseInt(string(fields[1]), 10, 64)
+	return id
+}
+
+// goroutineStack extracts the stack of one goroutine from a dump of every
+// goroutine, normalized so samples of the same blocking call compare equal:
+// the wait reason without its duration, functions without their arguments
+// and files without their pc offsets, down to the component Start call
+func goroutineStack(dump []byte, id int64) (string, bool) {
+	header := fmt.Sprintf("goroutine %d [", id)
+	for _, block := range strings.Split(string(dump), "\n\n") {
+		if !strings.HasPrefix(block, header) {
+			continue
+		}
+		lines := strings.Split(block, "\n")
+		state, _, _ := strings.Cut(strings.TrimPrefix(lines[0], header), "]")
+		state, _, _ = strings.Cut(state, ",")
+
+		stack := []string{"[" + state + "]"}
+		for i := 1; i+1 < len(lines); i += 2 {
+			if strings.HasPrefix(lines[i], componentStartFrame) {
+				break
+			}
+			file, _, _ := strings.Cut(lines[i+1], " +0x")
+			stack = append(stack, stripArguments(lines[i]), file)
+		}
+		return strings.Join(stack, "\n"), true
+	}
+	return "", false
+}
+
+// stripArguments removes the argument values from a function line of a
+// stack, which differ between calls
+func stripArguments(function string) string {
+	if !strings.HasSuffix(function, ")") {
+		return function
+	}
+	if open := strings.LastIndex(function, "("); open > 0 {
+		return function[:open] + "(...)"
+	}
+	return function
+}
@@ -0,0 +1,83 @@
+package component
+
+import (
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// BlockedComponent waits on a channel in Start until released
+type BlockedComponent struct {
+	MockComponent
+	release chan struct{}
+}
+
+func (c *BlockedComponent) Start(ctx Context) (Lifecycle, error) {
+	<-c.release
+	return c, nil
+}
+
+func TestSlowStartSampling(t *testing.T) {
+	var mu sync.Mutex
+	var slow []Event
+	blocked := &BlockedComponent{release: make(chan struct{})}
+	system := CreateSystem(map[string]*Component{
+		"db":  Define("db", &MockComponent{}),
+		"api": Define("api", blocked, "db"),
+	}, WithSlowStartSampling(10*time.Millisecond, time.Millisecond), WithEventListener(func(event Event) {
+		if event.Type == EventComponentSlowStart {
+			mu.Lock()
+			defer mu.Unlock()
+			slow = append(slow, event)
+		}
+	}))
+
+	time.AfterFunc(100*time.Millisecond, func() { close(blocked.release) })
+	if err := system.Start(); err != nil {
+		t.Fatalf("Failed to start system: %v", err)
+	}
+	defer system.Stop()
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(slow) != 1 || slow[0].Component != "api" {
+		t.Fatalf("Expected one slow start event for api, got %v", slow)
+	}
+	stack := slow[0].Stack
+	if !strings.HasPrefix(stack, "[chan receive]") || !strings.Contains(stack, "(*BlockedComponent).Start(...)") {
+		t.Errorf("Expected the blocking receive in the stack, got:\n%s", stack)
+	}
+	if strings.Contains(stack, "runStart") || strings.Contains(stack, "+0x") {
+		t.Errorf("Expected system frames and offsets left out, got:\n%s", stack)
+	}
+	if slow[0].Duration < 10*time.Millisecond {
+		t.Errorf("Expected the event past the threshold, got %v", slow[0].Duration)
+	}
+}
+
+func TestGoroutineStack(t *testing.T) {
+	dump := []byte(`goroutine 1 [running]:
+main.main()
+	/app/main.go:10 +0x1d
+
+goroutine 7 [select, 2 minutes]:
+example.com/app.(*Broker).dial(0xc000010000, {0x5, 0x6})
+	/app/broker.go:42 +0x99
+example.com/app.(*Broker).Start(0xc000010000, 0xc000020000)
+	/app/broker.go:17 +0x45
+` + componentStartFrame + `0xc000030000, 0xc000020000)
+	/component/component.go:127 +0x80
+`)
+
+	stack, ok := goroutineStack(dump, 7)
+	want := "[select]\n" +
+		"example.com/app.(*Broker).dial(...)\n\t/app/broker.go:42\n" +
+		"example.com/app.(*Broker).Start(...)\n\t/app/broker.go:17"
+	if !ok || stack != want {
+		t.Errorf("Expected:\n%s\ngot:\n%s", want, stack)
+	}
+	if _, ok := goroutineStack(dump, 70); ok {
+		t.Error("Expected no stack for a goroutine missing from the dump")
+	}
+}
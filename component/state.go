@@ -0,0 +1,135 @@
+package component
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// StateSaver is an optional interface for components that can export their
+// in-memory state when the system stops
+type StateSaver interface {
+	SaveState() ([]byte, error)
+}
+
+// StateLoader is an optional interface for components that can restore the
+// state saved by a previous run before they start
+type StateLoader interface {
+	LoadState(state []byte) error
+}
+
+// StateStore persists component state between system runs
+type StateStore interface {
+	// Save stores the state for a component key
+	Save(key string, state []byte) error
+
+	// Load returns the state for a component key, if any
+	Load(key string) ([]byte, bool, error)
+}
+
+// MemoryStateStore keeps component state in memory, surviving restarts of
+// a System within the same process
+type MemoryStateStore struct {
+	states map[string][]byte
+	mu     sync.Mutex
+}
+
+// NewMemoryStateStore creates an empty in-memory state store
+func NewMemoryStateStore() *MemoryStateStore {
+	return &MemoryStateStore{states: make(map[string][]byte)}
+}
+
+// Save stores a copy of the state for a component key
+func (m *MemoryStateStore) Save(key string, state []byte) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.states[key] = append([]byte(nil), state...)
+	return nil
+}
+
+// Load returns a copy of the state for a component key
+func (m *MemoryStateStore) Load(key string) ([]byte, bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	state, ok := m.states[key]
+	if !ok {
+		return nil, false, nil
+	}
+	return append([]byte(nil), state...), true, nil
+}
+
+// FileStateStore keeps component state as one file per key in a directory,
+// surviving process restarts
+type FileStateStore struct {
+	dir string
+}
+
+// NewFileStateStore creates a state store backed by the given directory
+func NewFileStateStore(dir string) (*FileStateStore, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create state directory: %w", err)
+	}
+	return &FileStateStore{dir: dir}, nil
+}
+
+// Save writes the state for a component key
+func (f *FileStateStore) Save(key string, state []byte) error {
+	return os.WriteFile(f.path(key), state, 0o600)
+}
+
+// Load reads the state for a component key
+func (f *FileStateStore) Load(key string) ([]byte, bool, error) {
+	state, err := os.ReadFile(f.path(key))
+	if os.IsNotExist(err) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	return state, true, nil
+}
+
+func (f *FileStateStore) path(key string) string {
+	return filepath.Join(f.dir, url.PathEscape(key)+".state")
+}
+
+// loadState offers previously saved state to a component that accepts it
+func (s *System) loadState(component *Component) error {
+	loader, ok := component.instance.(StateLoader)
+	if !ok || s.stateStore == nil {
+		return nil
+	}
+
+	state, found, err := s.stateStore.Load(component.key)
+	if err != nil {
+		return fmt.Errorf("failed to load state: %w", err)
+	}
+	if !found {
+		return nil
+	}
+
+	if err := loader.LoadState(state); err != nil {
+		return fmt.Errorf("failed to restore state: %w", err)
+	}
+	return nil
+}
+
+// saveState collects the state of a running component into the store
+func (s *System) saveState(component *Component) error {
+	saver, ok := component.instance.(StateSaver)
+	if !ok || s.stateStore == nil || !component.IsStarted() {
+		return nil
+	}
+
+	state, err := saver.SaveState()
+	if err != nil {
+		return fmt.Errorf("failed to collect state: %w", err)
+	}
+
+	if err := s.stateStore.Save(component.key, state); err != nil {
+		return fmt.Errorf("failed to save state: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+package component
+
+import (
+	"testing"
+)
+
+// CacheComponent keeps expensive in-memory state across restarts
+type CacheComponent struct {
+	Entries string
+	Loaded  bool
+}
+
+func (c *CacheComponent) Start(ctx Context) (Lifecycle, error) {
+	if c.Entries == "" {
+		c.Entries = "warm"
+	}
+	return c, nil
+}
+
+func (c *CacheComponent) Stop(ctx Context) error {
+	c.Entries = ""
+	return nil
+}
+
+func (c *CacheComponent) SaveState() ([]byte, error) {
+	return []byte(c.Entries), nil
+}
+
+func (c *CacheComponent) LoadState(state []byte) error {
+	c.Entries = string(state)
+	c.Loaded = true
+	return nil
+}
+
+func TestStatePersistedAcrossRestarts(t *testing.T) {
+	store := NewMemoryStateStore()
+
+	first := &CacheComponent{}
+	system := CreateSystem(map[string]*Component{
+		"cache": Define("cache", first),
+	}, WithStateStore(store))
+	if err := system.Start(); err != nil {
+		t.Fatalf("Failed to start system: %v", err)
+	}
+	if first.Loaded {
+		t.Error("Expected no state to be loaded on first start")
+	}
+	if err := system.Stop(); err != nil {
+		t.Fatalf("Failed to stop system: %v", err)
+	}
+
+	second := &CacheComponent{}
+	system = CreateSystem(map[string]*Component{
+		"cache": Define("cache", second),
+	}, WithStateStore(store))
+	if err := system.Start(); err != nil {
+		t.Fatalf("Failed to start system: %v", err)
+	}
+	if !second.Loaded || second.Entries != "warm" {
+		t.Errorf("Expected warm state to be restored, got loaded=%v entries=%q", second.Loaded, second.Entries)
+	}
+}
+
+func TestFileStateStore(t *testing.T) {
+	store, err := NewFileStateStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("Failed to create store: %v", err)
+	}
+
+	if _, found, err := store.Load("handlers/a"); err != nil || found {
+		t.Fatalf("Expected no state, got found=%v err=%v", found, err)
+	}
+	if err := store.Save("handlers/a", []byte("state")); err != nil {
+		t.Fatalf("Failed to save state: %v", err)
+	}
+	state, found, err := store.Load("handlers/a")
+	if err != nil || !found || string(state) != "state" {
+		t.Errorf("Expected saved state, got %q found=%v err=%v", state, found, err)
+	}
+}
@@ -0,0 +1,145 @@
+package component
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ErrInvalidTransition is matched by every TransitionError
+var ErrInvalidTransition = errors.New("invalid state transition")
+
+// transitions lists the states each state may move to:
+//
+//	not_started → starting
+//	starting    → started, completed, failed
+//	started     → stopping, degraded
+//	degraded    → started, stopping
+//	stopping    → stopped, failed
+//	stopped     → starting
+//	completed   → starting
+//	failed      → starting, quarantined
+//	quarantined → stopped
+var transitions = map[State][]State{
+	StateNotStarted:  {StateStarting},
+	StateStarting:    {StateStarted, StateCompleted, StateFailed},
+	StateStarted:     {StateStopping, StateDegraded},
+	StateDegraded:    {StateStarted, StateStopping},
+	StateStopping:    {StateStopped, StateFailed},
+	StateStopped:     {StateStarting},
+	StateCompleted:   {StateStarting},
+	StateFailed:      {StateStarting, StateQuarantined},
+	StateQuarantined: {StateStopped},
+}
+
+// NextStates returns the states a component in the given state may move to
+func NextStates(from State) []State {
+	return append([]State(nil), transitions[from]...)
+}
+
+// CanTransition reports whether a component may move from one state to another
+func CanTransition(from, to State) bool {
+	for _, next := range transitions[from] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
+
+// TransitionError reports a state change the state machine does not allow
+type TransitionError struct {
+	Key  string
+	From State
+	To   State
+}
+
+func (e *TransitionError) Error() string {
+	return fmt.Sprintf("component %s cannot move from %s to %s", e.Key, e.From, e.To)
+}
+
+func (e *TransitionError) Is(target error) bool {
+	return target == ErrInvalidTransition
+}
+
+// Transition is one state change of a component
+type Transition struct {
+	Component string
+	From      State
+	To        State
+	Time      time.Time
+}
+
+// TransitionListener receives every state change of the components of a
+// system. It is called synchronously while the component changes state, so
+// it must not block nor call back into the component or the System
+type TransitionListener func(Transition)
+
+// WithTransitionListener registers a listener for component state changes
+func WithTransitionListener(listener TransitionListener) Option {
+	return func(s *System) {
+		s.transitions.listeners = append(s.transitions.listeners, listener)
+	}
+}
+
+// transitionListeners delivers the state changes of a system's components
+type transitionListeners struct {
+	listeners []TransitionListener
+	mu        sync.Mutex
+}
+
+// notify delivers a transition to every listener. Deliveries are
+// serialized, so listeners are never called concurrently
+func (t *transitionListeners) notify(transition Transition) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for _, listener := range t.listeners {
+		listener(transition)
+	}
+}
+
+// watchTransitions routes the state changes of the components to the
+// transition listeners, if any
+func (s *System) watchTransitions(components map[string]*Component) {
+	if len(s.transitions.listeners) == 0 {
+		return
+	}
+	for _, component := range components {
+		if component != nil {
+			component.watcher.Store(&s.transitions)
+		}
+	}
+}
+
+// transition moves the component to a new state, failing with a
+// TransitionError if the state machine does not allow it from the current
+// state. The change is atomic, so concurrent transitions cannot both apply
+// from the same state
+func (c *Component) transition(to State) error {
+	for {
+		from := c.State()
+		if !CanTransition(from, to) {
+			return &TransitionError{Key: c.key, From: from, To: to}
+		}
+		if c.state.CompareAndSwap(int32(from), int32(to)) {
+			c.recordTransition(from, to)
+			return nil
+		}
+	}
+}
+
+// recordTransition stamps a state change and notifies the listeners
+func (c *Component) recordTransition(from, to State) {
+	now := time.Now()
+	c.since.Store(now.UnixNano())
+	switch {
+	case from == StateStarting && (to == StateStarted || to == StateCompleted):
+		c.startedAt.Store(now.UnixNano())
+	case to == StateStopped:
+		c.stoppedAt.Store(now.UnixNano())
+	}
+	if watcher := c.watcher.Load(); watcher != nil {
+		watcher.notify(Transition{Component: c.key, From: from, To: to, Time: now})
+	}
+}
@@ -0,0 +1,110 @@
+package component
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+func TestTransitionListener(t *testing.T) {
+	var transitions []string
+	system := CreateSystem(map[string]*Component{
+		"db":  Define("db", &MockComponent{}),
+		"api": Define("api", &MockComponent{StopError: errors.New("stuck")}, "db"),
+	}, WithQuarantineThreshold(1), WithTransitionListener(func(transition Transition) {
+		transitions = append(transitions, fmt.Sprintf("%s %s->%s", transition.Component, transition.From, transition.To))
+	}))
+
+	if err := system.Start(); err != nil {
+		t.Fatalf("Failed to start system: %v", err)
+	}
+	if err := system.Degrade("db", errors.New("slow")); err != nil {
+		t.Fatalf("Failed to degrade db: %v", err)
+	}
+	system.Stop()
+	if err := system.ClearQuarantine("api"); err != nil {
+		t.Fatalf("Failed to clear the quarantine: %v", err)
+	}
+
+	want := []string{
+		"db not_started->starting", "db starting->started",
+		"api not_started->starting", "api starting->started",
+		"db started->degraded",
+		"api started->stopping", "api stopping->failed", "api failed->quarantined",
+		"db degraded->stopping", "db stopping->stopped",
+		"api quarantined->stopped",
+	}
+	if !reflect.DeepEqual(transitions, want) {
+		t.Errorf("Expected transitions %v, got %v", want, transitions)
+	}
+}
+
+func TestInvalidTransition(t *testing.T) {
+	comp := Define("db", &MockComponent{})
+
+	err := comp.transition(StateStarted)
+	var transitionErr *TransitionError
+	if !errors.As(err, &transitionErr) || !errors.Is(err, ErrInvalidTransition) {
+		t.Fatalf("Expected a TransitionError, got %v", err)
+	}
+	if transitionErr.From != StateNotStarted || transitionErr.To != StateStarted || comp.State() != StateNotStarted {
+		t.Errorf("Expected the state left unchanged, got %v in state %s", err, comp.State())
+	}
+	if want := "component db cannot move from not_started to started"; err.Error() != want {
+		t.Errorf("Expected %q, got %q", want, err.Error())
+	}
+
+	// A quarantined component refuses to start until it is cleared
+	comp.state.Store(int32(StateQuarantined))
+	if _, err := comp.Start(Context{}); !errors.Is(err, ErrInvalidTransition) {
+		t.Errorf("Expected a quarantined component not to start, got %v", err)
+	}
+}
+
+func TestConcurrentTransitions(t *testing.T) {
+	comp := Define("db", &MockComponent{})
+	if _, err := comp.Start(Context{}); err != nil {
+		t.Fatalf("Failed to start component: %v", err)
+	}
+
+	// Only one of the racing moves from started to degraded may apply
+	var wg sync.WaitGroup
+	results := make(chan error, 8)
+	for range cap(results) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results <- comp.transition(StateDegraded)
+		}()
+	}
+	wg.Wait()
+	close(results)
+
+	var applied int
+	for err := range results {
+		if err == nil {
+			applied++
+		}
+	}
+	if applied != 1 {
+		t.Errorf("Expected exactly one transition to apply, got %d", applied)
+	}
+}
+
+func TestNextStates(t *testing.T) {
+	for from := StateNotStarted; from <= StateQuarantined; from++ {
+		if len(NextStates(from)) == 0 {
+			t.Errorf("Expected %s to have a way out", from)
+		}
+		for _, to := range NextStates(from) {
+			if !CanTransition(from, to) {
+				t.Errorf("Expected %s->%s to be allowed", from, to)
+			}
+		}
+	}
+	if CanTransition(StateStopped, StateStarted) {
+		t.Error("Expected a stopped component to go through starting")
+	}
+}
@@ -0,0 +1,147 @@
+package component
+
+import (
+	"fmt"
+	"time"
+)
+
+// State is the lifecycle state of a component
+type State int32
+
+const (
+	StateNotStarted State = iota
+	StateStarting
+	StateStarted
+	StateFailed
+	StateStopping
+	StateStopped
+
+	// StateDegraded is a started component that reported reduced service
+	StateDegraded
+
+	// StateCompleted is a one-shot component that finished its work
+	StateCompleted
+
+	// StateQuarantined is a component that failed to stop repeatedly and is
+	// not started again until its quarantine is cleared
+	StateQuarantined
+)
+
+func (s State) String() string {
+	switch s {
+	case StateNotStarted:
+		return "not_started"
+	case StateStarting:
+		return "starting"
+	case StateStarted:
+		return "started"
+	case StateFailed:
+		return "failed"
+	case StateStopping:
+		return "stopping"
+	case StateStopped:
+		return "stopped"
+	case StateDegraded:
+		return "degraded"
+	case StateCompleted:
+		return "completed"
+	case StateQuarantined:
+		return "quarantined"
+	default:
+		return fmt.Sprintf("state(%d)", int32(s))
+	}
+}
+
+// MarshalText encodes the state by name
+func (s State) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// UnmarshalText decodes a state name written by MarshalText
+func (s *State) UnmarshalText(text []byte) error {
+	for state := StateNotStarted; state <= StateQuarantined; state++ {
+		if state.String() == string(text) {
+			*s = state
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown state %q", text)
+}
+
+// State returns the current lifecycle state of the component. It never
+// blocks, so it is safe to call from hot paths such as health endpoints
+func (c *Component) State() State {
+	return State(c.state.Load())
+}
+
+// LastError returns the error behind the current state, such as the start
+// failure of a failed component or the reason given to Degrade
+func (c *Component) LastError() error {
+	if err := c.lastErr.Load(); err != nil {
+		return *err
+	}
+	return nil
+}
+
+// setLastErr records the error behind the current state
+func (c *Component) setLastErr(err error) {
+	if err == nil {
+		c.lastErr.Store(nil)
+		return
+	}
+	c.lastErr.Store(&err)
+}
+
+// IsStarted reports whether the system has started and not yet stopped.
+// Like Component.State it never blocks on an in-flight Start or Stop
+func (s *System) IsStarted() bool {
+	return s.started.Load()
+}
+
+// ComponentStatus is the lifecycle status of one component
+type ComponentStatus struct {
+	State State
+
+	// LastError is the error behind State, as returned by LastError
+	LastError error
+
+	// Since is when the component entered State. StartedAt and StoppedAt
+	// are its last successful start and last stop; all three are zero
+	// until they first happen
+	Since     time.Time
+	StartedAt time.Time
+	StoppedAt time.Time
+}
+
+// Status returns the lifecycle status of the component. Like State it
+// never blocks
+func (c *Component) Status() ComponentStatus {
+	return ComponentStatus{
+		State:     c.State(),
+		LastError: c.LastError(),
+		Since:     unixTime(c.since.Load()),
+		StartedAt: unixTime(c.startedAt.Load()),
+		StoppedAt: unixTime(c.stoppedAt.Load()),
+	}
+}
+
+// Status returns the lifecycle status of every component by key, without
+// waiting for an in-flight Start or Stop
+func (s *System) Status() map[string]ComponentStatus {
+	components := s.snapshot().components
+	status := make(map[string]ComponentStatus, len(components))
+	for key, component := range components {
+		if component != nil {
+			status[key] = component.Status()
+		}
+	}
+	return status
+}
+
+// unixTime converts nanoseconds since the epoch, zero meaning never
+func unixTime(nanos int64) time.Time {
+	if nanos == 0 {
+		return time.Time{}
+	}
+	return time.Unix(0, nanos)
+}
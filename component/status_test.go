@@ -0,0 +1,109 @@
+package component
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestComponentStateTransitions(t *testing.T) {
+	comp := Define("compA", &MockComponent{})
+	if comp.State() != StateNotStarted {
+		t.Errorf("Expected not_started, got %s", comp.State())
+	}
+
+	system := CreateSystem(map[string]*Component{"compA": comp})
+	if err := system.Start(); err != nil {
+		t.Fatalf("Failed to start system: %v", err)
+	}
+	if comp.State() != StateStarted || !system.IsStarted() {
+		t.Errorf("Expected started, got %s", comp.State())
+	}
+	if comp.Result() == nil {
+		t.Error("Expected the result of the started component")
+	}
+
+	if err := system.Stop(); err != nil {
+		t.Fatalf("Failed to stop system: %v", err)
+	}
+	if comp.State() != StateStopped || system.IsStarted() {
+		t.Errorf("Expected stopped, got %s", comp.State())
+	}
+	if result := comp.Result(); result != nil {
+		t.Errorf("Expected no result once stopped, got %v", result)
+	}
+}
+
+func TestComponentStateFailed(t *testing.T) {
+	comp := Define("compA", &MockComponent{StartError: errors.New("boom")})
+	system := CreateSystem(map[string]*Component{"compA": comp})
+
+	if err := system.Start(); err == nil {
+		t.Fatal("Expected start to fail")
+	}
+	if comp.State() != StateFailed {
+		t.Errorf("Expected failed, got %s", comp.State())
+	}
+}
+
+func TestSystemStatus(t *testing.T) {
+	failure := errors.New("boom")
+	system := CreateSystem(map[string]*Component{
+		"db":    Define("db", &MockComponent{}),
+		"api":   Define("api", &MockComponent{StartError: failure}, "db"),
+		"proxy": Define("proxy", &MockComponent{}, "api"),
+	})
+	before := time.Now()
+	if err := system.Start(); err == nil {
+		t.Fatal("Expected start to fail")
+	}
+
+	status := system.Status()
+	if db := status["db"]; db.State != StateStarted || db.LastError != nil || db.StartedAt.Before(before) || db.Since != db.StartedAt {
+		t.Errorf("Expected db started since its start, got %+v", db)
+	}
+	if api := status["api"]; api.State != StateFailed || !errors.Is(api.LastError, failure) || !api.StartedAt.IsZero() {
+		t.Errorf("Expected api failed with its start error, got %+v", api)
+	}
+	if proxy := status["proxy"]; proxy != (ComponentStatus{State: StateNotStarted}) {
+		t.Errorf("Expected proxy never started, got %+v", proxy)
+	}
+
+	if err := system.Stop(); err != nil {
+		t.Fatalf("Failed to stop system: %v", err)
+	}
+	if db := system.Status()["db"]; db.State != StateStopped || db.StoppedAt.Before(db.StartedAt) || db.Since != db.StoppedAt {
+		t.Errorf("Expected db stopped after its start, got %+v", db)
+	}
+}
+
+func BenchmarkComponentState(b *testing.B) {
+	comp := Define("compA", &MockComponent{})
+
+	// Hold the lifecycle mutex as an in-flight Start or Stop would
+	comp.mu.Lock()
+	defer comp.mu.Unlock()
+
+	b.ReportAllocs()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			_ = comp.State()
+			_ = comp.IsStarted()
+		}
+	})
+}
+
+func BenchmarkSystemIsStarted(b *testing.B) {
+	system := CreateSystem(map[string]*Component{})
+
+	// Hold the lifecycle mutex as an in-flight Start or Stop would
+	system.mu.Lock()
+	defer system.mu.Unlock()
+
+	b.ReportAllocs()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			_ = system.IsStarted()
+		}
+	})
+}
@@ -0,0 +1,132 @@
+package component
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// StopDeadlineContextKey holds the stop deadline of a component in the
+// Context passed to Stop
+const StopDeadlineContextKey = ReservedPrefix + "stop_deadline"
+
+// minStopWeight is the smallest share weight of a component, so components
+// that stopped instantly before still get some time
+const minStopWeight = time.Millisecond
+
+// ErrStopDeadlineExceeded is reported for a component whose Stop did not
+// return within its share of the stop deadline
+var ErrStopDeadlineExceeded = errors.New("stop deadline exceeded")
+
+// WithStopDeadline bounds a system Stop to d. The time left when a component
+// stops is shared among the components still running in proportion to their
+// last stop durations, so a slow component early in the order cannot use up
+// the time of the components behind it. A Stop overrunning its share is
+// abandoned and reported with ErrStopDeadlineExceeded; components without a
+// recorded stop get the average share
+func WithStopDeadline(d time.Duration) Option {
+	return func(s *System) {
+		s.stopTimeout = d
+	}
+}
+
+// stopDeadlineHolder carries a stop deadline in a Context
+type stopDeadlineHolder struct {
+	deadline time.Time
+}
+
+func (h *stopDeadlineHolder) Start(ctx Context) (Lifecycle, error) {
+	return h, nil
+}
+
+func (h *stopDeadlineHolder) Stop(ctx Context) error {
+	return nil
+}
+
+// StopDeadline returns when the component's Stop must have returned, if the
+// system stops within a deadline
+func (ctx Context) StopDeadline() (time.Time, bool) {
+	if holder, ok := ctx[StopDeadlineContextKey].(*stopDeadlineHolder); ok {
+		return holder.deadline, true
+	}
+	return time.Time{}, false
+}
+
+// stopShare returns the part of the time left that the component may spend
+// stopping, weighted by the last stop durations of the components still
+// running
+func (s *System) stopShare(component *Component) time.Duration {
+	remaining := time.Until(s.stopDeadline)
+	if remaining <= 0 {
+		return 0
+	}
+
+	s.shared.Lock()
+	defer s.shared.Unlock()
+
+	var known, count time.Duration
+	for _, timings := range s.timings {
+		if timings.stop > 0 {
+			known += timings.stop
+			count++
+		}
+	}
+	fallback := minStopWeight
+	if count > 0 {
+		fallback = known / count
+	}
+	weight := func(key string) time.Duration {
+		w := fallback
+		if recorded := s.timings[key].stop; recorded > 0 {
+			w = recorded
+		}
+		return max(w, minStopWeight)
+	}
+
+	total := weight(component.key)
+	for _, key := range s.startOrder {
+		if other := s.components[key]; key != component.key && other.IsStarted() {
+			total += weight(key)
+		}
+	}
+	return time.Duration(float64(remaining) * float64(weight(component.key)) / float64(total))
+}
+
+// stopWithin stops the component, giving up waiting once its share of the
+// stop deadline elapsed. An abandoned Stop still records its duration when
+// it returns, so the next shutdown gives the component more time
+func (s *System) stopWithin(component *Component, op operation) error {
+	share := max(min(s.stopShare(component)+s.stopGrace[component.key], time.Until(s.stopDeadline)), 0)
+	deadline := time.Now().Add(share)
+
+	s.shared.Lock()
+	ctx := make(Context, len(s.context)+1)
+	for key, value := range s.context {
+		ctx[key] = value
+	}
+	s.shared.Unlock()
+	ctx[StopDeadlineContextKey] = &stopDeadlineHolder{deadline: deadline}
+
+	done := make(chan error, 1)
+	stopTime := time.Now()
+	go func() {
+		done <- component.stop(ctx, op)
+	}()
+
+	timer := time.NewTimer(share)
+	defer timer.Stop()
+	select {
+	case err := <-done:
+		return err
+	case <-timer.C:
+	}
+
+	go func() {
+		if err := <-done; err == nil {
+			s.shared.Lock()
+			s.recordTiming(component, OpStop, time.Since(stopTime))
+			s.shared.Unlock()
+		}
+	}()
+	return fmt.Errorf("%w: stop did not return within its %v share", ErrStopDeadlineExceeded, share.Round(time.Millisecond))
+}
@@ -0,0 +1,103 @@
+package component
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+// HangingStopper blocks in Stop until released, recording its stop deadline
+type HangingStopper struct {
+	MockComponent
+	Release  chan struct{}
+	Deadline time.Time
+}
+
+func (h *HangingStopper) Stop(ctx Context) error {
+	h.Deadline, _ = ctx.StopDeadline()
+	if h.Release != nil {
+		<-h.Release
+	}
+	return h.MockComponent.Stop(ctx)
+}
+
+func TestStopDeadlineSharedByHistory(t *testing.T) {
+	slow := &HangingStopper{Release: make(chan struct{})}
+	defer close(slow.Release)
+	fastA := &HangingStopper{}
+	fastB := &HangingStopper{}
+
+	// c_slow stops first and would starve the others without a share
+	system := CreateSystem(map[string]*Component{
+		"a_fast": Define("a_fast", fastA),
+		"b_fast": Define("b_fast", fastB),
+		"c_slow": Define("c_slow", slow),
+	}, WithStopDeadline(400*time.Millisecond))
+	if err := system.Start(); err != nil {
+		t.Fatalf("Expected start to succeed, got %v", err)
+	}
+	system.timings = map[string]componentTimings{
+		"a_fast": {stop: 50 * time.Millisecond},
+		"b_fast": {stop: 50 * time.Millisecond},
+		"c_slow": {stop: 300 * time.Millisecond},
+	}
+
+	begin := time.Now()
+	err := system.Stop()
+	elapsed := time.Since(begin)
+
+	var componentErr *ComponentError
+	if !errors.As(err, &componentErr) || componentErr.Key != "c_slow" || !errors.Is(err, ErrStopDeadlineExceeded) {
+		t.Fatalf("Expected c_slow to exceed its share, got %v", err)
+	}
+	if elapsed < 250*time.Millisecond || elapsed > 400*time.Millisecond {
+		t.Errorf("Expected c_slow to be abandoned after about 300ms, took %v", elapsed)
+	}
+	if !fastA.StopCalled || !fastB.StopCalled {
+		t.Error("Expected the fast components to stop within the time left")
+	}
+	if fastA.Deadline.IsZero() || fastA.Deadline.Before(begin) {
+		t.Errorf("Expected a_fast to see its stop deadline, got %v", fastA.Deadline)
+	}
+}
+
+func TestStopDeadlineRecordsAbandonedStop(t *testing.T) {
+	hanging := &HangingStopper{Release: make(chan struct{})}
+	system := CreateSystem(map[string]*Component{
+		"worker": Define("worker", hanging),
+	}, WithStopDeadline(20*time.Millisecond))
+	if err := system.Start(); err != nil {
+		t.Fatalf("Expected start to succeed, got %v", err)
+	}
+
+	if err := system.Stop(); !errors.Is(err, ErrStopDeadlineExceeded) {
+		t.Fatalf("Expected the stop deadline to be exceeded, got %v", err)
+	}
+	time.Sleep(30 * time.Millisecond)
+	close(hanging.Release)
+
+	for i := 0; i < 100; i++ {
+		system.shared.Lock()
+		recorded := system.timings["worker"].stop
+		system.shared.Unlock()
+		if recorded >= 50*time.Millisecond {
+			return
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	t.Error("Expected the abandoned stop to record its full duration")
+}
+
+func TestStopDeadlineAbsent(t *testing.T) {
+	stopper := &HangingStopper{}
+	system := CreateSystem(map[string]*Component{"worker": Define("worker", stopper)})
+	if err := system.Start(); err != nil {
+		t.Fatalf("Expected start to succeed, got %v", err)
+	}
+	if err := system.Stop(); err != nil {
+		t.Fatalf("Expected stop to succeed, got %v", err)
+	}
+	if !stopper.Deadline.IsZero() {
+		t.Errorf("Expected no stop deadline, got %v", stopper.Deadline)
+	}
+}
@@ -0,0 +1,66 @@
+package component
+
+import (
+	"errors"
+	"fmt"
+)
+
+// WithStrict fails validation when a component's instance does not
+// implement the capabilities declared with Requires. Interfaces expected of
+// Start results, such as MultiProvider for Provides, are checked once the
+// component started
+func WithStrict() Option {
+	return func(s *System) {
+		s.strict = true
+	}
+}
+
+// Requires declares capabilities the component's instance must implement.
+// Systems created WithStrict refuse to start when one is missing
+func (c *Component) Requires(capabilities ...Capability) *Component {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.requires = append(c.requires, capabilities...)
+	return c
+}
+
+// requiredCapabilities returns the capabilities declared with Requires
+func (c *Component) requiredCapabilities() []Capability {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]Capability(nil), c.requires...)
+}
+
+// checkCapabilities verifies in strict mode that every component implements
+// the capabilities it requires, reporting all mismatches
+func (s *System) checkCapabilities() error {
+	if !s.strict {
+		return nil
+	}
+
+	var errs []error
+	for _, name := range s.sortedKeys() {
+		component := s.components[name]
+		for _, capability := range component.requiredCapabilities() {
+			if !implements(component.instance, capability) {
+				pkg, typ := component.instanceType()
+				errs = append(errs, &ComponentError{
+					Key: name,
+					Op:  OpValidate,
+					Err: fmt.Errorf("capability %s required but not implemented by %s.%s", capability, pkg, typ),
+				})
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// implements reports whether a Lifecycle implements a capability
+func implements(l Lifecycle, capability Capability) bool {
+	for _, check := range capabilityChecks {
+		if check.capability == capability {
+			return check.implements(l)
+		}
+	}
+	return false
+}
@@ -0,0 +1,47 @@
+package component
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestStrictMode(t *testing.T) {
+	silenceTestStdout(t)
+
+	components := func() map[string]*Component {
+		return map[string]*Component{
+			"cache":   Define("cache", &MockComponent{}).Requires(CapabilityStateSaver),
+			"pool":    Define("pool", &ConnectionFactory{}).Provides("db.read"),
+			"session": Define("session", &CapableComponent{}).Requires(CapabilityStateSaver, CapabilityReasonStopper),
+		}
+	}
+
+	err := CreateSystem(components(), WithStrict()).Start()
+	var componentErr *ComponentError
+	if !errors.As(err, &componentErr) || componentErr.Op != OpValidate {
+		t.Fatalf("Expected a validation error, got %v", err)
+	}
+	for _, want := range []string{
+		"capability state_saver required but not implemented by github.com/leandroolgomes/golang-dependency-graph/component.MockComponent for component cache",
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("Expected %q in:\n%v", want, err)
+		}
+	}
+	if strings.Contains(err.Error(), "session") {
+		t.Errorf("Expected session to satisfy its requirements, got %v", err)
+	}
+	if strings.Contains(err.Error(), "pool") {
+		t.Errorf("Expected a factory whose result provides its keys to pass, got %v", err)
+	}
+
+	if err := CreateSystem(components()).Start(); err != nil {
+		t.Errorf("Expected requirements to be ignored outside strict mode, got %v", err)
+	}
+
+	pool := map[string]*Component{"pool": Define("pool", &ConnectionFactory{}).Provides("db.read")}
+	if err := CreateSystem(pool, WithStrict()).Start(); err != nil {
+		t.Errorf("Expected the factory to start in strict mode, got %v", err)
+	}
+}
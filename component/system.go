@@ -1,176 +1,622 @@
 package component
 
 import (
+	"context"
 	"fmt"
+	"regexp"
 	"sort"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
 // System manages all components and their lifecycle
 type System struct {
 	components map[string]*Component
-	started    bool
+	options    []Option
+	started    atomic.Bool
 	context    Context
-	mu         sync.Mutex
+	stateStore StateStore
+	providers  map[string]string
+	listeners  []EventListener
+	gates      []gateRule
+	catalog    Catalog
+	logger     Logger
+	keyNaming  *regexp.Regexp
+	ctxStats   ContextStats
+
+	// reuseContexts makes each component reuse its dependency context
+	// map across starts
+	reuseContexts bool
+
+	startOrder []string
+	timings    map[string]componentTimings
+
+	// report is the StartupReport of the last Start, with the batch of
+	// each planned component while it runs, guarded by shared
+	report      StartupReport
+	reportBatch map[string]int
+
+	graph atomic.Pointer[graphSnapshot]
+
+	// startCtx bounds the Start in progress and inFlight holds the
+	// components it is starting, guarded by shared
+	startCtx context.Context
+	inFlight map[string]bool
+
+	tracer *runtimeTracer
+	spans  lifecycleSpans
+
+	// backpressure holds the signal of each component's current Start,
+	// guarded by shared
+	backpressure      map[string]*Backpressure
+	backpressureGrace time.Duration
+
+	// stopDeadline is when the Stop in progress must be done, if
+	// stopTimeout is set
+	stopTimeout  time.Duration
+	stopDeadline time.Time
+
+	// shutdownStages split Stop into stages; stopGrace holds the extra
+	// stop time of the components of the stage in progress
+	shutdownStages []ShutdownStage
+	stopGrace      map[string]time.Duration
+
+	// runtime overrides option settings; skipped holds the components the
+	// last Start left out because they, or a dependency, were disabled
+	runtime      runtimeSettings
+	runtimeStore RuntimeConfigStore
+	skipped      map[string]bool
+
+	desiredStore DesiredStateStore
+	drift        []Drift
+
+	group            *RunGroup
+	shutdownRequests chan ShutdownReason
+	groupMu          sync.Mutex
+
+	subscribers dependencySubscribers
+	observers   observers
+
+	preflight        []PreflightCheck
+	preflightTimeout time.Duration
+	prefetchTimeout  time.Duration
+
+	healthCheckTimeout time.Duration
+	healthInterval     time.Duration
+	healthPolicy       HealthPolicy
+	monitor            *healthMonitor
+
+	postStartHooks []systemHook
+	preStopHooks   []systemHook
+
+	guardStore DesiredStateStore
+	guardForce bool
+
+	quarantineThreshold int
+
+	transitions transitionListeners
+
+	// provides holds the result types declared with WithProvides
+	provides map[string]any
+
+	// slowStart is the Start duration past which a component's stack is
+	// sampled every slowStartInterval
+	slowStart         time.Duration
+	slowStartInterval time.Duration
+
+	strict        bool
+	parallelStart int
+	parallelStop  int
+	executor      Executor
+
+	// shared guards the context and start bookkeeping while components
+	// start concurrently; s.mu is held around the whole operation
+	shared sync.Mutex
+	emitMu sync.Mutex
+
+	mu sync.Mutex
 }
 
 // CreateSystem initializes a new system with the provided components
-func CreateSystem(components map[string]*Component) *System {
-	return &System{
+func CreateSystem(components map[string]*Component, opts ...Option) *System {
+	s := &System{
 		components: components,
-		started:    false,
+		options:    opts,
 		context:    make(Context),
 	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	s.watchTransitions(components)
+	return s
 }
 
-// Start initializes all components in dependency order
+// Start initializes all components in dependency order. A panic in a
+// component's Start fails it with a PanicError and stops the components
+// already started
 func (s *System) Start() error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
+	return s.startLocked()
+}
 
-	if s.started {
+// startLocked starts the system; the caller must hold s.mu
+func (s *System) startLocked() error {
+	if s.started.Load() {
 		return nil
 	}
 
+	correlationID := newCorrelationID()
 	systemStartTime := time.Now()
+	s.beginReport(correlationID, systemStartTime)
+	s.emit(Event{Type: EventSystemStarting, CorrelationID: correlationID})
 
-	// Check for cyclic dependencies
-	if err := s.checkCyclicDependencies(); err != nil {
+	err := s.startAll(correlationID)
+
+	// Check the results against the types declared with WithProvides
+	if err == nil {
+		err = s.checkProvides()
+	}
+
+	// Compare what the previous run intended with what this one reached
+	if reconcileErr := s.reconcile(correlationID); err == nil {
+		err = reconcileErr
+	}
+	if err == nil {
+		err = s.saveGoodTopology()
+	}
+
+	systemElapsedTime := time.Since(systemStartTime)
+	s.finishReport(systemElapsedTime, err)
+	s.emit(Event{Type: EventSystemStarted, CorrelationID: correlationID, Duration: systemElapsedTime, Err: err})
+	if err != nil {
+		s.rollbackPanic(err)
+		return correlate(correlationID, err)
+	}
+
+	s.Logger().Info(s.Catalog().Message(MsgSystemStarted, systemElapsedTime), "correlation_id", correlationID)
+
+	s.started.Store(true)
+	s.startHealthMonitor()
+	s.runHooks(s.postStartHooks, correlationID, nil)
+	return nil
+}
+
+// startAll validates the graph and starts every component in order
+func (s *System) startAll(correlationID string) error {
+	// Refuse topologies that broke since the last good start
+	if err := s.guardTopology(correlationID); err != nil {
 		return err
 	}
 
-	// Get components in order of dependencies
-	orderedComponents, err := s.getOrderedComponents()
+	orderedComponents, err := s.validate()
 	if err != nil {
 		return err
 	}
 
-	// Start components in order
-	for _, name := range orderedComponents {
-		component := s.components[name]
-		
-		// Create context with dependencies
-		ctx := make(Context)
-		for _, dep := range component.GetDependencies() {
-			depComponent, exists := s.components[dep]
-			if !exists {
-				return fmt.Errorf("dependency %s not found for component %s", dep, name)
-			}
-			
-			if !depComponent.IsStarted() {
-				return fmt.Errorf("dependency %s not started for component %s", dep, name)
-			}
-			
-			ctx[dep] = depComponent.instance
-		}
-		
-		// Start the component
-		lifecycle, err := component.Start(ctx)
-		if err != nil {
-			return fmt.Errorf("failed to start component %s: %w", name, err)
+	// Apply the runtime configuration saved by a previous run
+	if err := s.loadRuntimeConfig(); err != nil {
+		return err
+	}
+	orderedComponents = s.withoutDisabled(orderedComponents)
+	s.publishGraph(orderedComponents)
+
+	// Verify the environment before anything starts
+	if err := s.runPreflight(correlationID); err != nil {
+		return err
+	}
+
+	// Warm up remote resources in parallel before the ordered start
+	if err := s.runPrefetch(orderedComponents, correlationID); err != nil {
+		return err
+	}
+
+	// Start components in order, recording the order they actually started in
+	s.startOrder = s.startOrder[:0]
+	plan, executor := s.startPlan(orderedComponents), s.startExecutor()
+	s.reportPlan(plan, executor)
+	return executor.Execute(plan, func(key string) error {
+		if err := s.bootContext().Err(); err != nil {
+			return err
 		}
-		
-		// Store the lifecycle instance in system context
-		s.context[name] = lifecycle
+		return s.startComponent(s.components[key], correlationID)
+	})
+}
+
+// startComponent builds the dependency context and starts one component
+func (s *System) startComponent(component *Component, correlationID string) error {
+	name := component.key
+	if component.State() == StateQuarantined {
+		return &ComponentError{Key: name, Op: OpStart, Err: ErrQuarantined}
 	}
-	
-	systemElapsedTime := time.Since(systemStartTime)
-	fmt.Printf("Total system initialization time: %v\n", systemElapsedTime)
-	
-	s.started = true
+	s.markInFlight(name, true)
+	defer s.markInFlight(name, false)
+
+	ctx, err := s.startContext(component)
+	if err != nil {
+		return err
+	}
+
+	s.emit(componentEvent(EventComponentStarting, component, correlationID))
+	startTime := time.Now()
+
+	err = s.runStart(component, ctx, correlationID)
+	component.setLastErr(err)
+
+	event := componentEvent(EventComponentStarted, component, correlationID)
+	event.Duration = time.Since(startTime)
+	if err != nil {
+		event.Type = EventComponentFailed
+		event.Err = err
+	} else if component.IsCompleted() {
+		event.Type = EventComponentCompleted
+	}
+
+	// Record the start together with its event so the effective order
+	// matches what listeners observed, even when starting in parallel
+	s.shared.Lock()
+	defer s.shared.Unlock()
+	s.emit(event)
+	s.reportComponent(component, startTime, event)
+
+	if err != nil {
+		return &ComponentError{Key: name, Op: OpStart, Err: err}
+	}
+	component.memoStart(ctx, s.dependencyKeys(component))
+	s.recordTiming(component, OpStart, event.Duration)
+	s.recordStarted(name)
 	return nil
 }
 
-// Stop gracefully shuts down all components in reverse dependency order
+// startContext builds the context of a component from the results of its
+// dependencies
+func (s *System) startContext(component *Component) (Context, error) {
+	s.shared.Lock()
+	defer s.shared.Unlock()
+
+	deps := s.dependencyKeys(component)
+	ctx := s.dependencyContext(component, len(deps))
+	for _, dep := range deps {
+		provider, exists := s.resolve(dep)
+		if !exists {
+			return nil, &ComponentError{Key: component.key, Op: OpResolve, Err: &MissingDependencyError{Key: component.key, Dependency: dep}}
+		}
+
+		if !s.components[provider].satisfied() {
+			return nil, &ComponentError{Key: component.key, Op: OpResolve, Err: fmt.Errorf("dependency %s not started", dep)}
+		}
+
+		ctx[dep] = s.context[dep]
+	}
+	aliasContext(component, deps, ctx)
+	s.injectReserved(component, ctx)
+	if aware, ok := component.instance.(systemAware); ok {
+		aware.attach(s, component)
+	}
+	return ctx, nil
+}
+
+// recordStarted appends a component to the effective start order unless it
+// is already there, as for a rerun one-shot; the caller must hold s.shared
+func (s *System) recordStarted(name string) {
+	for _, started := range s.startOrder {
+		if started == name {
+			return
+		}
+	}
+	s.startOrder = append(s.startOrder, name)
+}
+
+// runStart waits for gates, restores state, starts the component and publishes its results
+func (s *System) runStart(component *Component, ctx Context, correlationID string) error {
+	// Wait for external conditions gating the component
+	if err := s.waitGates(component, correlationID); err != nil {
+		return err
+	}
+
+	// Offer state saved by a previous run
+	if err := s.loadState(component); err != nil {
+		return err
+	}
+
+	// Start the component
+	endSampling := s.sampleStart(component, correlationID)
+	lifecycle, err := s.startWithRetry(component, ctx, correlationID)
+	endSampling()
+	if err != nil {
+		return err
+	}
+
+	// Store the lifecycle instance in system context
+	s.shared.Lock()
+	defer s.shared.Unlock()
+	return s.publish(component, lifecycle)
+}
+
+// Stop gracefully shuts down all components in reverse dependency order,
+// in the batches ShutdownOrder reports, returning every component stop
+// failure joined. After a failed Start it unwinds only the components that
+// actually started
 func (s *System) Stop() error {
+	return s.StopWithReason(ShutdownReason{Cause: ShutdownAPI})
+}
+
+// StopWithReason shuts down all components like Stop, passing the reason to
+// components implementing ReasonStopper and to stop events
+func (s *System) StopWithReason(reason ShutdownReason) error {
+	s.drainRunGroup()
+
 	s.mu.Lock()
 	defer s.mu.Unlock()
+	return s.stopLocked(reason)
+}
+
+// Restart stops and starts the whole system as a single operation, so no
+// other lifecycle call can interleave between the two
+func (s *System) Restart() error {
+	s.drainRunGroup()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.stopLocked(ShutdownReason{Cause: ShutdownAPI, Detail: "restart"}); err != nil {
+		return err
+	}
+	return s.startLocked()
+}
 
-	if !s.started {
+// stopLocked stops the system; the caller must hold s.mu
+func (s *System) stopLocked(reason ShutdownReason) error {
+	started := s.started.Load()
+	if !started && !s.partiallyStarted() {
 		return nil
 	}
 
-	// Get components in order of dependencies
-	orderedComponents, err := s.getOrderedComponents()
-	if err != nil {
-		return err
+	correlationID := newCorrelationID()
+	stopTime := time.Now()
+	s.emit(Event{Type: EventSystemStopping, CorrelationID: correlationID, Reason: &reason})
+	if stopTimeout := s.effectiveRuntime().StopDeadline; stopTimeout > 0 {
+		s.stopDeadline = stopTime.Add(stopTimeout)
+		defer func() { s.stopDeadline = time.Time{} }()
+	}
+	s.stopHealthMonitor()
+	if started {
+		s.runHooks(s.preStopHooks, correlationID, &reason)
+	}
+	s.applyBackpressure(correlationID, reason)
+
+	err := s.stopAll(correlationID, reason)
+
+	s.emit(Event{Type: EventSystemStopped, CorrelationID: correlationID, Duration: time.Since(stopTime), Err: err, Reason: &reason})
+	return correlate(correlationID, err)
+}
+
+// stopAll stops every started component in the batches of ShutdownOrder
+func (s *System) stopAll(correlationID string, reason ShutdownReason) error {
+	defer s.started.Store(false)
+
+	// Components keep stopping past failures
+	return s.stopStages(s.stopPlan(), func(key string) error {
+		if err := s.stopComponent(s.components[key], correlationID, reason); err != nil {
+			return &ComponentError{Key: key, Op: OpStop, Err: err}
+		}
+		return nil
+	})
+}
+
+// partiallyStarted reports whether a failed Start left components running;
+// the caller must hold s.mu
+func (s *System) partiallyStarted() bool {
+	for _, key := range s.startOrder {
+		if s.components[key].IsStarted() {
+			return true
+		}
+	}
+	return false
+}
+
+// stopComponent saves the state of one component and stops it
+func (s *System) stopComponent(component *Component, correlationID string, reason ShutdownReason) error {
+	if !component.IsStarted() {
+		return nil
+	}
+
+	s.applyDependentBackpressure(component, correlationID, reason)
+	stopping := componentEvent(EventComponentStopping, component, correlationID)
+	stopping.Reason = &reason
+	s.emit(stopping)
+	stopTime := time.Now()
+
+	err := s.saveState(component)
+	var stopErr error
+	if s.stopDeadline.IsZero() {
+		stopErr = component.stop(s.context, s.operation(correlationID, reason))
+	} else {
+		stopErr = s.stopWithin(component, s.operation(correlationID, reason))
+	}
+	if stopErr != nil {
+		err = stopErr
 	}
 
-	// Reverse the order for stopping
-	for i, j := 0, len(orderedComponents)-1; i < j; i, j = i+1, j-1 {
-		orderedComponents[i], orderedComponents[j] = orderedComponents[j], orderedComponents[i]
+	component.setLastErr(err)
+	event := componentEvent(EventComponentStopped, component, correlationID)
+	event.Duration = time.Since(stopTime)
+	event.Err = err
+	event.Reason = &reason
+	s.emit(event)
+
+	s.shared.Lock()
+	defer s.shared.Unlock()
+	if err == nil {
+		s.recordTiming(component, OpStop, event.Duration)
+	}
+	s.recordStopResult(component, correlationID, err)
+	return err
+}
+
+// validate checks the graph and returns the components in start order. It
+// reports every problem found rather than stopping at the first, joining
+// them when there are several
+func (s *System) validate() ([]string, error) {
+	var errs []error
+
+	// Map provided keys to their components
+	errs = append(errs, s.buildProviders())
+
+	// Enforce the key naming convention
+	errs = append(errs, s.checkKeyNaming())
+
+	// Check that every dependency is satisfied
+	errs = append(errs, s.checkMissingDependencies())
+
+	// Check for cyclic dependencies
+	errs = append(errs, s.checkCyclicDependencies())
+
+	// Check declarations against implemented capabilities
+	errs = append(errs, s.checkCapabilities())
+
+	if err := joinErrors(errs); err != nil {
+		return nil, err
 	}
 
-	// Stop components in reverse order
-	var lastErr error
-	for _, name := range orderedComponents {
-		component := s.components[name]
-		if err := component.Stop(s.context); err != nil {
-			lastErr = fmt.Errorf("failed to stop component %s: %w", name, err)
-			// Continue stopping other components even if one fails
+	// Get components in order of dependencies
+	return s.getOrderedComponents()
+}
+
+// checkMissingDependencies reports every dependency no component or
+// provided key satisfies
+func (s *System) checkMissingDependencies() error {
+	var errs []error
+	for _, name := range s.sortedKeys() {
+		for _, dep := range s.dependencyKeys(s.components[name]) {
+			if _, exists := s.resolve(dep); !exists {
+				errs = append(errs, &ComponentError{Key: name, Op: OpResolve, Err: &MissingDependencyError{Key: name, Dependency: dep}})
+			}
 		}
 	}
+	return joinErrors(errs)
+}
 
-	s.started = false
-	return lastErr
+// systemAware is implemented by built-in instances that need access to the
+// system running them, such as scheduled tasks
+type systemAware interface {
+	attach(s *System, c *Component)
 }
 
-// GetContext returns the system context with all component results
-func (s *System) GetContext() Context {
+// operation describes a lifecycle operation for the components taking part in it
+func (s *System) operation(correlationID string, reason ShutdownReason) operation {
+	return operation{correlationID: correlationID, reason: reason, catalog: s.Catalog(), logger: s.Logger()}
+}
+
+// EffectiveOrder returns the order components actually started in during
+// the last Start. Stop unwinds it in the batches of ShutdownOrder: its exact
+// reverse when stopping sequentially in a single stage
+func (s *System) EffectiveOrder() []string {
 	s.mu.Lock()
 	defer s.mu.Unlock()
-	
-	// Create a copy to prevent external modification
-	ctx := make(Context)
-	for k, v := range s.context {
-		ctx[k] = v
+	return append([]string(nil), s.startOrder...)
+}
+
+// ShutdownOrder returns the batches the next Stop stops the started
+// components in. A batch begins once the previous one finished and its
+// components may stop concurrently, so no component stops before the
+// components depending on it. Stopping sequentially each batch holds one
+// component, in the exact reverse of EffectiveOrder; WithParallelStop the
+// batches are the topological levels of the start order, last level first.
+// WithShutdownStages the stages follow one another, each ordered that way
+// on its own. A system created WithExecutor stops in the order its executor
+// chooses
+func (s *System) ShutdownOrder() [][]string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	plan := s.stopPlan()
+	if len(s.shutdownStages) == 0 {
+		return s.stopBatches(plan)
 	}
-	
-	return ctx
+	var batches [][]string
+	for _, staged := range s.stagePlans(plan) {
+		batches = append(batches, s.stopBatches(staged)...)
+	}
+	return batches
 }
 
-// checkCyclicDependencies verifies that there are no cyclic dependencies
-func (s *System) checkCyclicDependencies() error {
-	visited := make(map[string]bool)
-	recStack := make(map[string]bool)
+// DependenciesOf returns the keys of the components a component depends on,
+// with provided keys and prefix dependencies resolved to their providers
+func (s *System) DependenciesOf(key string) []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
 
-	for name := range s.components {
-		if !visited[name] {
-			if s.isCyclic(name, visited, recStack) {
-				return fmt.Errorf("cyclic dependency detected involving component %s", name)
-			}
-		}
+	component, exists := s.components[key]
+	if !exists {
+		return nil
 	}
+	return s.resolvedDependencies(component)
+}
 
-	return nil
+// ContextKeysOf returns the keys a component finds in its context, with
+// prefix dependencies expanded to every matching key
+func (s *System) ContextKeysOf(key string) []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	component, exists := s.components[key]
+	if !exists {
+		return nil
+	}
+	if s.providers == nil {
+		s.buildProviders()
+	}
+	return s.dependencyKeys(component)
 }
 
-// isCyclic is a helper function for cycle detection using DFS
-func (s *System) isCyclic(name string, visited, recStack map[string]bool) bool {
-	visited[name] = true
-	recStack[name] = true
+// Component returns the component registered under key
+func (s *System) Component(key string) (*Component, bool) {
+	component, exists := s.snapshot().components[key]
+	return component, exists
+}
 
-	component := s.components[name]
-	for _, dep := range component.GetDependencies() {
-		// Verificar se a dependência existe
-		_, exists := s.components[dep]
-		if !exists {
-			// Dependência não encontrada, mas não é um ciclo
-			continue
-		}
-		
-		if !visited[dep] {
-			if s.isCyclic(dep, visited, recStack) {
-				return true
-			}
-		} else if recStack[dep] {
-			return true
+// resolvedDependencies returns the provider keys a component depends on; the
+// caller must hold s.mu
+func (s *System) resolvedDependencies(component *Component) []string {
+	if s.providers == nil {
+		s.buildProviders()
+	}
+
+	seen := make(map[string]bool)
+	var deps []string
+	for _, dep := range s.dependencyKeys(component) {
+		if provider, ok := s.resolve(dep); ok && !seen[provider] {
+			seen[provider] = true
+			deps = append(deps, provider)
 		}
 	}
+	return deps
+}
 
-	recStack[name] = false
-	return false
+// GetContext returns the system context with all component results
+func (s *System) GetContext() Context {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Create a copy to prevent external modification
+	ctx := make(Context)
+	for k, v := range s.context {
+		ctx[k] = v
+	}
+
+	return ctx
+}
+
+// checkCyclicDependencies verifies that there are no cyclic dependencies,
+// reporting every cycle
+func (s *System) checkCyclicDependencies() error {
+	var errs []error
+	for _, cycle := range s.findCycles() {
+		errs = append(errs, &CycleError{Key: cycle[0], Path: cycle})
+	}
+	return joinErrors(errs)
 }
 
 // getOrderedComponents returns components in dependency order
@@ -178,24 +624,25 @@ func (s *System) getOrderedComponents() ([]string, error) {
 	// Build dependency graph
 	graph := make(map[string][]string)
 	inDegree := make(map[string]int)
-	
+
 	// Initialize all components with zero in-degree
 	for name := range s.components {
 		inDegree[name] = 0
 		graph[name] = []string{}
 	}
-	
+
 	// Calculate in-degree for each component
 	for name, component := range s.components {
-		for _, dep := range component.GetDependencies() {
-			if _, exists := s.components[dep]; !exists {
-				return nil, fmt.Errorf("dependency %s not found for component %s", dep, name)
+		for _, dep := range s.dependencyKeys(component) {
+			provider, exists := s.resolve(dep)
+			if !exists {
+				return nil, &ComponentError{Key: name, Op: OpResolve, Err: &MissingDependencyError{Key: name, Dependency: dep}}
 			}
-			graph[dep] = append(graph[dep], name)
+			graph[provider] = append(graph[provider], name)
 			inDegree[name]++
 		}
 	}
-	
+
 	// Find all sources (nodes with in-degree 0)
 	var queue []string
 	for name, degree := range inDegree {
@@ -203,18 +650,18 @@ func (s *System) getOrderedComponents() ([]string, error) {
 			queue = append(queue, name)
 		}
 	}
-	
+
 	// Topological sort
 	var result []string
 	for len(queue) > 0 {
 		// Sort queue for deterministic order
 		sort.Strings(queue)
-		
+
 		// Take first element
 		current := queue[0]
 		queue = queue[1:]
 		result = append(result, current)
-		
+
 		// Reduce in-degree of neighbors
 		for _, neighbor := range graph[current] {
 			inDegree[neighbor]--
@@ -223,11 +670,11 @@ func (s *System) getOrderedComponents() ([]string, error) {
 			}
 		}
 	}
-	
+
 	// Check if all components were included
 	if len(result) != len(s.components) {
-		return nil, fmt.Errorf("cyclic dependency detected")
+		return nil, &CycleError{}
 	}
-	
+
 	return result, nil
 }
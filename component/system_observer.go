@@ -0,0 +1,75 @@
+package component
+
+// SystemObserver receives the lifecycle of a system through one hook per
+// event type, as the foundation of metrics, tracing or progress UIs. Embed
+// ObserverHooks to implement only the hooks of interest. Hooks are called
+// synchronously and must not call lifecycle methods of the system
+type SystemObserver interface {
+	OnSystemStarting(event Event)
+
+	// OnSystemStarted is called once the start finished, with Err set if it failed
+	OnSystemStarted(event Event)
+	OnSystemStopping(event Event)
+	OnSystemStopped(event Event)
+	OnComponentStarting(event Event)
+	OnComponentStarted(event Event)
+
+	// OnComponentFailed is called when a component failed to start
+	OnComponentFailed(event Event)
+	OnComponentStopping(event Event)
+
+	// OnComponentStopped is called once a component stopped, with Err set
+	// if its stop failed
+	OnComponentStopped(event Event)
+}
+
+// ObserverHooks implements every SystemObserver hook as a no-op
+type ObserverHooks struct{}
+
+func (ObserverHooks) OnSystemStarting(event Event)    {}
+func (ObserverHooks) OnSystemStarted(event Event)     {}
+func (ObserverHooks) OnSystemStopping(event Event)    {}
+func (ObserverHooks) OnSystemStopped(event Event)     {}
+func (ObserverHooks) OnComponentStarting(event Event) {}
+func (ObserverHooks) OnComponentStarted(event Event)  {}
+func (ObserverHooks) OnComponentFailed(event Event)   {}
+func (ObserverHooks) OnComponentStopping(event Event) {}
+func (ObserverHooks) OnComponentStopped(event Event)  {}
+
+// WithSystemObserver registers observers with the system. The option may be
+// given several times; observers are called in registration order
+func WithSystemObserver(observers ...SystemObserver) Option {
+	return func(s *System) {
+		for _, observer := range observers {
+			observer := observer
+			s.listeners = append(s.listeners, func(event Event) {
+				dispatchObserver(observer, event)
+			})
+		}
+	}
+}
+
+// dispatchObserver calls the hook of an observer matching the event type.
+// Events without a hook, such as health events, are not delivered
+func dispatchObserver(observer SystemObserver, event Event) {
+	switch event.Type {
+	case EventSystemStarting:
+		observer.OnSystemStarting(event)
+	case EventSystemStarted:
+		observer.OnSystemStarted(event)
+	case EventSystemStopping:
+		observer.OnSystemStopping(event)
+	case EventSystemStopped:
+		observer.OnSystemStopped(event)
+	case EventComponentStarting:
+		observer.OnComponentStarting(event)
+	case EventComponentStarted:
+		observer.OnComponentStarted(event)
+	case EventComponentFailed:
+		observer.OnComponentFailed(event)
+	case EventComponentStopping:
+		observer.OnComponentStopping(event)
+	case EventComponentStopped:
+		observer.OnComponentStopped(event)
+	}
+}
@@ -0,0 +1,46 @@
+package component
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// progressObserver records the hooks it implements
+type progressObserver struct {
+	ObserverHooks
+	name  string
+	calls *[]string
+}
+
+func (o progressObserver) OnComponentStarted(event Event) {
+	*o.calls = append(*o.calls, o.name+" started "+event.Component)
+}
+
+func (o progressObserver) OnComponentFailed(event Event) {
+	*o.calls = append(*o.calls, o.name+" failed "+event.Component+": "+event.Err.Error())
+}
+
+func (o progressObserver) OnSystemStarted(event Event) {
+	*o.calls = append(*o.calls, o.name+" system started")
+}
+
+func TestWithSystemObserver(t *testing.T) {
+	silenceTestStdout(t)
+
+	var calls []string
+	system := CreateSystem(map[string]*Component{
+		"db":  Define("db", &MockComponent{}),
+		"api": Define("api", &MockComponent{StartError: errors.New("port in use")}, "db"),
+	}, WithSystemObserver(progressObserver{name: "first", calls: &calls}), WithSystemObserver(progressObserver{name: "second", calls: &calls}))
+	system.Start()
+
+	want := []string{
+		"first started db", "second started db",
+		"first failed api: failed to start component: port in use", "second failed api: failed to start component: port in use",
+		"first system started", "second system started",
+	}
+	if !reflect.DeepEqual(calls, want) {
+		t.Errorf("Expected hooks %v, got %v", want, calls)
+	}
+}
@@ -2,6 +2,7 @@ package component
 
 import (
 	"errors"
+	"reflect"
 	"testing"
 )
 
@@ -114,6 +115,40 @@ func TestSystemStartError(t *testing.T) {
 	}
 }
 
+func TestStopAfterFailedStart(t *testing.T) {
+	var stopped []string
+	cache := &MockComponent{Key: "cache"}
+	api := &MockComponent{Key: "api"}
+	system := CreateSystem(map[string]*Component{
+		"db":     Define("db", &MockComponent{Key: "db"}),
+		"cache":  Define("cache", cache, "db"),
+		"broker": Define("broker", &MockComponent{Key: "broker", StartError: errors.New("start error")}, "cache"),
+		"api":    Define("api", api, "broker"),
+	}, WithEventListener(func(event Event) {
+		if event.Type == EventComponentStopped {
+			stopped = append(stopped, event.Component)
+		}
+	}))
+
+	if err := system.Start(); err == nil {
+		t.Fatal("Expected system start to fail, but it succeeded")
+	}
+	if err := system.Stop(); err != nil {
+		t.Fatalf("Expected the partial start to unwind cleanly, got %v", err)
+	}
+
+	// Only what started is stopped, in the reverse of the real start order
+	if want := []string{"cache", "db"}; !reflect.DeepEqual(stopped, want) {
+		t.Errorf("Expected %v to be stopped, got %v", want, stopped)
+	}
+	if api.StartCalled || api.StopCalled {
+		t.Error("Expected api never to be started nor stopped")
+	}
+	if err := system.Stop(); err != nil || len(stopped) != 2 {
+		t.Errorf("Expected a second Stop to do nothing, got %v and %v", err, stopped)
+	}
+}
+
 func TestSystemCyclicDependency(t *testing.T) {
 	// Create mock components with a cyclic dependency
 	compA := &MockComponent{Key: "compA"}
@@ -155,3 +190,61 @@ func TestMissingDependency(t *testing.T) {
 		t.Fatal("Expected system start to fail due to missing dependency, but it succeeded")
 	}
 }
+
+// HookedComponent records when its dependency hook runs relative to Start
+type HookedComponent struct {
+	MockComponent
+	HookErr   error
+	HookCalls []string
+}
+
+func (h *HookedComponent) AfterDependenciesStarted(ctx Context) error {
+	h.HookCalls = append(h.HookCalls, "hook")
+	return h.HookErr
+}
+
+func (h *HookedComponent) Start(ctx Context) (Lifecycle, error) {
+	h.HookCalls = append(h.HookCalls, "start")
+	return h.MockComponent.Start(ctx)
+}
+
+func TestAfterDependenciesStartedHook(t *testing.T) {
+	compA := &MockComponent{Key: "compA"}
+	compB := &HookedComponent{MockComponent: MockComponent{Key: "compB"}}
+
+	var depStarted bool
+	components := map[string]*Component{
+		"compA": Define("compA", compA),
+		"compB": Define("compB", compB, "compA").AfterDependenciesStarted(func(ctx Context) error {
+			depStarted = compA.StartCalled && ctx["compA"] != nil
+			return nil
+		}),
+	}
+
+	system := CreateSystem(components)
+	if err := system.Start(); err != nil {
+		t.Fatalf("Failed to start system: %v", err)
+	}
+
+	if !depStarted {
+		t.Error("Expected dependency to be started before the hook ran")
+	}
+	if len(compB.HookCalls) != 2 || compB.HookCalls[0] != "hook" || compB.HookCalls[1] != "start" {
+		t.Errorf("Expected hook before start, got %v", compB.HookCalls)
+	}
+}
+
+func TestAfterDependenciesStartedHookError(t *testing.T) {
+	compA := &HookedComponent{MockComponent: MockComponent{Key: "compA"}, HookErr: errors.New("schema mismatch")}
+
+	system := CreateSystem(map[string]*Component{
+		"compA": Define("compA", compA),
+	})
+
+	if err := system.Start(); err == nil {
+		t.Fatal("Expected system start to fail due to hook error, but it succeeded")
+	}
+	if compA.StartCalled {
+		t.Error("Component should not start when its dependency hook fails")
+	}
+}
@@ -0,0 +1,45 @@
+package component
+
+import (
+	"sort"
+)
+
+// WithTags labels the component, e.g. "ingress" or "storage", so options,
+// tooling and route groups can select components by role
+func (c *Component) WithTags(tags ...string) *Component {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.tags = append(c.tags, tags...)
+	return c
+}
+
+// GetTags returns the tags of the component
+func (c *Component) GetTags() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]string(nil), c.tags...)
+}
+
+// HasTag reports whether the component carries the tag
+func (c *Component) HasTag(tag string) bool {
+	for _, t := range c.GetTags() {
+		if t == tag {
+			return true
+		}
+	}
+	return false
+}
+
+// ComponentsWithTag returns the components carrying the tag, ordered by key
+func (s *System) ComponentsWithTag(tag string) []*Component {
+	var tagged []*Component
+	for _, component := range s.snapshot().components {
+		if component.HasTag(tag) {
+			tagged = append(tagged, component)
+		}
+	}
+	sort.Slice(tagged, func(i, j int) bool {
+		return tagged[i].key < tagged[j].key
+	})
+	return tagged
+}
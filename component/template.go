@@ -0,0 +1,59 @@
+package component
+
+import (
+	"sort"
+)
+
+// Template defines a component that is instantiated several times with
+// different parameters, e.g. one Kafka consumer per topic
+type Template[P any] struct {
+	name         string
+	factory      func(params P) Lifecycle
+	dependencies []string
+}
+
+// DefineTemplate creates a template whose instances share the given dependencies
+func DefineTemplate[P any](name string, factory func(params P) Lifecycle, dependencies ...string) *Template[P] {
+	return &Template[P]{
+		name:         name,
+		factory:      factory,
+		dependencies: dependencies,
+	}
+}
+
+// Name returns the template name
+func (t *Template[P]) Name() string {
+	return t.name
+}
+
+// Key returns the key of the instance with the given id, "name/id"
+func (t *Template[P]) Key(id string) string {
+	return t.name + "/" + id
+}
+
+// Pattern returns the prefix dependency matching every instance
+func (t *Template[P]) Pattern() string {
+	return t.name + "/" + Wildcard
+}
+
+// Instance creates a component from the template, also delivering params to
+// it at Start. Extra dependencies are added to the ones shared by every instance
+func (t *Template[P]) Instance(id string, params P, dependencies ...string) *Component {
+	deps := append(append([]string(nil), t.dependencies...), dependencies...)
+	return Define(t.Key(id), t.factory(params), deps...).WithParams(params)
+}
+
+// Instances creates one component per entry of params, ordered by id
+func (t *Template[P]) Instances(params map[string]P) []*Component {
+	ids := make([]string, 0, len(params))
+	for id := range params {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	components := make([]*Component, 0, len(ids))
+	for _, id := range ids {
+		components = append(components, t.Instance(id, params[id]))
+	}
+	return components
+}
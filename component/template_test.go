@@ -0,0 +1,59 @@
+package component
+
+import (
+	"testing"
+)
+
+// Consumer is a parameterized component instantiated from a template
+type Consumer struct {
+	MockComponent
+	Topic string
+	Group string
+}
+
+func (c *Consumer) Start(ctx Context) (Lifecycle, error) {
+	return c, nil
+}
+
+type consumerParams struct {
+	Topic string
+	Group string
+}
+
+func TestTemplateInstances(t *testing.T) {
+	consumers := DefineTemplate("kafka_consumer", func(p consumerParams) Lifecycle {
+		return &Consumer{Topic: p.Topic, Group: p.Group}
+	}, "config")
+
+	instances := consumers.Instances(map[string]consumerParams{
+		"orders":   {Topic: "orders", Group: "billing"},
+		"payments": {Topic: "payments", Group: "billing"},
+	})
+
+	components := map[string]*Component{
+		"config": Define("config", &MockComponent{}),
+		"router": Define("router", &ContextCapture{}, consumers.Pattern()),
+	}
+	for _, c := range instances {
+		components[c.Key()] = c
+	}
+
+	system := CreateSystem(components)
+	if err := system.Start(); err != nil {
+		t.Fatalf("Failed to start system: %v", err)
+	}
+
+	ctx := system.GetContext()
+	orders, ok := ctx["kafka_consumer/orders"].(*Consumer)
+	if !ok || orders.Topic != "orders" || orders.Group != "billing" {
+		t.Errorf("Expected orders consumer with its parameters, got %v", ctx["kafka_consumer/orders"])
+	}
+	if deps := instances[0].GetDependencies(); len(deps) != 1 || deps[0] != "config" {
+		t.Errorf("Expected instances to share the template dependencies, got %v", deps)
+	}
+
+	router := components["router"].instance.(*ContextCapture)
+	if len(router.Ctx.Matching(consumers.Pattern())) != 2 {
+		t.Errorf("Expected router to collect both consumers, got %v", router.Ctx)
+	}
+}
@@ -0,0 +1,45 @@
+package component
+
+// TopologyNode is a component in an exported topology
+type TopologyNode struct {
+	Key      string
+	ID       string
+	State    State
+	Tags     []string
+	Metadata Metadata
+}
+
+// TopologyEdge records that From depends on To
+type TopologyEdge struct {
+	From string
+	To   string
+}
+
+// Topology is a snapshot of the component graph for exporters
+type Topology struct {
+	Nodes []TopologyNode
+	Edges []TopologyEdge
+}
+
+// Topology returns the component graph with the current state of every
+// component, nodes and edges sorted by key
+func (s *System) Topology() Topology {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var topology Topology
+	for _, key := range s.sortedKeys() {
+		component := s.components[key]
+		topology.Nodes = append(topology.Nodes, TopologyNode{
+			Key:      key,
+			ID:       component.id,
+			State:    component.State(),
+			Tags:     component.GetTags(),
+			Metadata: component.GetMetadata(),
+		})
+		for _, dep := range s.resolvedDependencies(component) {
+			topology.Edges = append(topology.Edges, TopologyEdge{From: key, To: dep})
+		}
+	}
+	return topology
+}
@@ -0,0 +1,152 @@
+package component
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// TracerContextKey holds the runtime tracer of a component's Context
+const TracerContextKey = ReservedPrefix + "tracer"
+
+// runtimeTracer records the context keys each component reads through
+// Context.Get, keyed by component then context key
+type runtimeTracer struct {
+	reads map[string]map[string]bool
+	mu    sync.Mutex
+}
+
+// record notes that a component read a context key
+func (t *runtimeTracer) record(component, key string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.reads[component] == nil {
+		t.reads[component] = make(map[string]bool)
+	}
+	t.reads[component][key] = true
+}
+
+// readsOf returns the keys a component read and whether it read any
+func (t *runtimeTracer) readsOf(component string) (map[string]bool, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	reads, ok := t.reads[component]
+	copied := make(map[string]bool, len(reads))
+	for key := range reads {
+		copied[key] = true
+	}
+	return copied, ok
+}
+
+// contextTracer carries the tracer in a component's Context
+type contextTracer struct {
+	tracer    *runtimeTracer
+	component string
+
+	// aliases maps the local keys of a composed component to qualified ones
+	aliases map[string]string
+}
+
+func (c *contextTracer) Start(ctx Context) (Lifecycle, error) {
+	return c, nil
+}
+
+func (c *contextTracer) Stop(ctx Context) error {
+	return nil
+}
+
+// WithRuntimeTracing records which dependencies each component reads through
+// Context.Get, during Start and afterwards through a retained Context, so
+// TraceReport can compare them with the declared dependencies
+func WithRuntimeTracing() Option {
+	return func(s *System) {
+		s.tracer = &runtimeTracer{reads: make(map[string]map[string]bool)}
+	}
+}
+
+// Get returns the dependency stored under key, recording the read when the
+// system traces dependencies. Reads indexing the map directly are not seen
+func (ctx Context) Get(key string) Lifecycle {
+	if tracer, ok := ctx[TracerContextKey].(*contextTracer); ok && !strings.HasPrefix(key, ReservedPrefix) {
+		tracer.tracer.record(tracer.component, qualifiedKey(tracer.aliases, key))
+	}
+	return ctx[key]
+}
+
+// DependencyAs returns the dependency stored under key as type T, recording
+// the read like Context.Get
+func DependencyAs[T any](ctx Context, key string) (T, bool) {
+	dependency, ok := ctx.Get(key).(T)
+	return dependency, ok
+}
+
+// TraceReport compares observed reads with declared dependencies
+type TraceReport struct {
+	// Undeclared edges were read at runtime without being declared
+	Undeclared []TopologyEdge
+
+	// Unused edges were declared but never read by a component that read
+	// at least one dependency through Context.Get
+	Unused []TopologyEdge
+}
+
+// Truthful reports whether the declarations match what was observed
+func (r TraceReport) Truthful() bool {
+	return len(r.Undeclared) == 0 && len(r.Unused) == 0
+}
+
+func (r TraceReport) String() string {
+	var b strings.Builder
+	for _, edge := range r.Undeclared {
+		fmt.Fprintf(&b, "%s reads %s without declaring it\n", edge.From, edge.To)
+	}
+	for _, edge := range r.Unused {
+		fmt.Fprintf(&b, "%s declares %s but never reads it\n", edge.From, edge.To)
+	}
+	return b.String()
+}
+
+// TraceReport returns the edges that differ between what components read at
+// runtime and what they declare. It is empty unless WithRuntimeTracing is set
+func (s *System) TraceReport() TraceReport {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var report TraceReport
+	if s.tracer == nil {
+		return report
+	}
+	if s.providers == nil {
+		s.buildProviders()
+	}
+
+	for _, name := range s.sortedKeys() {
+		reads, traced := s.tracer.readsOf(name)
+		if !traced {
+			continue
+		}
+
+		declared := make(map[string]bool)
+		for _, dep := range s.dependencyKeys(s.components[name]) {
+			declared[dep] = true
+			if !reads[dep] {
+				report.Unused = append(report.Unused, TopologyEdge{From: name, To: dep})
+			}
+		}
+
+		var undeclared []string
+		for key := range reads {
+			if !declared[key] {
+				undeclared = append(undeclared, key)
+			}
+		}
+		sort.Strings(undeclared)
+		for _, key := range undeclared {
+			report.Undeclared = append(report.Undeclared, TopologyEdge{From: name, To: key})
+		}
+	}
+	return report
+}
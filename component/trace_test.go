@@ -0,0 +1,61 @@
+package component
+
+import (
+	"reflect"
+	"testing"
+)
+
+// ReadingComponent reads the listed keys from its Context when started
+type ReadingComponent struct {
+	MockComponent
+	Reads []string
+}
+
+func (r *ReadingComponent) Start(ctx Context) (Lifecycle, error) {
+	for _, key := range r.Reads {
+		ctx.Get(key)
+	}
+	return r.MockComponent.Start(ctx)
+}
+
+func TestTraceReportFindsUndeclaredAndUnusedEdges(t *testing.T) {
+	system := CreateSystem(map[string]*Component{
+		"config": Define("config", &MockComponent{}),
+		"cache":  Define("cache", &MockComponent{}),
+		"db":     Define("db", &ReadingComponent{Reads: []string{"config"}}, "config"),
+		"api":    Define("api", &ReadingComponent{Reads: []string{"db", "cache", EnvContextKey}}, "db", "config"),
+		"worker": Define("worker", &MockComponent{}, "db"),
+	}, WithRuntimeTracing())
+
+	if err := system.Start(); err != nil {
+		t.Fatalf("Expected start to succeed, got %v", err)
+	}
+	defer system.Stop()
+
+	report := system.TraceReport()
+	if !reflect.DeepEqual(report.Undeclared, []TopologyEdge{{From: "api", To: "cache"}}) {
+		t.Errorf("Expected api reading cache to be undeclared, got %v", report.Undeclared)
+	}
+	// worker never reads through Get, so its declarations are not judged
+	if !reflect.DeepEqual(report.Unused, []TopologyEdge{{From: "api", To: "config"}}) {
+		t.Errorf("Expected api declaring config to be unused, got %v", report.Unused)
+	}
+	if report.Truthful() {
+		t.Error("Expected report not to be truthful")
+	}
+}
+
+func TestContextGetWithoutTracing(t *testing.T) {
+	ctx := Context{"db": &MockComponent{}}
+	if ctx.Get("db") == nil {
+		t.Error("Expected Get to return the dependency")
+	}
+	if _, ok := DependencyAs[*MockComponent](ctx, "db"); !ok {
+		t.Error("Expected DependencyAs to return the typed dependency")
+	}
+
+	system := CreateSystem(map[string]*Component{"db": Define("db", &MockComponent{})})
+	if report := system.TraceReport(); !report.Truthful() {
+		t.Errorf("Expected empty report without tracing, got %v", report)
+	}
+}
@@ -0,0 +1,86 @@
+package component
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+)
+
+// Validate checks the wiring without starting anything and returns the
+// order components would start in. Besides the checks Start makes, such
+// as cyclic and missing dependencies, it reports definitions Start cannot
+// detect: nil components, components registered under a key other than
+// their own, two entries defining the same key, and components depending
+// on themselves. Every problem is reported in one joined error. CI can call
+// it to verify wiring before deploying
+func (s *System) Validate() ([]string, error) {
+	_, order, err := s.dryRun()
+	return order, err
+}
+
+// candidate returns a system holding components with the validation
+// options of s, to check a graph without touching the live one; the caller
+// must hold s.mu
+func (s *System) candidate(components map[string]*Component) *System {
+	return &System{components: components, keyNaming: s.keyNaming, strict: s.strict}
+}
+
+// dryRun validates a copy of the system, returning it with the start order
+func (s *System) dryRun() (*System, []string, error) {
+	s.mu.Lock()
+	candidate := s.candidate(s.components)
+	s.mu.Unlock()
+
+	// Nil components are reported by checkDefinitions and left out of the
+	// graph checks, so both report together
+	definitionErr := candidate.checkDefinitions()
+	if definitionErr != nil {
+		components := make(map[string]*Component, len(candidate.components))
+		for key, component := range candidate.components {
+			if component != nil {
+				components[key] = component
+			}
+		}
+		candidate.components = components
+	}
+	order, err := candidate.validate()
+	if err = joinErrors([]error{definitionErr, err}); err != nil {
+		return nil, nil, err
+	}
+	return candidate, order, nil
+}
+
+// checkDefinitions reports component map entries that do not match their
+// component, and components depending on themselves
+func (s *System) checkDefinitions() error {
+	keys := make([]string, 0, len(s.components))
+	for key := range s.components {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var errs []error
+	definedUnder := make(map[string]string, len(keys))
+	for _, key := range keys {
+		component := s.components[key]
+		if component == nil {
+			errs = append(errs, &ComponentError{Key: key, Op: OpValidate, Err: errors.New("component is nil")})
+			continue
+		}
+		if component.key != key {
+			errs = append(errs, &ComponentError{Key: key, Op: OpValidate, Err: fmt.Errorf("registered under a different key than its own key %s", component.key)})
+		}
+		if previous, exists := definedUnder[component.key]; exists {
+			errs = append(errs, &ComponentError{Key: component.key, Op: OpValidate, Err: fmt.Errorf("defined twice, under %s and %s", previous, key)})
+		}
+		definedUnder[component.key] = key
+
+		for _, dep := range component.GetDependencies() {
+			if dep == component.key || dep == key || slices.Contains(component.GetProvides(), dep) {
+				errs = append(errs, &ComponentError{Key: key, Op: OpValidate, Err: fmt.Errorf("depends on itself through %s", dep)})
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,104 @@
+package component
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+	db := &MockComponent{}
+	system := CreateSystem(map[string]*Component{
+		"db":    Define("db", db),
+		"cache": Define("cache", &MockComponent{}),
+		"api":   Define("api", &MockComponent{}, "db", "cache"),
+	})
+
+	order, err := system.Validate()
+	if err != nil {
+		t.Fatalf("Expected valid wiring, got %v", err)
+	}
+	if want := []string{"cache", "db", "api"}; !reflect.DeepEqual(order, want) {
+		t.Errorf("Expected order %v, got %v", want, order)
+	}
+	if db.StartCalled || system.IsStarted() {
+		t.Error("Expected Validate not to start anything")
+	}
+}
+
+func TestValidateReportsWiringMistakes(t *testing.T) {
+	for name, tc := range map[string]struct {
+		components map[string]*Component
+		want       []string
+	}{
+		"missing dependency": {
+			components: map[string]*Component{"api": Define("api", &MockComponent{}, "db")},
+			want:       []string{"dependency db not found"},
+		},
+		"cycle": {
+			components: map[string]*Component{
+				"a": Define("a", &MockComponent{}, "b"),
+				"b": Define("b", &MockComponent{}, "a"),
+			},
+			want: []string{"cyclic dependency"},
+		},
+		"self dependency": {
+			components: map[string]*Component{"db": Define("db", &MockComponent{}, "db")},
+			want:       []string{"depends on itself through db for component db"},
+		},
+		"mismatched and duplicate keys": {
+			components: map[string]*Component{
+				"db":       Define("db", &MockComponent{}),
+				"database": Define("db", &MockComponent{}),
+				"cache":    nil,
+			},
+			want: []string{
+				"component is nil for component cache",
+				"registered under a different key than its own key db for component database",
+				"defined twice, under database and db for component db",
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := CreateSystem(tc.components).Validate()
+			if err == nil {
+				t.Fatal("Expected the wiring to be rejected")
+			}
+			for _, want := range tc.want {
+				if !strings.Contains(err.Error(), want) {
+					t.Errorf("Expected %q in %v", want, err)
+				}
+			}
+		})
+	}
+}
+
+func TestValidateReportsEveryProblem(t *testing.T) {
+	system := CreateSystem(map[string]*Component{
+		"api":     Define("api", &MockComponent{}, "db", "sessions"),
+		"worker":  Define("worker", &MockComponent{}, "queue"),
+		"a":       Define("a", &MockComponent{}, "b"),
+		"b":       Define("b", &MockComponent{}, "a"),
+		"cache":   Define("cache", &MockComponent{}).Provides("store"),
+		"storage": Define("storage", &MockComponent{}).Provides("store"),
+		"broken":  nil,
+	})
+
+	_, err := system.Validate()
+	for _, want := range []string{
+		"component is nil for component broken",
+		"dependency db not found for component api",
+		"dependency sessions not found for component api",
+		"dependency queue not found for component worker",
+		"cyclic dependency detected involving component a: a -> b -> a",
+		"key store provided by component storage is already provided by cache",
+	} {
+		if err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("Expected %q in %v", want, err)
+		}
+	}
+	if !errors.Is(err, ErrMissingDependency) || !errors.Is(err, ErrCyclicDependency) {
+		t.Errorf("Expected the joined error to match each failure mode, got %v", err)
+	}
+}
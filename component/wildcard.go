@@ -0,0 +1,53 @@
+package component
+
+import (
+	"sort"
+	"strings"
+)
+
+// Wildcard is the suffix marking a prefix dependency such as "handlers/*"
+const Wildcard = "*"
+
+// isPattern reports whether a dependency is a prefix dependency
+func isPattern(dep string) bool {
+	return strings.HasSuffix(dep, Wildcard)
+}
+
+// matchesPattern reports whether a key is selected by a prefix dependency
+func matchesPattern(pattern, key string) bool {
+	return strings.HasPrefix(key, strings.TrimSuffix(pattern, Wildcard))
+}
+
+// Matching returns the entries of the context selected by a prefix
+// dependency, e.g. ctx.Matching("handlers/*")
+func (ctx Context) Matching(pattern string) Context {
+	matched := make(Context)
+	for key, value := range ctx {
+		if matchesPattern(pattern, key) {
+			matched[key] = value
+		}
+	}
+	return matched
+}
+
+// dependencyKeys returns the dependencies of a component with prefix
+// dependencies expanded to every matching key registered in the system
+func (s *System) dependencyKeys(component *Component) []string {
+	var keys []string
+	for _, dep := range component.GetDependencies() {
+		if !isPattern(dep) {
+			keys = append(keys, dep)
+			continue
+		}
+
+		var matched []string
+		for key, provider := range s.providers {
+			if provider != component.key && matchesPattern(dep, key) {
+				matched = append(matched, key)
+			}
+		}
+		sort.Strings(matched)
+		keys = append(keys, matched...)
+	}
+	return keys
+}
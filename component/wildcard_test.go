@@ -0,0 +1,38 @@
+package component
+
+import (
+	"testing"
+)
+
+func TestPrefixDependencies(t *testing.T) {
+	users := &MockComponent{Key: "handlers/users"}
+	orders := &MockComponent{Key: "handlers/orders"}
+	router := &ContextCapture{}
+
+	system := CreateSystem(map[string]*Component{
+		"handlers/users":  Define("handlers/users", users),
+		"handlers/orders": Define("handlers/orders", orders),
+		"config":          Define("config", &MockComponent{}),
+		"router":          Define("router", router, "config", "handlers/*"),
+	})
+	if err := system.Start(); err != nil {
+		t.Fatalf("Failed to start system: %v", err)
+	}
+
+	handlers := router.Ctx.Matching("handlers/*")
+	if len(handlers) != 2 || handlers["handlers/users"] != users || handlers["handlers/orders"] != orders {
+		t.Errorf("Expected router to collect both handlers, got %v", handlers)
+	}
+	if router.Ctx["config"] == nil {
+		t.Error("Expected router to receive its plain dependencies")
+	}
+}
+
+func TestPrefixDependencyWithoutMatches(t *testing.T) {
+	system := CreateSystem(map[string]*Component{
+		"router": Define("router", &MockComponent{}, "handlers/*"),
+	})
+	if err := system.Start(); err != nil {
+		t.Fatalf("Expected prefix dependency without matches to be valid: %v", err)
+	}
+}
@@ -0,0 +1,237 @@
+package componentconfig
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/leandroolgomes/golang-dependency-graph/component"
+)
+
+// CycleError reports a cyclic dependency with the path closing it, e.g.
+// api -> db -> api
+type CycleError struct {
+	Path []string
+}
+
+func (e *CycleError) Error() string {
+	return "cyclic dependency: " + strings.Join(e.Path, " -> ")
+}
+
+// Is matches component.ErrCyclicDependency
+func (e *CycleError) Is(target error) bool {
+	return target == component.ErrCyclicDependency
+}
+
+// keys returns the component keys of the definition, sorted
+func (d Definition) keys() []string {
+	keys := make([]string, 0, len(d.Components))
+	for key := range d.Components {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// providers maps every component key and provided key to its component
+func (d Definition) providers() (map[string]string, error) {
+	providers := make(map[string]string, len(d.Components))
+	for key := range d.Components {
+		providers[key] = key
+	}
+
+	var errs []error
+	for _, key := range d.keys() {
+		for _, provided := range d.Components[key].Provides {
+			if owner, exists := providers[provided]; exists {
+				errs = append(errs, fmt.Errorf("component %s provides %s, already provided by %s", key, provided, owner))
+				continue
+			}
+			providers[provided] = key
+		}
+	}
+	return providers, errors.Join(errs...)
+}
+
+// dependencies resolves the dependencies of every component to component
+// keys the way a System does, expanding prefix dependencies such as
+// "handlers/*", and reports the ones nothing provides
+func (d Definition) dependencies() (map[string][]string, error) {
+	providers, err := d.providers()
+	errs := []error{err}
+
+	provided := make([]string, 0, len(providers))
+	for key := range providers {
+		provided = append(provided, key)
+	}
+	sort.Strings(provided)
+
+	resolved := make(map[string][]string, len(d.Components))
+	for _, key := range d.keys() {
+		seen := make(map[string]bool)
+		add := func(dep string) {
+			if !seen[dep] {
+				seen[dep] = true
+				resolved[key] = append(resolved[key], dep)
+			}
+		}
+
+		for _, dep := range d.Components[key].Dependencies {
+			if strings.HasSuffix(dep, component.Wildcard) {
+				prefix := strings.TrimSuffix(dep, component.Wildcard)
+				for _, candidate := range provided {
+					if strings.HasPrefix(candidate, prefix) && providers[candidate] != key {
+						add(providers[candidate])
+					}
+				}
+				continue
+			}
+			provider, ok := providers[dep]
+			if !ok {
+				errs = append(errs, fmt.Errorf("component %s depends on %s, which no component provides", key, dep))
+				continue
+			}
+			add(provider)
+		}
+	}
+	return resolved, errors.Join(errs...)
+}
+
+// Edges returns the resolved dependency edges, sorted by component key.
+// Dependencies nothing provides are left out
+func (d Definition) Edges() []component.TopologyEdge {
+	dependencies, _ := d.dependencies()
+	var edges []component.TopologyEdge
+	for _, key := range d.keys() {
+		for _, dep := range dependencies[key] {
+			edges = append(edges, component.TopologyEdge{From: key, To: dep})
+		}
+	}
+	return edges
+}
+
+// Cycle returns the path of a cyclic dependency, starting and ending with
+// the same component, or nil when the graph is acyclic
+func (d Definition) Cycle() []string {
+	dependencies, _ := d.dependencies()
+
+	const (
+		unvisited = iota
+		visiting
+		done
+	)
+	state := make(map[string]int, len(d.Components))
+	var stack []string
+	var visit func(key string) []string
+	visit = func(key string) []string {
+		state[key] = visiting
+		stack = append(stack, key)
+		for _, dep := range dependencies[key] {
+			switch state[dep] {
+			case visiting:
+				for i, entry := range stack {
+					if entry == dep {
+						return append(append([]string(nil), stack[i:]...), dep)
+					}
+				}
+			case unvisited:
+				if path := visit(dep); path != nil {
+					return path
+				}
+			}
+		}
+		stack = stack[:len(stack)-1]
+		state[key] = done
+		return nil
+	}
+
+	for _, key := range d.keys() {
+		if state[key] == unvisited {
+			if path := visit(key); path != nil {
+				return path
+			}
+		}
+	}
+	return nil
+}
+
+// Validate checks that every dependency is provided, that no key is
+// provided twice and that the graph is acyclic, reporting every problem
+func (d Definition) Validate() error {
+	_, err := d.dependencies()
+	errs := []error{err}
+	if path := d.Cycle(); path != nil {
+		errs = append(errs, &CycleError{Path: path})
+	}
+	return errors.Join(errs...)
+}
+
+// Order returns the components in a start order: dependencies first, ties
+// broken by key
+func (d Definition) Order() ([]string, error) {
+	if err := d.Validate(); err != nil {
+		return nil, err
+	}
+
+	dependencies, _ := d.dependencies()
+	placed := make(map[string]bool, len(d.Components))
+	order := make([]string, 0, len(d.Components))
+	for len(order) < len(d.Components) {
+		for _, key := range d.keys() {
+			if placed[key] {
+				continue
+			}
+			ready := true
+			for _, dep := range dependencies[key] {
+				ready = ready && placed[dep]
+			}
+			if ready {
+				placed[key] = true
+				order = append(order, key)
+				break
+			}
+		}
+	}
+	return order, nil
+}
+
+// WriteDOT renders the definition in Graphviz DOT format with the layout of
+// System.ExportDOT, without component states
+func (d Definition) WriteDOT(w io.Writer) error {
+	b := bufio.NewWriter(w)
+	fmt.Fprintln(b, "digraph components {")
+	fmt.Fprintln(b, "  rankdir=BT;")
+	fmt.Fprintln(b, "  node [shape=box];")
+	for _, key := range d.keys() {
+		fmt.Fprintf(b, "  %s;\n", quote(key))
+	}
+	for _, edge := range d.Edges() {
+		fmt.Fprintf(b, "  %s -> %s;\n", quote(edge.From), quote(edge.To))
+	}
+	fmt.Fprintln(b, "}")
+	return b.Flush()
+}
+
+// WriteMermaid renders the definition as a Mermaid flowchart. Nodes get
+// generated identifiers since keys may hold characters Mermaid rejects
+func (d Definition) WriteMermaid(w io.Writer) error {
+	ids := make(map[string]string, len(d.Components))
+	b := bufio.NewWriter(w)
+	fmt.Fprintln(b, "graph BT")
+	for i, key := range d.keys() {
+		ids[key] = fmt.Sprintf("c%d", i)
+		fmt.Fprintf(b, "  %s[%s]\n", ids[key], quote(key))
+	}
+	for _, edge := range d.Edges() {
+		fmt.Fprintf(b, "  %s --> %s\n", ids[edge.From], ids[edge.To])
+	}
+	return b.Flush()
+}
+
+// quote quotes a DOT identifier or Mermaid label
+func quote(text string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(text) + `"`
+}
@@ -0,0 +1,120 @@
+package componentconfig
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/leandroolgomes/golang-dependency-graph/component"
+)
+
+func definitionOf(t *testing.T, document string) Definition {
+	t.Helper()
+	definition, err := Parse([]byte(document), YAML)
+	if err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+	return definition
+}
+
+func TestOrder(t *testing.T) {
+	definition := definitionOf(t, `
+components:
+  server:
+    dependencies: [routes, database]
+  routes:
+    dependencies: ["handlers/*"]
+  handlers/users:
+    dependencies: [database]
+  handlers/orders: {}
+  db:
+    provides: [database]
+`)
+
+	order, err := definition.Order()
+	if err != nil {
+		t.Fatalf("Expected a valid definition, got %v", err)
+	}
+	want := []string{"db", "handlers/orders", "handlers/users", "routes", "server"}
+	if !reflect.DeepEqual(order, want) {
+		t.Errorf("Expected order %v, got %v", want, order)
+	}
+}
+
+func TestCycle(t *testing.T) {
+	definition := definitionOf(t, `
+components:
+  api:
+    dependencies: [cache]
+  cache:
+    dependencies: [db]
+  db:
+    dependencies: [api]
+  metrics: {}
+`)
+
+	path := definition.Cycle()
+	if want := []string{"api", "cache", "db", "api"}; !reflect.DeepEqual(path, want) {
+		t.Errorf("Expected cycle %v, got %v", want, path)
+	}
+
+	var cycleErr *CycleError
+	if err := definition.Validate(); !errors.As(err, &cycleErr) || cycleErr.Error() != "cyclic dependency: api -> cache -> db -> api" {
+		t.Errorf("Expected a cycle error with its path, got %v", err)
+	}
+	if err := definition.Validate(); !errors.Is(err, component.ErrCyclicDependency) {
+		t.Errorf("Expected the cycle error to match ErrCyclicDependency, got %v", err)
+	}
+	if _, err := definition.Order(); err == nil {
+		t.Error("Expected no order for a cyclic graph")
+	}
+}
+
+func TestValidateReportsEveryProblem(t *testing.T) {
+	definition := definitionOf(t, `
+components:
+  api:
+    dependencies: [sessions, queue]
+  db:
+    provides: [store]
+  cache:
+    provides: [store]
+`)
+
+	err := definition.Validate()
+	if err == nil {
+		t.Fatal("Expected the definition to be invalid")
+	}
+	for _, problem := range []string{"depends on sessions", "depends on queue", "store, already provided by cache"} {
+		if !strings.Contains(err.Error(), problem) {
+			t.Errorf("Expected %q to be reported, got %v", problem, err)
+		}
+	}
+}
+
+func TestRender(t *testing.T) {
+	definition := definitionOf(t, `
+components:
+  app:
+    dependencies: [db]
+  db: {}
+`)
+
+	var dot strings.Builder
+	if err := definition.WriteDOT(&dot); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(dot.String(), `"app" -> "db";`) {
+		t.Errorf("Expected the DOT edge, got:\n%s", dot.String())
+	}
+
+	var mermaid strings.Builder
+	if err := definition.WriteMermaid(&mermaid); err != nil {
+		t.Fatal(err)
+	}
+	want := "graph BT\n  c0[\"app\"]\n  c1[\"db\"]\n  c0 --> c1\n"
+	if mermaid.String() != want {
+		t.Errorf("Expected:\n%s\ngot:\n%s", want, mermaid.String())
+	}
+}
@@ -0,0 +1,171 @@
+// Package componentconfig builds a component.System from a YAML or JSON
+// definition mapping component keys to registered factories, so different
+// deployments can wire different graphs without recompiling
+package componentconfig
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/leandroolgomes/golang-dependency-graph/component"
+)
+
+// Format is the encoding of a definition
+type Format string
+
+const (
+	JSON Format = "json"
+	YAML Format = "yaml"
+)
+
+// Factory creates the instance of a component from the params of its definition
+type Factory func(key string, params map[string]interface{}) (component.Lifecycle, error)
+
+// Definition is the declarative form of a system:
+//
+//	components:
+//	  config:
+//	    factory: config
+//	  http_server:
+//	    factory: http_server
+//	    dependencies: [app_routes, config]
+//	    params:
+//	      read_timeout: 5s
+type Definition struct {
+	Components map[string]ComponentDefinition `json:"components"`
+}
+
+// ComponentDefinition declares one component of a Definition
+type ComponentDefinition struct {
+	// Factory names the registered factory creating the instance; it
+	// defaults to the component key
+	Factory      string                 `json:"factory"`
+	Dependencies []string               `json:"dependencies"`
+	Provides     []string               `json:"provides"`
+	Tags         []string               `json:"tags"`
+	OneShot      bool                   `json:"one_shot"`
+	Description  string                 `json:"description"`
+	Owner        string                 `json:"owner"`
+	Params       map[string]interface{} `json:"params"`
+}
+
+// Registry holds the factories a definition may refer to
+type Registry struct {
+	factories map[string]Factory
+}
+
+// NewRegistry creates an empty registry
+func NewRegistry() *Registry {
+	return &Registry{factories: make(map[string]Factory)}
+}
+
+// Register adds a factory under name
+func (r *Registry) Register(name string, factory Factory) *Registry {
+	r.factories[name] = factory
+	return r
+}
+
+// Parse decodes a definition. YAML support covers the block mappings,
+// sequences, flow sequences and scalars a definition needs, not the whole
+// YAML language
+func Parse(data []byte, format Format) (Definition, error) {
+	var definition Definition
+	if format == YAML {
+		value, err := parseYAML(string(data))
+		if err != nil {
+			return definition, err
+		}
+		if data, err = json.Marshal(value); err != nil {
+			return definition, err
+		}
+	}
+
+	decoder := json.NewDecoder(strings.NewReader(string(data)))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&definition); err != nil {
+		return definition, fmt.Errorf("invalid definition: %w", err)
+	}
+	return definition, nil
+}
+
+// Build creates the components of a definition with the registered factories
+func (r *Registry) Build(definition Definition) (map[string]*component.Component, error) {
+	keys := make([]string, 0, len(definition.Components))
+	for key := range definition.Components {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	components := make(map[string]*component.Component, len(keys))
+	for _, key := range keys {
+		def := definition.Components[key]
+		name := def.Factory
+		if name == "" {
+			name = key
+		}
+		factory, ok := r.factories[name]
+		if !ok {
+			return nil, fmt.Errorf("component %s: unknown factory %s", key, name)
+		}
+		instance, err := factory(key, def.Params)
+		if err != nil {
+			return nil, fmt.Errorf("component %s: %w", key, err)
+		}
+
+		var c *component.Component
+		if def.OneShot {
+			c = component.DefineOneShot(key, instance, def.Dependencies...)
+		} else {
+			c = component.Define(key, instance, def.Dependencies...)
+		}
+		c.Provides(def.Provides...).WithTags(def.Tags...)
+		if def.Description != "" || def.Owner != "" {
+			c.WithMetadata(component.Metadata{Description: def.Description, Owner: def.Owner})
+		}
+		if def.Params != nil {
+			c.WithParams(def.Params)
+		}
+		components[key] = c
+	}
+	return components, nil
+}
+
+// ParseFile reads a definition file, choosing the format by its extension
+func ParseFile(path string) (Definition, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Definition{}, err
+	}
+
+	format := JSON
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		format = YAML
+	case ".json":
+	default:
+		return Definition{}, fmt.Errorf("unknown definition format %s", filepath.Ext(path))
+	}
+
+	definition, err := Parse(data, format)
+	if err != nil {
+		return Definition{}, fmt.Errorf("%s: %w", path, err)
+	}
+	return definition, nil
+}
+
+// Load reads a definition file and creates the system it describes
+func (r *Registry) Load(path string, opts ...component.Option) (*component.System, error) {
+	definition, err := ParseFile(path)
+	if err != nil {
+		return nil, err
+	}
+	components, err := r.Build(definition)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return component.CreateSystem(components, opts...), nil
+}
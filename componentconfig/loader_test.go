@@ -0,0 +1,154 @@
+package componentconfig
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/leandroolgomes/golang-dependency-graph/component"
+)
+
+// service records the params it was created with
+type service struct {
+	params  map[string]interface{}
+	started bool
+}
+
+func (s *service) Start(ctx component.Context) (component.Lifecycle, error) {
+	s.started = true
+	return s, nil
+}
+
+func (s *service) Stop(ctx component.Context) error {
+	return nil
+}
+
+// Provided publishes the service under its database alias
+func (s *service) Provided() map[string]component.Lifecycle {
+	return map[string]component.Lifecycle{"database": s}
+}
+
+const definitionYAML = `
+# The api is wired to the database through its alias
+components:
+  db:
+    factory: service
+    provides: [database]
+    tags:
+      - storage
+    params:
+      pool: 4
+      dsn: "postgres://localhost/app"
+  api:
+    factory: service
+    dependencies: [database]
+    description: 'public API'
+    owner: platform
+`
+
+const definitionJSON = `{
+  "components": {
+    "db": {
+      "factory": "service",
+      "provides": ["database"],
+      "tags": ["storage"],
+      "params": {"pool": 4, "dsn": "postgres://localhost/app"}
+    },
+    "api": {
+      "factory": "service",
+      "dependencies": ["database"],
+      "description": "public API",
+      "owner": "platform"
+    }
+  }
+}`
+
+func newRegistry(created map[string]*service) *Registry {
+	return NewRegistry().Register("service", func(key string, params map[string]interface{}) (component.Lifecycle, error) {
+		instance := &service{params: params}
+		created[key] = instance
+		return instance, nil
+	})
+}
+
+func TestParseYAMLMatchesJSON(t *testing.T) {
+	fromYAML, err := Parse([]byte(definitionYAML), YAML)
+	if err != nil {
+		t.Fatalf("Failed to parse YAML: %v", err)
+	}
+	fromJSON, err := Parse([]byte(definitionJSON), JSON)
+	if err != nil {
+		t.Fatalf("Failed to parse JSON: %v", err)
+	}
+	if !reflect.DeepEqual(fromYAML, fromJSON) {
+		t.Errorf("Expected equivalent definitions, got %+v and %+v", fromYAML, fromJSON)
+	}
+}
+
+func TestParseRejectsUnknownFields(t *testing.T) {
+	_, err := Parse([]byte("components:\n  db:\n    factroy: service\n"), YAML)
+	if err == nil || !strings.Contains(err.Error(), "factroy") {
+		t.Errorf("Expected the misspelled field to be rejected, got %v", err)
+	}
+}
+
+func TestBuild(t *testing.T) {
+	definition, err := Parse([]byte(definitionYAML), YAML)
+	if err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+
+	created := map[string]*service{}
+	components, err := newRegistry(created).Build(definition)
+	if err != nil {
+		t.Fatalf("Failed to build: %v", err)
+	}
+	if created["db"].params["pool"] != float64(4) {
+		t.Errorf("Expected db factory to receive its params, got %v", created["db"].params)
+	}
+
+	system := component.CreateSystem(components)
+	if err := system.Start(); err != nil {
+		t.Fatalf("Failed to start built system: %v", err)
+	}
+	if !created["api"].started || !created["db"].started {
+		t.Error("Expected every built component to start")
+	}
+	if got := components["api"].GetMetadata().Owner; got != "platform" {
+		t.Errorf("Expected api owner platform, got %q", got)
+	}
+}
+
+func TestBuildUnknownFactory(t *testing.T) {
+	definition := Definition{Components: map[string]ComponentDefinition{"cache": {}}}
+	_, err := NewRegistry().Build(definition)
+	if err == nil || !strings.Contains(err.Error(), "unknown factory cache") {
+		t.Errorf("Expected the factory to default to the key and be unknown, got %v", err)
+	}
+}
+
+func TestLoad(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "system.yml")
+	if err := os.WriteFile(path, []byte(definitionYAML), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	created := map[string]*service{}
+	system, err := newRegistry(created).Load(path)
+	if err != nil {
+		t.Fatalf("Failed to load: %v", err)
+	}
+	if err := system.Start(); err != nil {
+		t.Fatalf("Failed to start loaded system: %v", err)
+	}
+	if len(created) != 2 {
+		t.Errorf("Expected two components, got %v", created)
+	}
+
+	if _, err := newRegistry(created).Load(filepath.Join(dir, "system.toml")); err == nil {
+		t.Error("Expected a missing file to fail")
+	}
+}
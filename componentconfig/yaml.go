@@ -0,0 +1,279 @@
+package componentconfig
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// yamlLine is a significant line of a YAML document
+type yamlLine struct {
+	number int
+	indent int
+	text   string
+}
+
+// parseYAML decodes the YAML subset used by definitions: block mappings and
+// sequences nested by indentation, flow sequences, quoted and plain scalars,
+// and comments
+func parseYAML(document string) (interface{}, error) {
+	var lines []yamlLine
+	for i, raw := range strings.Split(document, "\n") {
+		text := stripComment(strings.TrimRight(raw, " \r"))
+		trimmed := strings.TrimLeft(text, " ")
+		if strings.TrimSpace(trimmed) == "" || trimmed == "---" {
+			continue
+		}
+		if strings.HasPrefix(trimmed, "\t") || strings.HasPrefix(text, "\t") {
+			return nil, fmt.Errorf("yaml line %d: tabs are not allowed for indentation", i+1)
+		}
+		lines = append(lines, yamlLine{number: i + 1, indent: len(text) - len(trimmed), text: strings.TrimRight(trimmed, " ")})
+	}
+	if len(lines) == 0 {
+		return map[string]interface{}{}, nil
+	}
+
+	value, next, err := parseBlock(lines, 0, lines[0].indent)
+	if err != nil {
+		return nil, err
+	}
+	if next < len(lines) {
+		return nil, fmt.Errorf("yaml line %d: unexpected indentation", lines[next].number)
+	}
+	return value, nil
+}
+
+// parseBlock parses the mapping or sequence starting at lines[i]
+func parseBlock(lines []yamlLine, i, indent int) (interface{}, int, error) {
+	if isSequenceItem(lines[i].text) {
+		return parseSequence(lines, i, indent)
+	}
+	return parseMapping(lines, i, indent)
+}
+
+func isSequenceItem(text string) bool {
+	return text == "-" || strings.HasPrefix(text, "- ")
+}
+
+func parseSequence(lines []yamlLine, i, indent int) (interface{}, int, error) {
+	items := []interface{}{}
+	for i < len(lines) && lines[i].indent == indent && isSequenceItem(lines[i].text) {
+		content := strings.TrimSpace(strings.TrimPrefix(lines[i].text, "-"))
+		switch {
+		case content == "":
+			// The item is the block nested below
+			if i+1 < len(lines) && lines[i+1].indent > indent {
+				value, next, err := parseBlock(lines, i+1, lines[i+1].indent)
+				if err != nil {
+					return nil, 0, err
+				}
+				items = append(items, value)
+				i = next
+				continue
+			}
+			items = append(items, nil)
+			i++
+		case isMappingEntry(content):
+			// "- key: value" starts a mapping indented past the dash
+			itemIndent := indent + len(lines[i].text) - len(content)
+			rewritten := append([]yamlLine(nil), lines...)
+			rewritten[i] = yamlLine{number: lines[i].number, indent: itemIndent, text: content}
+			value, next, err := parseMapping(rewritten, i, itemIndent)
+			if err != nil {
+				return nil, 0, err
+			}
+			items = append(items, value)
+			i = next
+		default:
+			value, err := parseScalar(content, lines[i].number)
+			if err != nil {
+				return nil, 0, err
+			}
+			items = append(items, value)
+			i++
+		}
+	}
+	return items, i, nil
+}
+
+func parseMapping(lines []yamlLine, i, indent int) (interface{}, int, error) {
+	mapping := map[string]interface{}{}
+	for i < len(lines) && lines[i].indent == indent {
+		line := lines[i]
+		if !isMappingEntry(line.text) {
+			return nil, 0, fmt.Errorf("yaml line %d: expected \"key: value\", got %q", line.number, line.text)
+		}
+		key, rest := splitEntry(line.text)
+		key, err := unquoteKey(key, line.number)
+		if err != nil {
+			return nil, 0, err
+		}
+		if _, exists := mapping[key]; exists {
+			return nil, 0, fmt.Errorf("yaml line %d: duplicate key %s", line.number, key)
+		}
+
+		i++
+		if rest != "" {
+			value, err := parseScalar(rest, line.number)
+			if err != nil {
+				return nil, 0, err
+			}
+			mapping[key] = value
+			continue
+		}
+
+		// The value is the block nested below; a sequence may sit at the
+		// indentation of its key
+		if i < len(lines) && (lines[i].indent > indent || (lines[i].indent == indent && isSequenceItem(lines[i].text))) {
+			value, next, err := parseBlock(lines, i, lines[i].indent)
+			if err != nil {
+				return nil, 0, err
+			}
+			mapping[key] = value
+			i = next
+			continue
+		}
+		mapping[key] = nil
+	}
+	if i < len(lines) && lines[i].indent > indent {
+		return nil, 0, fmt.Errorf("yaml line %d: unexpected indentation", lines[i].number)
+	}
+	return mapping, i, nil
+}
+
+// isMappingEntry reports whether text is "key:" or "key: value" outside quotes
+func isMappingEntry(text string) bool {
+	key, _ := splitEntry(text)
+	return key != ""
+}
+
+// splitEntry splits "key: value" at the first colon followed by a space or
+// the end of the line, outside quotes
+func splitEntry(text string) (string, string) {
+	if strings.HasPrefix(text, "[") || strings.HasPrefix(text, "{") {
+		return "", ""
+	}
+	var quote byte
+	for i := 0; i < len(text); i++ {
+		switch c := text[i]; {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			if i == 0 {
+				quote = c
+			}
+		case c == ':' && (i+1 == len(text) || text[i+1] == ' '):
+			return strings.TrimSpace(text[:i]), strings.TrimSpace(text[i+1:])
+		}
+	}
+	return "", ""
+}
+
+func unquoteKey(key string, number int) (string, error) {
+	value, err := parseScalar(key, number)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprint(value), nil
+}
+
+// parseScalar decodes a quoted, plain or flow value
+func parseScalar(text string, number int) (interface{}, error) {
+	switch {
+	case strings.HasPrefix(text, `"`):
+		value, err := strconv.Unquote(text)
+		if err != nil {
+			return nil, fmt.Errorf("yaml line %d: invalid quoted string %s", number, text)
+		}
+		return value, nil
+	case strings.HasPrefix(text, "'"):
+		if len(text) < 2 || !strings.HasSuffix(text, "'") {
+			return nil, fmt.Errorf("yaml line %d: invalid quoted string %s", number, text)
+		}
+		return strings.ReplaceAll(text[1:len(text)-1], "''", "'"), nil
+	case strings.HasPrefix(text, "["):
+		return parseFlowSequence(text, number)
+	case text == "{}":
+		return map[string]interface{}{}, nil
+	case strings.HasPrefix(text, "{"):
+		return nil, fmt.Errorf("yaml line %d: flow mappings are not supported", number)
+	case text == "~" || text == "null":
+		return nil, nil
+	case text == "true":
+		return true, nil
+	case text == "false":
+		return false, nil
+	}
+	if n, err := strconv.ParseInt(text, 10, 64); err == nil {
+		return n, nil
+	}
+	if f, err := strconv.ParseFloat(text, 64); err == nil {
+		return f, nil
+	}
+	return text, nil
+}
+
+// parseFlowSequence decodes "[a, b]" holding scalars
+func parseFlowSequence(text string, number int) (interface{}, error) {
+	if !strings.HasSuffix(text, "]") {
+		return nil, fmt.Errorf("yaml line %d: unterminated flow sequence %s", number, text)
+	}
+	items := []interface{}{}
+	inner := strings.TrimSpace(text[1 : len(text)-1])
+	if inner == "" {
+		return items, nil
+	}
+
+	var quote byte
+	start := 0
+	for i := 0; i <= len(inner); i++ {
+		if i < len(inner) {
+			c := inner[i]
+			if quote != 0 {
+				if c == quote {
+					quote = 0
+				}
+				continue
+			}
+			if c == '"' || c == '\'' {
+				quote = c
+				continue
+			}
+			if c == '[' || c == '{' {
+				return nil, fmt.Errorf("yaml line %d: nested flow collections are not supported", number)
+			}
+			if c != ',' {
+				continue
+			}
+		}
+		value, err := parseScalar(strings.TrimSpace(inner[start:i]), number)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, value)
+		start = i + 1
+	}
+	return items, nil
+}
+
+// stripComment removes a comment starting with " #" or at the line start,
+// outside quotes
+func stripComment(text string) string {
+	var quote byte
+	for i := 0; i < len(text); i++ {
+		c := text[i]
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '#' && (i == 0 || text[i-1] == ' '):
+			return text[:i]
+		}
+	}
+	return text
+}
@@ -0,0 +1,65 @@
+package componentconfig
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseYAML(t *testing.T) {
+	document := `
+name: "quoted # not a comment" # trailing comment
+single: 'it''s'
+count: 3
+ratio: 0.5
+enabled: true
+missing: ~
+empty: {}
+flow: [a, "b, c", 2]
+nested:
+  list:
+  - one
+  - key: value
+    other: 2
+  -
+    - deep
+`
+	got, err := parseYAML(document)
+	if err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+
+	want := map[string]interface{}{
+		"name":    "quoted # not a comment",
+		"single":  "it's",
+		"count":   int64(3),
+		"ratio":   0.5,
+		"enabled": true,
+		"missing": nil,
+		"empty":   map[string]interface{}{},
+		"flow":    []interface{}{"a", "b, c", int64(2)},
+		"nested": map[string]interface{}{
+			"list": []interface{}{
+				"one",
+				map[string]interface{}{"key": "value", "other": int64(2)},
+				[]interface{}{"deep"},
+			},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected %#v, got %#v", want, got)
+	}
+}
+
+func TestParseYAMLErrors(t *testing.T) {
+	for name, document := range map[string]string{
+		"tab":       "a:\n\tb: 1\n",
+		"duplicate": "a: 1\na: 2\n",
+		"indent":    "a: 1\n  b: 2\n",
+		"flow map":  "a: {b: 1}\n",
+		"no key":    "a:\n  just text\n",
+	} {
+		if _, err := parseYAML(document); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
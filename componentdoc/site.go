@@ -0,0 +1,207 @@
+// Package componentdoc generates architecture documentation from the
+// metadata of a component system
+package componentdoc
+
+import (
+	htmltemplate "html/template"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	texttemplate "text/template"
+
+	"github.com/leandroolgomes/golang-dependency-graph/component"
+)
+
+// Format selects the kind of site to generate
+type Format int
+
+const (
+	Markdown Format = iota
+	HTML
+)
+
+// Page documents one component
+type Page struct {
+	component.TopologyNode
+	// File is the name of the page in the site directory and Href the
+	// relative link to it
+	File         string
+	Href         string
+	Dependencies []*Page
+	Dependents   []*Page
+}
+
+// Site is the documentation of a whole system
+type Site struct {
+	Title string
+	Pages []*Page
+}
+
+// NewSite builds the documentation model of a topology, linking every page
+// to the pages of its dependencies and dependents
+func NewSite(title string, topology component.Topology, format Format) *Site {
+	ext := ".md"
+	if format == HTML {
+		ext = ".html"
+	}
+
+	site := &Site{Title: title}
+	byKey := make(map[string]*Page, len(topology.Nodes))
+	for _, node := range topology.Nodes {
+		file := url.PathEscape(node.Key) + ext
+		page := &Page{TopologyNode: node, File: file, Href: url.PathEscape(file)}
+		byKey[node.Key] = page
+		site.Pages = append(site.Pages, page)
+	}
+	for _, edge := range topology.Edges {
+		from, to := byKey[edge.From], byKey[edge.To]
+		from.Dependencies = append(from.Dependencies, to)
+		to.Dependents = append(to.Dependents, from)
+	}
+	return site
+}
+
+// Generate writes the documentation site of the system into dir: an index
+// listing every component and one page per component
+func Generate(dir, title string, system *component.System, format Format) error {
+	site := NewSite(title, system.Topology(), format)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+
+	index := "index.md"
+	if format == HTML {
+		index = "index.html"
+	}
+	if err := writeFile(filepath.Join(dir, index), func(w io.Writer) error {
+		return execute(w, format, "index", site)
+	}); err != nil {
+		return err
+	}
+
+	for _, page := range site.Pages {
+		data := struct {
+			Site *Site
+			*Page
+		}{site, page}
+		if err := writeFile(filepath.Join(dir, page.File), func(w io.Writer) error {
+			return execute(w, format, "page", data)
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeFile creates a file and fills it with write
+func writeFile(path string, write func(io.Writer) error) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := write(f); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// execute renders a named template in the requested format
+func execute(w io.Writer, format Format, name string, data interface{}) error {
+	if format == HTML {
+		return htmlTemplates.ExecuteTemplate(w, name, data)
+	}
+	return markdownTemplates.ExecuteTemplate(w, name, data)
+}
+
+var funcs = map[string]interface{}{
+	"join": strings.Join,
+}
+
+var markdownTemplates = texttemplate.Must(texttemplate.New("").Funcs(funcs).Parse(`
+{{- define "index" -}}
+# {{.Title}}
+
+| Component | Description | Owner | Tags |
+|-----------|-------------|-------|------|
+{{range .Pages}}| [{{.Key}}]({{.Href}}) | {{.Metadata.Description}} | {{.Metadata.Owner}} | {{join .Tags ", "}} |
+{{end -}}
+{{end}}
+
+{{- define "page" -}}
+# {{.Key}}
+
+[{{.Site.Title}}](index.md)
+{{with .Metadata.Description}}
+{{.}}
+{{end}}
+{{- with .Metadata.Owner}}
+**Owner:** {{.}}
+{{end}}
+{{- with .Tags}}
+**Tags:** {{join . ", "}}
+{{end}}
+## Dependencies
+{{range .Dependencies}}
+- [{{.Key}}]({{.Href}})
+{{- else}}
+None
+{{- end}}
+
+## Dependents
+{{range .Dependents}}
+- [{{.Key}}]({{.Href}})
+{{- else}}
+None
+{{- end}}
+{{with .Metadata.Links}}
+## Links
+{{range .}}
+- <{{.}}>
+{{- end}}
+{{end -}}
+{{end}}
+`))
+
+var htmlTemplates = htmltemplate.Must(htmltemplate.New("").Funcs(funcs).Parse(`
+{{- define "index" -}}
+<!DOCTYPE html>
+<html>
+<head><meta charset="utf-8"><title>{{.Title}}</title></head>
+<body>
+<h1>{{.Title}}</h1>
+<table>
+<tr><th>Component</th><th>Description</th><th>Owner</th><th>Tags</th></tr>
+{{range .Pages}}<tr><td><a href="{{.Href}}">{{.Key}}</a></td><td>{{.Metadata.Description}}</td><td>{{.Metadata.Owner}}</td><td>{{join .Tags ", "}}</td></tr>
+{{end -}}
+</table>
+</body>
+</html>
+{{end}}
+
+{{- define "page" -}}
+<!DOCTYPE html>
+<html>
+<head><meta charset="utf-8"><title>{{.Key}} - {{.Site.Title}}</title></head>
+<body>
+<p><a href="index.html">{{.Site.Title}}</a></p>
+<h1>{{.Key}}</h1>
+{{with .Metadata.Description}}<p>{{.}}</p>
+{{end}}
+{{- with .Metadata.Owner}}<p><strong>Owner:</strong> {{.}}</p>
+{{end}}
+{{- with .Tags}}<p><strong>Tags:</strong> {{join . ", "}}</p>
+{{end -}}
+<h2>Dependencies</h2>
+<ul>{{range .Dependencies}}<li><a href="{{.Href}}">{{.Key}}</a></li>{{else}}<li>None</li>{{end}}</ul>
+<h2>Dependents</h2>
+<ul>{{range .Dependents}}<li><a href="{{.Href}}">{{.Key}}</a></li>{{else}}<li>None</li>{{end}}</ul>
+{{with .Metadata.Links}}<h2>Links</h2>
+<ul>{{range .}}<li><a href="{{.}}">{{.}}</a></li>{{end}}</ul>
+{{end -}}
+</body>
+</html>
+{{end}}
+`))
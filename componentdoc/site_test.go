@@ -0,0 +1,79 @@
+package componentdoc
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/leandroolgomes/golang-dependency-graph/component"
+)
+
+type fake struct{}
+
+func (f *fake) Start(ctx component.Context) (component.Lifecycle, error) {
+	return f, nil
+}
+
+func (f *fake) Stop(ctx component.Context) error {
+	return nil
+}
+
+func docSystem() *component.System {
+	return component.CreateSystem(map[string]*component.Component{
+		"db": component.Define("db", &fake{}).WithTags("storage").WithMetadata(component.Metadata{
+			Description: "Primary <postgres> database",
+			Owner:       "data-team",
+			Links:       []string{"https://wiki.example.com/db"},
+		}),
+		"http/server": component.Define("http/server", &fake{}, "db"),
+	})
+}
+
+func TestGenerateMarkdown(t *testing.T) {
+	dir := t.TempDir()
+	if err := Generate(dir, "Shop", docSystem(), Markdown); err != nil {
+		t.Fatalf("Failed to generate: %v", err)
+	}
+
+	index := readFile(t, filepath.Join(dir, "index.md"))
+	if !strings.Contains(index, "| [db](db.md) | Primary <postgres> database | data-team | storage |") {
+		t.Errorf("Unexpected index:\n%s", index)
+	}
+
+	db := readFile(t, filepath.Join(dir, "db.md"))
+	for _, want := range []string{"# db", "**Owner:** data-team", "## Dependents\n\n- [http/server](http%252Fserver.md)", "- <https://wiki.example.com/db>"} {
+		if !strings.Contains(db, want) {
+			t.Errorf("Expected %q in page:\n%s", want, db)
+		}
+	}
+
+	server := readFile(t, filepath.Join(dir, "http%2Fserver.md"))
+	if !strings.Contains(server, "## Dependencies\n\n- [db](db.md)") || !strings.Contains(server, "## Dependents\n\nNone") {
+		t.Errorf("Unexpected page:\n%s", server)
+	}
+}
+
+func TestGenerateHTML(t *testing.T) {
+	dir := t.TempDir()
+	if err := Generate(dir, "Shop", docSystem(), HTML); err != nil {
+		t.Fatalf("Failed to generate: %v", err)
+	}
+
+	db := readFile(t, filepath.Join(dir, "db.html"))
+	if !strings.Contains(db, "Primary &lt;postgres&gt; database") {
+		t.Errorf("Expected metadata to be escaped:\n%s", db)
+	}
+	if !strings.Contains(db, `<a href="http%252Fserver.html">http/server</a>`) {
+		t.Errorf("Expected a link to the dependent:\n%s", db)
+	}
+}
+
+func readFile(t *testing.T, path string) string {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read %s: %v", path, err)
+	}
+	return string(data)
+}
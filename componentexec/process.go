@@ -0,0 +1,387 @@
+// Package componentexec runs external processes as components, so services
+// written in other languages take part in the dependency graph: they start
+// after their dependencies, are probed until ready, report their health,
+// stop with a signal and are restarted when they exit
+package componentexec
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"os/exec"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/leandroolgomes/golang-dependency-graph/component"
+)
+
+const (
+	// DefaultStartTimeout bounds the wait for a process to become ready
+	DefaultStartTimeout = 30 * time.Second
+
+	// DefaultStopTimeout is how long a process may take to exit after its
+	// stop signal before it is killed
+	DefaultStopTimeout = 10 * time.Second
+
+	// DefaultProbeInterval is the pause between two readiness probes
+	DefaultProbeInterval = 100 * time.Millisecond
+
+	// DefaultRestartBackoff is the pause before the first restart; it
+	// doubles with every restart after it
+	DefaultRestartBackoff = 100 * time.Millisecond
+)
+
+var (
+	// ErrNotRunning is reported by Health while the process is not running
+	ErrNotRunning = errors.New("process is not running")
+
+	// ErrKilled is reported by Stop for a process that did not exit after
+	// its stop signal and had to be killed
+	ErrKilled = errors.New("process killed after stop timeout")
+)
+
+// Probe checks an external process, returning nil when it is ready or healthy
+type Probe func(ctx context.Context) error
+
+// HTTPProbe is a Probe that succeeds when a GET on the URL answers with a
+// status below 400
+func HTTPProbe(url string) Probe {
+	return func(ctx context.Context) error {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			return err
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			return err
+		}
+		resp.Body.Close()
+		if resp.StatusCode >= http.StatusBadRequest {
+			return fmt.Errorf("probe %s answered %s", url, resp.Status)
+		}
+		return nil
+	}
+}
+
+// TCPProbe is a Probe that succeeds when the address accepts connections
+func TCPProbe(addr string) Probe {
+	return func(ctx context.Context) error {
+		var dialer net.Dialer
+		conn, err := dialer.DialContext(ctx, "tcp", addr)
+		if err != nil {
+			return err
+		}
+		return conn.Close()
+	}
+}
+
+// RestartPolicy decides whether a process that exited on its own is started again
+type RestartPolicy int
+
+const (
+	// RestartNever leaves an exited process down, failing its health
+	RestartNever RestartPolicy = iota
+
+	// RestartOnFailure restarts a process that exited with an error
+	RestartOnFailure
+
+	// RestartAlways restarts a process however it exited
+	RestartAlways
+)
+
+// Process is a component running an external command. Its Start launches
+// the command and waits for ReadyProbe; its Stop sends StopSignal and kills
+// the process if it has not exited within StopTimeout or the component's
+// stop deadline, whichever is sooner
+type Process struct {
+	// Path and Args are the command, as for exec.Command
+	Path string
+	Args []string
+
+	// Env is added to the environment of the current process; Dir is the
+	// working directory, the current one when empty
+	Env []string
+	Dir string
+
+	// Stdout and Stderr receive the output of the process, discarded when nil
+	Stdout io.Writer
+	Stderr io.Writer
+
+	// ReadyProbe is probed every ProbeInterval after launch until it
+	// succeeds, for at most StartTimeout. Without one the process is ready
+	// once launched
+	ReadyProbe    Probe
+	ProbeInterval time.Duration
+	StartTimeout  time.Duration
+
+	// HealthProbe is called by the system's health checks while the
+	// process is running; without one Health only checks that it runs
+	HealthProbe Probe
+
+	// StopSignal defaults to SIGTERM and StopTimeout to DefaultStopTimeout
+	StopSignal  os.Signal
+	StopTimeout time.Duration
+
+	// Restart is the policy for a process exiting on its own. At most
+	// MaxRestarts restarts are made, none when zero, with a backoff starting
+	// at RestartBackoff and doubling every time
+	Restart        RestartPolicy
+	MaxRestarts    int
+	RestartBackoff time.Duration
+
+	mu       sync.Mutex
+	cmd      *exec.Cmd
+	running  bool
+	lastExit error
+	restarts int
+	quit     chan struct{}
+	finished chan struct{}
+}
+
+// New creates a component running the command with its arguments
+func New(path string, args ...string) *Process {
+	return &Process{Path: path, Args: args}
+}
+
+// Start launches the process and waits until it is ready
+func (p *Process) Start(ctx component.Context) (component.Lifecycle, error) {
+	p.mu.Lock()
+	p.quit = make(chan struct{})
+	p.finished = make(chan struct{})
+	p.restarts = 0
+	p.mu.Unlock()
+
+	cmd, exited, err := p.launch()
+	if err != nil {
+		close(p.finished)
+		return nil, err
+	}
+	if err := p.waitReady(exited); err != nil {
+		p.interrupt()
+		cmd.Process.Kill()
+		p.mu.Lock()
+		p.running, p.lastExit = false, <-exited
+		p.mu.Unlock()
+		close(p.finished)
+		return nil, err
+	}
+
+	go p.supervise(exited)
+	return p, nil
+}
+
+// launch starts the command unless the process is stopping. The returned
+// channel receives the result of waiting for the process to exit
+func (p *Process) launch() (*exec.Cmd, chan error, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	select {
+	case <-p.quit:
+		return nil, nil, ErrNotRunning
+	default:
+	}
+
+	cmd := exec.Command(p.Path, p.Args...)
+	cmd.Env = append(os.Environ(), p.Env...)
+	cmd.Dir = p.Dir
+	cmd.Stdout = p.Stdout
+	cmd.Stderr = p.Stderr
+	if err := cmd.Start(); err != nil {
+		return nil, nil, fmt.Errorf("failed to start %s: %w", p.Path, err)
+	}
+
+	exited := make(chan error, 1)
+	go func() { exited <- cmd.Wait() }()
+	p.cmd = cmd
+	p.running = true
+	return cmd, exited, nil
+}
+
+// waitReady probes the process until ReadyProbe succeeds, the process
+// exits or the start timeout elapses
+func (p *Process) waitReady(exited chan error) error {
+	if p.ReadyProbe == nil {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), or(p.StartTimeout, DefaultStartTimeout))
+	defer cancel()
+	ticker := time.NewTicker(or(p.ProbeInterval, DefaultProbeInterval))
+	defer ticker.Stop()
+
+	for {
+		err := p.ReadyProbe(ctx)
+		if err == nil {
+			return nil
+		}
+		select {
+		case exitErr := <-exited:
+			// Keep the result for the caller waiting on the exit
+			exited <- exitErr
+			return fmt.Errorf("%s exited before it was ready: %v", p.Path, exitErr)
+		case <-ctx.Done():
+			return fmt.Errorf("%s not ready after %v: %w", p.Path, or(p.StartTimeout, DefaultStartTimeout), err)
+		case <-ticker.C:
+		}
+	}
+}
+
+// supervise waits for the process to exit and restarts it as the policy
+// allows, until Stop is called
+func (p *Process) supervise(exited chan error) {
+	defer close(p.finished)
+
+	for {
+		err := <-exited
+		p.mu.Lock()
+		p.running = false
+		p.lastExit = err
+		restart := p.shouldRestart(err)
+		backoff := or(p.RestartBackoff, DefaultRestartBackoff) << p.restarts
+		if restart {
+			p.restarts++
+		}
+		p.mu.Unlock()
+		if !restart {
+			return
+		}
+
+		select {
+		case <-p.quit:
+			return
+		case <-time.After(backoff):
+		}
+		_, next, err := p.launch()
+		if err != nil {
+			p.mu.Lock()
+			p.lastExit = err
+			p.mu.Unlock()
+			return
+		}
+		exited = next
+	}
+}
+
+// shouldRestart applies the restart policy to an exit; the caller must hold p.mu
+func (p *Process) shouldRestart(exitErr error) bool {
+	select {
+	case <-p.quit:
+		return false
+	default:
+	}
+	if p.restarts >= p.MaxRestarts {
+		return false
+	}
+	switch p.Restart {
+	case RestartAlways:
+		return true
+	case RestartOnFailure:
+		return exitErr != nil
+	default:
+		return false
+	}
+}
+
+// interrupt stops restarts and returns the running command, if any
+func (p *Process) interrupt() *exec.Cmd {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.quit == nil {
+		return nil
+	}
+	select {
+	case <-p.quit:
+	default:
+		close(p.quit)
+	}
+	if !p.running {
+		return nil
+	}
+	return p.cmd
+}
+
+// Stop signals the process and waits for it to exit, killing it once the
+// stop timeout or the component's stop deadline has passed
+func (p *Process) Stop(ctx component.Context) error {
+	cmd := p.interrupt()
+	if cmd == nil {
+		if p.finished != nil {
+			<-p.finished
+		}
+		return nil
+	}
+
+	timeout := or(p.StopTimeout, DefaultStopTimeout)
+	if deadline, ok := ctx.StopDeadline(); ok {
+		timeout = min(timeout, time.Until(deadline))
+	}
+	signal := p.StopSignal
+	if signal == nil {
+		signal = syscall.SIGTERM
+	}
+	if err := cmd.Process.Signal(signal); err != nil && !errors.Is(err, os.ErrProcessDone) {
+		return fmt.Errorf("failed to signal %s: %w", p.Path, err)
+	}
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	select {
+	case <-p.finished:
+		return nil
+	case <-timer.C:
+	}
+	cmd.Process.Kill()
+	<-p.finished
+	return fmt.Errorf("%s did not exit within %v: %w", p.Path, timeout, ErrKilled)
+}
+
+// Health reports whether the process is running and passes its Health probe,
+// implementing component.HealthChecker
+func (p *Process) Health(ctx context.Context) error {
+	p.mu.Lock()
+	running, lastExit := p.running, p.lastExit
+	p.mu.Unlock()
+
+	if !running {
+		if lastExit != nil {
+			return fmt.Errorf("%w: %v", ErrNotRunning, lastExit)
+		}
+		return ErrNotRunning
+	}
+	if p.HealthProbe != nil {
+		return p.HealthProbe(ctx)
+	}
+	return nil
+}
+
+// PID returns the process ID of the running process, or 0 when none is running
+func (p *Process) PID() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if !p.running {
+		return 0
+	}
+	return p.cmd.Process.Pid
+}
+
+// Restarts returns how many times the process was restarted since Start
+func (p *Process) Restarts() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.restarts
+}
+
+// or returns d, or fallback when d is not set
+func or(d, fallback time.Duration) time.Duration {
+	if d <= 0 {
+		return fallback
+	}
+	return d
+}
@@ -0,0 +1,159 @@
+package componentexec
+
+import (
+	"context"
+	"errors"
+	"net"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/leandroolgomes/golang-dependency-graph/component"
+)
+
+// TestMain turns the test binary into the external process under test when
+// HELPER_PROCESS names a behavior
+func TestMain(m *testing.M) {
+	switch os.Getenv("HELPER_PROCESS") {
+	case "":
+		os.Exit(m.Run())
+	case "serve":
+		// Listen until asked to stop
+		listener, err := net.Listen("tcp", os.Getenv("HELPER_ADDR"))
+		if err != nil {
+			os.Exit(2)
+		}
+		defer listener.Close()
+		signals := make(chan os.Signal, 1)
+		signal.Notify(signals, syscall.SIGTERM)
+		<-signals
+	case "stubborn":
+		// Ignore the stop signal, then report ready
+		signal.Ignore(syscall.SIGTERM)
+		listener, err := net.Listen("tcp", os.Getenv("HELPER_ADDR"))
+		if err != nil {
+			os.Exit(2)
+		}
+		defer listener.Close()
+		time.Sleep(time.Minute)
+	case "crash":
+		os.Exit(3)
+	}
+}
+
+func helper(behavior string, env ...string) *Process {
+	process := New(os.Args[0], "-test.run=^$")
+	process.Env = append([]string{"HELPER_PROCESS=" + behavior}, env...)
+	return process
+}
+
+func freeAddr(t *testing.T) string {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer listener.Close()
+	return listener.Addr().String()
+}
+
+// dependent records whether the process was reachable when it started
+type dependent struct {
+	addr      string
+	reachable error
+}
+
+func (d *dependent) Start(ctx component.Context) (component.Lifecycle, error) {
+	d.reachable = TCPProbe(d.addr)(context.Background())
+	return d, nil
+}
+
+func (d *dependent) Stop(ctx component.Context) error {
+	return nil
+}
+
+func TestProcessInSystem(t *testing.T) {
+	addr := freeAddr(t)
+	process := helper("serve", "HELPER_ADDR="+addr)
+	process.ReadyProbe = TCPProbe(addr)
+	process.ProbeInterval = 10 * time.Millisecond
+
+	api := &dependent{addr: addr}
+	system := component.CreateSystem(map[string]*component.Component{
+		"broker": component.Define("broker", process),
+		"api":    component.Define("api", api, "broker"),
+	})
+	if err := system.Start(); err != nil {
+		t.Fatalf("Failed to start system: %v", err)
+	}
+	if api.reachable != nil {
+		t.Errorf("Expected the process ready before its dependents start, got %v", api.reachable)
+	}
+	if health, _ := system.Health().Component("broker"); health.Status != component.HealthHealthy || !health.Checked || process.PID() == 0 {
+		t.Errorf("Expected a healthy running process, got %+v", health)
+	}
+
+	if err := system.Stop(); err != nil {
+		t.Fatalf("Expected the process to exit on SIGTERM, got %v", err)
+	}
+	if err := process.Health(context.Background()); !errors.Is(err, ErrNotRunning) {
+		t.Errorf("Expected the process not running after Stop, got %v", err)
+	}
+}
+
+func TestProcessNotReady(t *testing.T) {
+	process := helper("crash")
+	process.ReadyProbe = TCPProbe(freeAddr(t))
+	process.ProbeInterval = 10 * time.Millisecond
+
+	_, err := process.Start(component.Context{})
+	if err == nil || !strings.Contains(err.Error(), "exited before it was ready") {
+		t.Fatalf("Expected the start to fail on the early exit, got %v", err)
+	}
+	if err := process.Stop(component.Context{}); err != nil {
+		t.Errorf("Expected nothing to stop, got %v", err)
+	}
+}
+
+func TestProcessRestartPolicy(t *testing.T) {
+	process := helper("crash")
+	process.Restart = RestartOnFailure
+	process.MaxRestarts = 2
+	process.RestartBackoff = time.Millisecond
+	if _, err := process.Start(component.Context{}); err != nil {
+		t.Fatalf("Failed to start process: %v", err)
+	}
+
+	// The supervisor gives up once the restarts are used up
+	select {
+	case <-process.finished:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("Expected the restarts to be used up, got %d", process.Restarts())
+	}
+	if err := process.Health(context.Background()); !errors.Is(err, ErrNotRunning) || !strings.Contains(err.Error(), "exit status 3") || process.Restarts() != 2 {
+		t.Errorf("Expected the process left down after its last restart, got %v after %d restarts", err, process.Restarts())
+	}
+	if err := process.Stop(component.Context{}); err != nil {
+		t.Errorf("Expected an exited process to stop cleanly, got %v", err)
+	}
+}
+
+func TestProcessKilledAfterStopTimeout(t *testing.T) {
+	addr := freeAddr(t)
+	process := helper("stubborn", "HELPER_ADDR="+addr)
+	process.ReadyProbe = TCPProbe(addr)
+	process.ProbeInterval = 10 * time.Millisecond
+	process.StopTimeout = 50 * time.Millisecond
+	if _, err := process.Start(component.Context{}); err != nil {
+		t.Fatalf("Failed to start process: %v", err)
+	}
+
+	if err := process.Stop(component.Context{}); !errors.Is(err, ErrKilled) {
+		t.Errorf("Expected the process to be killed, got %v", err)
+	}
+	if process.PID() != 0 {
+		t.Error("Expected no process running after the kill")
+	}
+}
@@ -0,0 +1,202 @@
+// Package componentfix rewrites map-based system definitions to the
+// component.Builder API, in the manner of go fix
+package componentfix
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ImportPath is the import path of the component package
+const ImportPath = "github.com/leandroolgomes/golang-dependency-graph/component"
+
+// Finding reports a CreateSystem call site, rewritten or left alone
+type Finding struct {
+	Pos       token.Position
+	Rewritten bool
+	Message   string
+}
+
+func (f Finding) String() string {
+	return fmt.Sprintf("%s: %s", f.Pos, f.Message)
+}
+
+// Rewrite converts every component.CreateSystem(map[string]*Component{...},
+// opts...) call of a Go source file to
+// component.NewBuilder(opts...).Add(...).MustBuild(). A call is rewritten
+// only when every map key is a string literal equal to the key of the
+// component defined for it, so wiring is unchanged; the others are
+// reported and left alone. It returns the formatted source and whether it changed
+func Rewrite(filename string, src []byte) ([]byte, bool, []Finding, error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, filename, src, parser.ParseComments)
+	if err != nil {
+		return nil, false, nil, err
+	}
+	qualifier, ok := qualifierOf(file)
+	if !ok {
+		return src, false, nil, nil
+	}
+
+	type replacement struct {
+		start, end int
+		text       string
+	}
+	var replacements []replacement
+	var findings []Finding
+	offset := func(pos token.Pos) int { return fset.Position(pos).Offset }
+	source := func(node ast.Node) string { return string(src[offset(node.Pos()):offset(node.End())]) }
+
+	ast.Inspect(file, func(node ast.Node) bool {
+		call, ok := node.(*ast.CallExpr)
+		if !ok || !isCreateSystem(call, qualifier) {
+			return true
+		}
+		position := fset.Position(call.Pos())
+		components, problem := builderComponents(call)
+		if problem != "" {
+			findings = append(findings, Finding{Pos: position, Message: problem})
+			return true
+		}
+
+		var text strings.Builder
+		text.WriteString(qualifier + "NewBuilder(")
+		if len(call.Args) > 1 {
+			opts := string(src[offset(call.Args[1].Pos()):offset(call.Rparen)])
+			text.WriteString(strings.TrimRight(strings.TrimSpace(opts), ","))
+		}
+		text.WriteString(").Add(")
+		if len(components) > 0 {
+			text.WriteString("\n")
+		}
+		for _, c := range components {
+			text.WriteString(source(c) + ",\n")
+		}
+		text.WriteString(").MustBuild()")
+
+		replacements = append(replacements, replacement{start: offset(call.Pos()), end: offset(call.End()), text: text.String()})
+		findings = append(findings, Finding{Pos: position, Rewritten: true, Message: fmt.Sprintf("rewrote CreateSystem with %d components to NewBuilder", len(components))})
+		return false
+	})
+	if len(replacements) == 0 {
+		return src, false, findings, nil
+	}
+
+	sort.Slice(replacements, func(i, j int) bool { return replacements[i].start > replacements[j].start })
+	out := append([]byte(nil), src...)
+	for _, r := range replacements {
+		out = append(out[:r.start], append([]byte(r.text), out[r.end:]...)...)
+	}
+	formatted, err := format.Source(out)
+	if err != nil {
+		return nil, false, findings, fmt.Errorf("%s: formatting rewritten source: %w", filename, err)
+	}
+	return formatted, !bytes.Equal(formatted, src), findings, nil
+}
+
+// qualifierOf returns the prefix of component identifiers in a file: the
+// import name followed by a dot, or "" within the component package itself
+func qualifierOf(file *ast.File) (string, bool) {
+	for _, spec := range file.Imports {
+		if path, _ := strconv.Unquote(spec.Path.Value); path != ImportPath {
+			continue
+		}
+		switch {
+		case spec.Name == nil:
+			return "component.", true
+		case spec.Name.Name == "_":
+			return "", false
+		case spec.Name.Name == ".":
+			return "", true
+		default:
+			return spec.Name.Name + ".", true
+		}
+	}
+	return "", file.Name.Name == "component"
+}
+
+// isCreateSystem reports whether a call is component.CreateSystem
+func isCreateSystem(call *ast.CallExpr, qualifier string) bool {
+	if qualifier == "" {
+		ident, ok := call.Fun.(*ast.Ident)
+		return ok && ident.Name == "CreateSystem"
+	}
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok || sel.Sel.Name != "CreateSystem" {
+		return false
+	}
+	x, ok := sel.X.(*ast.Ident)
+	return ok && x.Name+"." == qualifier
+}
+
+// builderComponents returns the component expressions of a CreateSystem
+// call, or why it cannot be rewritten safely
+func builderComponents(call *ast.CallExpr) ([]ast.Expr, string) {
+	if len(call.Args) == 0 {
+		return nil, "CreateSystem without components"
+	}
+	literal, ok := call.Args[0].(*ast.CompositeLit)
+	if !ok {
+		return nil, "components are not a map literal; convert by hand"
+	}
+	if _, ok := literal.Type.(*ast.MapType); !ok {
+		return nil, "components are not a map literal; convert by hand"
+	}
+
+	var components []ast.Expr
+	for _, elt := range literal.Elts {
+		kv := elt.(*ast.KeyValueExpr)
+		mapKey, ok := stringLiteral(kv.Key)
+		if !ok {
+			return nil, "map key is not a string literal; convert by hand"
+		}
+		key, ok := definedKey(kv.Value)
+		if !ok {
+			return nil, fmt.Sprintf("cannot verify the key of the component under %q; convert by hand", mapKey)
+		}
+		if key != mapKey {
+			return nil, fmt.Sprintf("map key %q differs from component key %q; CreateSystem wires it as %q", mapKey, key, mapKey)
+		}
+		components = append(components, kv.Value)
+	}
+	return components, ""
+}
+
+// definedKey returns the key literal a component expression is defined
+// with: the first argument of the innermost call of a chain such as
+// component.Define("db", db, "config").WithTags("storage")
+func definedKey(expr ast.Expr) (string, bool) {
+	for {
+		call, ok := expr.(*ast.CallExpr)
+		if !ok {
+			return "", false
+		}
+		if sel, ok := call.Fun.(*ast.SelectorExpr); ok {
+			if inner, ok := sel.X.(*ast.CallExpr); ok {
+				expr = inner
+				continue
+			}
+		}
+		if len(call.Args) == 0 {
+			return "", false
+		}
+		return stringLiteral(call.Args[0])
+	}
+}
+
+// stringLiteral returns the value of a string literal expression
+func stringLiteral(expr ast.Expr) (string, bool) {
+	literal, ok := expr.(*ast.BasicLit)
+	if !ok || literal.Kind != token.STRING {
+		return "", false
+	}
+	value, err := strconv.Unquote(literal.Value)
+	return value, err == nil
+}
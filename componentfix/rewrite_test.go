@@ -0,0 +1,85 @@
+package componentfix
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRewrite(t *testing.T) {
+	src := `package main
+
+import cmp "github.com/leandroolgomes/golang-dependency-graph/component"
+
+func build(db, api cmp.Lifecycle, opts []cmp.Option) *cmp.System {
+	// the whole application
+	return cmp.CreateSystem(map[string]*cmp.Component{
+		"db":  cmp.Define("db", db),
+		"api": cmp.Define("api", api, "db").WithTags("edge"),
+	}, opts...)
+}
+`
+	want := `package main
+
+import cmp "github.com/leandroolgomes/golang-dependency-graph/component"
+
+func build(db, api cmp.Lifecycle, opts []cmp.Option) *cmp.System {
+	// the whole application
+	return cmp.NewBuilder(opts...).Add(
+		cmp.Define("db", db),
+		cmp.Define("api", api, "db").WithTags("edge"),
+	).MustBuild()
+}
+`
+	out, changed, findings, err := Rewrite("main.go", []byte(src))
+	if err != nil {
+		t.Fatalf("Failed to rewrite: %v", err)
+	}
+	if !changed || string(out) != want {
+		t.Errorf("Expected:\n%s\ngot:\n%s", want, out)
+	}
+	if len(findings) != 1 || !findings[0].Rewritten || findings[0].Pos.Line != 7 {
+		t.Errorf("Expected the rewritten call reported, got %v", findings)
+	}
+}
+
+func TestRewriteLeavesUnsafeCalls(t *testing.T) {
+	src := `package main
+
+import "github.com/leandroolgomes/golang-dependency-graph/component"
+
+var components = map[string]*component.Component{}
+
+func build(db component.Lifecycle, c *component.Component) {
+	component.CreateSystem(map[string]*component.Component{"database": component.Define("db", db)})
+	component.CreateSystem(map[string]*component.Component{"db": c})
+	component.CreateSystem(components)
+}
+`
+	out, changed, findings, err := Rewrite("main.go", []byte(src))
+	if err != nil {
+		t.Fatalf("Failed to rewrite: %v", err)
+	}
+	if changed || string(out) != src {
+		t.Errorf("Expected the source unchanged, got:\n%s", out)
+	}
+	for i, want := range []string{`map key "database" differs from component key "db"`, `cannot verify the key of the component under "db"`, "not a map literal"} {
+		if i >= len(findings) || findings[i].Rewritten || !strings.Contains(findings[i].Message, want) {
+			t.Errorf("Expected finding %d to contain %q, got %v", i, want, findings)
+		}
+	}
+}
+
+func TestRewriteWithinComponentPackage(t *testing.T) {
+	src := `package component
+
+func example() *System {
+	return CreateSystem(map[string]*Component{"db": Define("db", nil)})
+}
+`
+	out, changed, _, err := Rewrite("example.go", []byte(src))
+	if err != nil || !changed || !strings.Contains(string(out), `NewBuilder().Add(
+		Define("db", nil),
+	).MustBuild()`) {
+		t.Errorf("Expected the unqualified call rewritten, got %v:\n%s", err, out)
+	}
+}
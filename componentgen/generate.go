@@ -0,0 +1,198 @@
+// Package componentgen generates the skeleton of a new component: its type,
+// definition, Start and Stop, typed dependency accessors and a test built on
+// the componenttest harness, so components share one structure across teams
+package componentgen
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"go/token"
+	"strings"
+	"text/template"
+	"unicode"
+
+	"github.com/leandroolgomes/golang-dependency-graph/component"
+)
+
+// Dependency is a dependency of the generated component. Type is the Go type
+// its accessor asserts the dependency to, component.Lifecycle when empty
+type Dependency struct {
+	Key  string
+	Type string
+}
+
+// ParseDependency parses a dependency written as key or key:Type, e.g.
+// "db:*sql.DB"
+func ParseDependency(spec string) (Dependency, error) {
+	key, typ, _ := strings.Cut(spec, ":")
+	if key == "" {
+		return Dependency{}, fmt.Errorf("dependency %q has no key", spec)
+	}
+	return Dependency{Key: key, Type: typ}, nil
+}
+
+// Spec describes the component to generate
+type Spec struct {
+	// Package is the name of the Go package the files belong to
+	Package string
+
+	// Key is the component key, e.g. "invoice_store" or "billing/invoice_store"
+	Key string
+
+	Dependencies []Dependency
+}
+
+// Files are the generated sources
+type Files struct {
+	// Name is the base file name, e.g. "invoice_store.go"
+	Name   string
+	Source []byte
+
+	// TestName is the test file name, e.g. "invoice_store_test.go"
+	TestName string
+	Test     []byte
+}
+
+// Generate renders the component and its test
+func Generate(spec Spec) (Files, error) {
+	if err := spec.check(); err != nil {
+		return Files{}, err
+	}
+
+	data := templateData{Package: spec.Package, Key: spec.Key, Type: exported(name(spec.Key))}
+	seen := map[string]bool{"ctx": true, "c": true, "err": true}
+	for _, dep := range spec.Dependencies {
+		field := unexported(dep.Key)
+		if seen[field] {
+			field += "Dep"
+		}
+		seen[field] = true
+
+		typ := dep.Type
+		if typ == "" {
+			typ = "component.Lifecycle"
+		}
+		data.Dependencies = append(data.Dependencies, dependencyData{
+			Key:      dep.Key,
+			Field:    field,
+			Accessor: field + "Of",
+			Type:     typ,
+			Typed:    dep.Type != "",
+		})
+		if dep.Type != "" {
+			data.Typed = append(data.Typed, dep.Key)
+		}
+	}
+
+	source, err := render(sourceTemplate, data)
+	if err != nil {
+		return Files{}, err
+	}
+	test, err := render(testTemplate, data)
+	if err != nil {
+		return Files{}, err
+	}
+	base := strings.ToLower(snake(name(spec.Key)))
+	return Files{Name: base + ".go", Source: source, TestName: base + "_test.go", Test: test}, nil
+}
+
+// check rejects specs that cannot produce valid code
+func (spec Spec) check() error {
+	if !token.IsIdentifier(spec.Package) {
+		return fmt.Errorf("package name %q is not a Go identifier", spec.Package)
+	}
+	if spec.Key == "" || strings.HasPrefix(spec.Key, component.ReservedPrefix) {
+		return fmt.Errorf("component key %q is empty or uses the reserved prefix %s", spec.Key, component.ReservedPrefix)
+	}
+	if !token.IsIdentifier(exported(name(spec.Key))) {
+		return fmt.Errorf("component key %q does not name a Go type", spec.Key)
+	}
+	seen := make(map[string]bool)
+	for _, dep := range spec.Dependencies {
+		if dep.Key == spec.Key {
+			return fmt.Errorf("component %s cannot depend on itself", spec.Key)
+		}
+		if seen[dep.Key] {
+			return fmt.Errorf("dependency %s listed twice", dep.Key)
+		}
+		seen[dep.Key] = true
+		if !token.IsIdentifier(unexported(dep.Key)) {
+			return fmt.Errorf("dependency key %q does not name a Go identifier", dep.Key)
+		}
+	}
+	return nil
+}
+
+type templateData struct {
+	Package      string
+	Key          string
+	Type         string
+	Dependencies []dependencyData
+
+	// Typed lists the dependencies a Stub cannot stand in for
+	Typed []string
+}
+
+type dependencyData struct {
+	Key      string
+	Field    string
+	Accessor string
+	Type     string
+	Typed    bool
+}
+
+// render executes a template and formats the result
+func render(t *template.Template, data templateData) ([]byte, error) {
+	var b bytes.Buffer
+	if err := t.Execute(&b, data); err != nil {
+		return nil, err
+	}
+	formatted, err := format.Source(b.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("generated invalid code: %w", err)
+	}
+	return formatted, nil
+}
+
+// name returns the last segment of a namespaced key
+func name(key string) string {
+	if i := strings.LastIndexAny(key, "/."); i >= 0 {
+		return key[i+1:]
+	}
+	return key
+}
+
+// words splits a key on every rune that is not a letter or a digit
+func words(key string) []string {
+	return strings.FieldsFunc(key, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// exported returns the key in CamelCase, e.g. "invoice_store" as InvoiceStore
+func exported(key string) string {
+	var b strings.Builder
+	for _, word := range words(key) {
+		b.WriteString(strings.ToUpper(word[:1]) + word[1:])
+	}
+	return b.String()
+}
+
+// unexported returns the key in camelCase, e.g. "db.read" as dbRead
+func unexported(key string) string {
+	camel := exported(key)
+	if camel == "" {
+		return ""
+	}
+	identifier := strings.ToLower(camel[:1]) + camel[1:]
+	if token.IsKeyword(identifier) {
+		identifier += "Dep"
+	}
+	return identifier
+}
+
+// snake returns the key in snake_case, e.g. "invoice-store" as invoice_store
+func snake(key string) string {
+	return strings.Join(words(key), "_")
+}
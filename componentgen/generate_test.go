@@ -0,0 +1,89 @@
+package componentgen
+
+import (
+	"go/parser"
+	"go/token"
+	"strings"
+	"testing"
+)
+
+func TestGenerate(t *testing.T) {
+	db, _ := ParseDependency("db:*Database")
+	files, err := Generate(Spec{
+		Package:      "billing",
+		Key:          "billing/invoice_store",
+		Dependencies: []Dependency{db, {Key: "cache.read"}, {Key: "type"}},
+	})
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	if files.Name != "invoice_store.go" || files.TestName != "invoice_store_test.go" {
+		t.Errorf("Expected invoice_store files, got %s and %s", files.Name, files.TestName)
+	}
+
+	for name, src := range map[string][]byte{files.Name: files.Source, files.TestName: files.Test} {
+		if _, err := parser.ParseFile(token.NewFileSet(), name, src, 0); err != nil {
+			t.Errorf("Expected %s to parse, got %v", name, err)
+		}
+	}
+
+	source := string(files.Source)
+	for _, want := range []string{
+		"package billing",
+		`const InvoiceStoreKey = "billing/invoice_store"`,
+		"\tdb        *Database\n",
+		"\tcacheRead component.Lifecycle\n",
+		"\ttypeDep   component.Lifecycle\n",
+		`component.Define(InvoiceStoreKey, new(InvoiceStore), "db", "cache.read", "type")`,
+		"func dbOf(ctx component.Context) (*Database, error) {",
+		`component.DependencyAs[*Database](ctx, "db")`,
+		"func typeDepOf(ctx component.Context) (component.Lifecycle, error) {",
+		"ctx.StopDeadline()",
+	} {
+		if !strings.Contains(source, want) {
+			t.Errorf("Expected the source to contain %q, got:\n%s", want, source)
+		}
+	}
+
+	test := string(files.Test)
+	for _, want := range []string{
+		"func TestInvoiceStore(t *testing.T) {",
+		`t.Skip("TODO: add fakes for the typed dependencies db")`,
+		"componenttest.Isolate(system, InvoiceStoreKey, fakes)",
+	} {
+		if !strings.Contains(test, want) {
+			t.Errorf("Expected the test to contain %q, got:\n%s", want, test)
+		}
+	}
+}
+
+func TestGenerateWithoutTypedDependencies(t *testing.T) {
+	files, err := Generate(Spec{Package: "app", Key: "worker"})
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	if !strings.Contains(string(files.Source), "type Worker struct{}") {
+		t.Errorf("Expected an empty component type, got:\n%s", files.Source)
+	}
+	if strings.Contains(string(files.Test), "t.Skip") {
+		t.Errorf("Expected the test to run with stubs only, got:\n%s", files.Test)
+	}
+}
+
+func TestGenerateRejectsInvalidSpecs(t *testing.T) {
+	for name, spec := range map[string]Spec{
+		"package":        {Package: "my-app", Key: "worker"},
+		"empty key":      {Package: "app"},
+		"reserved key":   {Package: "app", Key: "@worker"},
+		"self":           {Package: "app", Key: "worker", Dependencies: []Dependency{{Key: "worker"}}},
+		"duplicate":      {Package: "app", Key: "worker", Dependencies: []Dependency{{Key: "db"}, {Key: "db"}}},
+		"not identifier": {Package: "app", Key: "1worker"},
+	} {
+		if _, err := Generate(spec); err == nil {
+			t.Errorf("%s: expected the spec to be rejected", name)
+		}
+	}
+	if _, err := ParseDependency(":Store"); err == nil {
+		t.Error("Expected a dependency without key to be rejected")
+	}
+}
@@ -0,0 +1,100 @@
+package componentgen
+
+import "text/template"
+
+var sourceTemplate = template.Must(template.New("source").Parse(`package {{.Package}}
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/leandroolgomes/golang-dependency-graph/component"
+)
+
+// {{.Type}}Key is the key the {{.Type}} component is registered under
+const {{.Type}}Key = {{printf "%q" .Key}}
+
+// {{.Type}} is the {{.Key}} component
+type {{.Type}} struct {{if .Dependencies}}{
+{{- range .Dependencies}}
+	{{.Field}} {{.Type}}
+{{- end}}
+}{{else}}{}{{end}}
+
+// Define{{.Type}} defines the component with its dependencies
+func Define{{.Type}}() *component.Component {
+	return component.Define({{.Type}}Key, new({{.Type}}){{range .Dependencies}}, {{printf "%q" .Key}}{{end}})
+}
+
+func (c *{{.Type}}) Start(ctx component.Context) (component.Lifecycle, error) {
+{{- range .Dependencies}}
+	{{.Field}}, err := {{.Accessor}}(ctx)
+	if err != nil {
+		return nil, err
+	}
+	c.{{.Field}} = {{.Field}}
+{{end}}
+	// TODO: acquire what the component needs, logging with ctx.Logger()
+	return c, nil
+}
+
+func (c *{{.Type}}) Stop(ctx component.Context) error {
+	stopCtx := context.Background()
+	if deadline, ok := ctx.StopDeadline(); ok {
+		var cancel context.CancelFunc
+		stopCtx, cancel = context.WithDeadline(stopCtx, deadline)
+		defer cancel()
+	}
+	return c.release(stopCtx)
+}
+
+// release frees what Start acquired, giving up once ctx is done
+func (c *{{.Type}}) release(ctx context.Context) error {
+	// TODO: close connections, flush buffers
+	if err := ctx.Err(); err != nil {
+		return fmt.Errorf("release {{.Key}}: %w", err)
+	}
+	return nil
+}
+{{range .Dependencies}}
+// {{.Accessor}} returns the {{.Key}} dependency as {{.Type}}
+func {{.Accessor}}(ctx component.Context) ({{.Type}}, error) {
+	dependency, ok := component.DependencyAs[{{.Type}}](ctx, {{printf "%q" .Key}})
+	if !ok {
+		return dependency, fmt.Errorf("dependency {{.Key}} is missing or not a {{.Type}}")
+	}
+	return dependency, nil
+}
+{{end}}`))
+
+var testTemplate = template.Must(template.New("test").Parse(`package {{.Package}}
+
+import (
+	"testing"
+
+	"github.com/leandroolgomes/golang-dependency-graph/component"
+	"github.com/leandroolgomes/golang-dependency-graph/componenttest"
+)
+
+func Test{{.Type}}(t *testing.T) {
+{{- if .Typed}}
+	t.Skip("TODO: add fakes for the typed dependencies{{range .Typed}} {{.}}{{end}}")
+{{end}}
+	system := component.CreateSystem(map[string]*component.Component{
+		{{.Type}}Key: Define{{.Type}}(),
+	})
+	// Dependencies without a fake are replaced by componenttest.Stub
+	fakes := []componenttest.Fake{}
+	isolated, err := componenttest.Isolate(system, {{.Type}}Key, fakes)
+	if err != nil {
+		t.Fatalf("Failed to isolate {{.Key}}: %v", err)
+	}
+
+	if err := isolated.Start(); err != nil {
+		t.Fatalf("Failed to start {{.Key}}: %v", err)
+	}
+	if err := isolated.Stop(); err != nil {
+		t.Fatalf("Failed to stop {{.Key}}: %v", err)
+	}
+}
+`))
@@ -0,0 +1,145 @@
+package componenthttp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/leandroolgomes/golang-dependency-graph/component"
+)
+
+// DefaultAdminShutdownTimeout bounds the shutdown of an Admin server when
+// the system gives no stop deadline
+const DefaultAdminShutdownTimeout = 5 * time.Second
+
+// AdminHandler serves the live state of the system for operators:
+//
+//	/components  states, dependencies and start durations as JSON
+//	/graph       the graph as DOT, or in any registered encoder format
+//	             with ?format=json, yaml, mermaid, protobuf, ...
+//	/health      the HealthHandler report
+//	/runtime     the runtime configuration as JSON; PUT replaces it
+//
+// None of the endpoints wait for an in-flight start or stop, so a stuck
+// boot can be inspected while it hangs
+func AdminHandler(system *component.System) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /components", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(system.GraphExport().Components)
+	})
+	mux.HandleFunc("GET /graph", func(w http.ResponseWriter, r *http.Request) {
+		format := r.URL.Query().Get("format")
+		var encoder component.Encoder = component.DOTEncoder{Styles: component.DefaultDOTStyles}
+		if format != "" && format != component.FormatDOT {
+			registered, ok := component.LookupEncoder(format)
+			if !ok {
+				http.Error(w, "unknown format "+format, http.StatusBadRequest)
+				return
+			}
+			encoder = registered
+		}
+		w.Header().Set("Content-Type", encoder.ContentType())
+		system.ExportGraph(w, encoder)
+	})
+	mux.Handle("GET /health", HealthHandler(system))
+	mux.HandleFunc("GET /runtime", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(system.RuntimeConfig())
+	})
+	mux.HandleFunc("PUT /runtime", func(w http.ResponseWriter, r *http.Request) {
+		var config component.RuntimeConfig
+		if err := json.NewDecoder(r.Body).Decode(&config); err != nil {
+			http.Error(w, "invalid runtime config: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+		if err := system.UpdateRuntimeConfig(func(current *component.RuntimeConfig) { *current = config }); err != nil {
+			http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(system.RuntimeConfig())
+	})
+	return mux
+}
+
+// Admin is a component serving AdminHandler on its own listener. Bind it to
+// the system it is part of before starting:
+//
+//	admin := componenthttp.NewAdmin("127.0.0.1:9090")
+//	system := component.CreateSystem(map[string]*component.Component{
+//		"admin": component.Define("admin", admin),
+//		...
+//	})
+//	admin.Bind(system)
+type Admin struct {
+	addr   string
+	system *component.System
+
+	server   *http.Server
+	listener net.Listener
+	mu       sync.Mutex
+}
+
+// NewAdmin creates an admin component listening on addr
+func NewAdmin(addr string) *Admin {
+	return &Admin{addr: addr}
+}
+
+// Bind sets the system the admin endpoints report on
+func (a *Admin) Bind(system *component.System) *Admin {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.system = system
+	return a
+}
+
+// Addr returns the address the admin server listens on once started, which
+// resolves a ":0" port
+func (a *Admin) Addr() string {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.listener == nil {
+		return a.addr
+	}
+	return a.listener.Addr().String()
+}
+
+func (a *Admin) Start(ctx component.Context) (component.Lifecycle, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.system == nil {
+		return nil, errors.New("admin server is not bound to a system")
+	}
+
+	listener, err := net.Listen("tcp", a.addr)
+	if err != nil {
+		return nil, err
+	}
+	a.listener = listener
+	a.server = &http.Server{Handler: AdminHandler(a.system), ReadHeaderTimeout: 10 * time.Second}
+	go a.server.Serve(listener)
+	return a, nil
+}
+
+func (a *Admin) Stop(ctx component.Context) error {
+	a.mu.Lock()
+	server := a.server
+	a.server, a.listener = nil, nil
+	a.mu.Unlock()
+	if server == nil {
+		return nil
+	}
+
+	deadline, ok := ctx.StopDeadline()
+	if !ok {
+		deadline = time.Now().Add(DefaultAdminShutdownTimeout)
+	}
+	shutdownCtx, cancel := context.WithDeadline(context.Background(), deadline)
+	defer cancel()
+	return server.Shutdown(shutdownCtx)
+}
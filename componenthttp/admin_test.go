@@ -0,0 +1,109 @@
+package componenthttp
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/leandroolgomes/golang-dependency-graph/component"
+)
+
+func TestAdmin(t *testing.T) {
+	admin := NewAdmin("127.0.0.1:0")
+	system := component.CreateSystem(map[string]*component.Component{
+		"admin": component.Define("admin", admin),
+		"db":    component.Define("db", &fake{}),
+		"api":   component.Define("api", &fake{}, "db"),
+	})
+	admin.Bind(system)
+	if err := system.Start(); err != nil {
+		t.Fatalf("Failed to start system: %v", err)
+	}
+
+	get := func(path string) (int, string) {
+		t.Helper()
+		response, err := http.Get("http://" + admin.Addr() + path)
+		if err != nil {
+			t.Fatalf("GET %s: %v", path, err)
+		}
+		defer response.Body.Close()
+		body, _ := io.ReadAll(response.Body)
+		return response.StatusCode, string(body)
+	}
+
+	code, body := get("/components")
+	var components []component.GraphComponent
+	if err := json.Unmarshal([]byte(body), &components); code != http.StatusOK || err != nil || len(components) != 3 {
+		t.Fatalf("Expected the three components, got %d %s", code, body)
+	}
+	if api := components[1]; api.Key != "api" || api.State != component.StateStarted || len(api.Dependencies) != 1 || api.StartDuration == 0 {
+		t.Errorf("Expected api started after db with its duration, got %+v", api)
+	}
+
+	if code, body = get("/graph"); code != http.StatusOK || !strings.Contains(body, `"api" -> "db";`) {
+		t.Errorf("Expected the DOT graph, got %d %s", code, body)
+	}
+	if code, body = get("/graph?format=json"); code != http.StatusOK || !strings.Contains(body, `"schema_version": 1`) {
+		t.Errorf("Expected the JSON graph, got %d %s", code, body)
+	}
+	if code, body = get("/graph?format=yaml"); code != http.StatusOK || !strings.Contains(body, "schema_version: 1") {
+		t.Errorf("Expected the YAML graph, got %d %s", code, body)
+	}
+	if code, _ = get("/graph?format=svg"); code != http.StatusBadRequest {
+		t.Errorf("Expected an unknown format to be rejected, got %d", code)
+	}
+	if code, body = get("/health"); code != http.StatusOK || !strings.Contains(body, `"status":"healthy"`) {
+		t.Errorf("Expected a healthy report, got %d %s", code, body)
+	}
+
+	addr := admin.Addr()
+	if err := system.Stop(); err != nil {
+		t.Fatalf("Failed to stop system: %v", err)
+	}
+	if _, err := http.Get("http://" + addr + "/components"); err == nil {
+		t.Error("Expected the admin server to be closed after Stop")
+	}
+}
+
+func TestAdminUnbound(t *testing.T) {
+	if _, err := NewAdmin("127.0.0.1:0").Start(component.Context{}); err == nil {
+		t.Error("Expected an unbound admin server to fail to start")
+	}
+}
+
+func TestAdminRuntimeConfig(t *testing.T) {
+	store := component.NewMemoryRuntimeConfigStore()
+	system := component.CreateSystem(map[string]*component.Component{
+		"db":  component.Define("db", &fake{}),
+		"api": component.Define("api", &fake{}, "db"),
+	}, component.WithRuntimeConfigStore(store))
+	handler := AdminHandler(system)
+
+	put := func(body string) *httptest.ResponseRecorder {
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodPut, "/runtime", strings.NewReader(body)))
+		return recorder
+	}
+
+	if recorder := put(`{"parallel_start": 4, "disabled": ["api"]}`); recorder.Code != http.StatusOK || !strings.Contains(recorder.Body.String(), `"disabled":["api"]`) {
+		t.Errorf("Expected the runtime config to be replaced, got %d %s", recorder.Code, recorder.Body)
+	}
+	if saved, found, _ := store.LoadRuntimeConfig(); !found || saved.ParallelStart != 4 {
+		t.Errorf("Expected the change to be saved, got %+v", saved)
+	}
+	if recorder := put(`{"disabled": ["cache"]}`); recorder.Code != http.StatusUnprocessableEntity {
+		t.Errorf("Expected an unknown component to be rejected, got %d", recorder.Code)
+	}
+	if recorder := put(`{`); recorder.Code != http.StatusBadRequest {
+		t.Errorf("Expected invalid JSON to be rejected, got %d", recorder.Code)
+	}
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/runtime", nil))
+	if !strings.Contains(recorder.Body.String(), `"parallel_start":4`) {
+		t.Errorf("Expected the current runtime config, got %s", recorder.Body)
+	}
+}
@@ -0,0 +1,88 @@
+package componenthttp
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/leandroolgomes/golang-dependency-graph/component"
+)
+
+// HealthHandler serves the detailed health report of the system as JSON,
+// running component health checks and responding 503 when any component is
+// unhealthy. With ?component=key it drills down to that component and its
+// transitive dependencies
+func HealthHandler(system *component.System) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		report := system.Health()
+
+		if key := r.URL.Query().Get("component"); key != "" {
+			drilled, ok := drillDown(report, key)
+			if !ok {
+				http.Error(w, "unknown component "+key, http.StatusNotFound)
+				return
+			}
+			report = drilled
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if report.Status == component.HealthUnhealthy {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+		json.NewEncoder(w).Encode(report)
+	})
+}
+
+// drillDown narrows a report to a component and its transitive
+// dependencies, with the status of that component
+func drillDown(report component.HealthReport, key string) (component.HealthReport, bool) {
+	target, ok := report.Component(key)
+	if !ok {
+		return component.HealthReport{}, false
+	}
+
+	included := map[string]bool{key: true}
+	queue := []string{key}
+	for len(queue) > 0 {
+		health, _ := report.Component(queue[0])
+		queue = queue[1:]
+		for _, dep := range health.Dependencies {
+			if !included[dep] {
+				included[dep] = true
+				queue = append(queue, dep)
+			}
+		}
+	}
+
+	drilled := component.HealthReport{Status: target.Status}
+	for _, health := range report.Components {
+		if included[health.Key] {
+			drilled.Components = append(drilled.Components, health)
+		}
+	}
+	return drilled, true
+}
+
+// ReadyHandler answers readiness probes: 200 once System.Ready passes,
+// 503 with the reasons otherwise, so deployment tooling can gate traffic
+func ReadyHandler(system *component.System) http.Handler {
+	return probeHandler(system.Ready)
+}
+
+// LiveHandler answers liveness probes: 200 while System.Alive passes, 503
+// with the reasons otherwise
+func LiveHandler(system *component.System) http.Handler {
+	return probeHandler(system.Alive)
+}
+
+// probeHandler reports the result of a probe as plain text
+func probeHandler(probe func() error) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		if err := probe(); err != nil {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			w.Write([]byte(err.Error() + "\n"))
+			return
+		}
+		w.Write([]byte("ok\n"))
+	})
+}
@@ -0,0 +1,111 @@
+package componenthttp
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/leandroolgomes/golang-dependency-graph/component"
+)
+
+func TestHealthHandler(t *testing.T) {
+	system := component.CreateSystem(map[string]*component.Component{
+		"config":      component.Define("config", &fake{}),
+		"db":          component.Define("db", &fake{}, "config"),
+		"cache":       component.Define("cache", &fake{}, "config"),
+		"http_server": component.Define("http_server", &fake{}, "db", "cache"),
+	})
+	if err := system.Start(); err != nil {
+		t.Fatalf("Failed to start system: %v", err)
+	}
+	defer system.Stop()
+
+	report, code := getHealth(t, system, "")
+	if code != http.StatusOK || report.Status != component.HealthHealthy || len(report.Components) != 4 {
+		t.Fatalf("Expected a healthy report, got %d %+v", code, report)
+	}
+
+	system.Degrade("db", errors.New("replica lag"))
+	report, code = getHealth(t, system, "")
+	if code != http.StatusOK || report.Status != component.HealthDegraded {
+		t.Fatalf("Expected a degraded report served with 200, got %d %+v", code, report)
+	}
+	db, _ := report.Component("db")
+	if db.Cause != "db" || db.Error != "replica lag" {
+		t.Errorf("Expected db to be its own cause, got %+v", db)
+	}
+	server, _ := report.Component("http_server")
+	if server.Status != component.HealthDegraded || server.Cause != "db" || server.Reason != "http_server degraded because db degraded" {
+		t.Errorf("Expected http_server attributed to db, got %+v", server)
+	}
+	if cache, _ := report.Component("cache"); cache.Status != component.HealthHealthy {
+		t.Errorf("Expected cache unaffected, got %+v", cache)
+	}
+
+	report, _ = getHealth(t, system, "?component=db")
+	if len(report.Components) != 2 || report.Components[0].Key != "config" || report.Components[1].Key != "db" {
+		t.Errorf("Expected db and its dependencies only, got %+v", report.Components)
+	}
+	if _, code := getHealth(t, system, "?component=missing"); code != http.StatusNotFound {
+		t.Errorf("Expected 404 for an unknown component, got %d", code)
+	}
+}
+
+func TestHealthHandlerUnhealthy(t *testing.T) {
+	system := component.CreateSystem(map[string]*component.Component{
+		"db":  component.Define("db", &fake{startErr: errors.New("refused")}),
+		"api": component.Define("api", &fake{}, "db"),
+	})
+	system.Start()
+
+	report, code := getHealth(t, system, "")
+	if code != http.StatusServiceUnavailable || report.Status != component.HealthUnhealthy {
+		t.Fatalf("Expected 503, got %d %+v", code, report)
+	}
+	api, _ := report.Component("api")
+	if api.Cause != "db" || api.Reason != "api unhealthy because db unhealthy" {
+		t.Errorf("Expected api attributed to db, got %+v", api)
+	}
+}
+
+func getHealth(t *testing.T, system *component.System, query string) (component.HealthReport, int) {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	HealthHandler(system).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/health"+query, nil))
+
+	var report component.HealthReport
+	if rec.Code != http.StatusNotFound {
+		if err := json.NewDecoder(rec.Body).Decode(&report); err != nil {
+			t.Fatalf("Failed to decode report: %v", err)
+		}
+	}
+	return report, rec.Code
+}
+
+func TestReadyHandler(t *testing.T) {
+	system := component.CreateSystem(map[string]*component.Component{
+		"db": component.Define("db", &fake{}),
+	})
+
+	serve := func(handler http.Handler) int {
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+		return recorder.Code
+	}
+	if code := serve(ReadyHandler(system)); code != http.StatusServiceUnavailable {
+		t.Errorf("Expected not ready before start, got %d", code)
+	}
+	if code := serve(LiveHandler(system)); code != http.StatusOK {
+		t.Errorf("Expected alive before start, got %d", code)
+	}
+
+	if err := system.Start(); err != nil {
+		t.Fatalf("Failed to start system: %v", err)
+	}
+	defer system.Stop()
+	if code := serve(ReadyHandler(system)); code != http.StatusOK {
+		t.Errorf("Expected ready once started, got %d", code)
+	}
+}
@@ -0,0 +1,60 @@
+// Package componenthttp exposes component system state to HTTP servers
+package componenthttp
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/leandroolgomes/golang-dependency-graph/component"
+)
+
+// RequireTags returns middleware that short-circuits requests with 503 when
+// any component carrying one of the tags is not available, so a route group
+// depending on e.g. "storage" fails fast while the rest of the API keeps serving
+func RequireTags(system *component.System, tags ...string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if unavailable := Unavailable(system, tags...); len(unavailable) > 0 {
+				w.Header().Set("Retry-After", "5")
+				http.Error(w, fmt.Sprintf("service unavailable: %s", strings.Join(unavailable, ", ")), http.StatusServiceUnavailable)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// Unavailable returns the keys of the components carrying any of the tags
+// that are not currently available: not running, or not healthy in the
+// system's HealthReport. Degraded components count as unavailable, as
+// degrading announces a component cannot do its work fully and the routes
+// gated on it should shed load until it recovers; a dependency failing or
+// degrading takes its dependents with it. Health checks are not called, so
+// a request never waits for them
+func Unavailable(system *component.System, tags ...string) []string {
+	report := system.HealthReport()
+	seen := make(map[string]bool)
+	var unavailable []string
+	for _, tag := range tags {
+		for _, c := range system.ComponentsWithTag(tag) {
+			if seen[c.Key()] {
+				continue
+			}
+			seen[c.Key()] = true
+			if !available(c, report) {
+				unavailable = append(unavailable, c.Key())
+			}
+		}
+	}
+	return unavailable
+}
+
+// available reports whether a component can serve requests
+func available(c *component.Component, report component.HealthReport) bool {
+	if !c.IsStarted() {
+		return false
+	}
+	health, ok := report.Component(c.Key())
+	return ok && health.Status == component.HealthHealthy
+}
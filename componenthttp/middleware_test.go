@@ -0,0 +1,85 @@
+package componenthttp
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/leandroolgomes/golang-dependency-graph/component"
+)
+
+type fake struct {
+	startErr error
+}
+
+func (f *fake) Start(ctx component.Context) (component.Lifecycle, error) {
+	return f, f.startErr
+}
+
+func (f *fake) Stop(ctx component.Context) error {
+	return nil
+}
+
+func TestRequireTags(t *testing.T) {
+	system := component.CreateSystem(map[string]*component.Component{
+		"cache":  component.Define("cache", &fake{}).WithTags("storage"),
+		"search": component.Define("search", &fake{startErr: errors.New("down")}).WithTags("search"),
+	})
+	system.Start()
+
+	ok := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	tests := []struct {
+		tags     []string
+		expected int
+	}{
+		{[]string{"storage"}, http.StatusOK},
+		{[]string{"search"}, http.StatusServiceUnavailable},
+		{[]string{"storage", "search"}, http.StatusServiceUnavailable},
+	}
+
+	for _, tt := range tests {
+		rec := httptest.NewRecorder()
+		RequireTags(system, tt.tags...)(ok).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+		if rec.Code != tt.expected {
+			t.Errorf("Tags %v: expected status %d, got %d", tt.tags, tt.expected, rec.Code)
+		}
+		if rec.Code == http.StatusServiceUnavailable && !strings.Contains(rec.Body.String(), "search") {
+			t.Errorf("Expected response to name the unavailable component, got %q", rec.Body.String())
+		}
+	}
+}
+
+func TestRequireTagsFollowsHealth(t *testing.T) {
+	system := component.CreateSystem(map[string]*component.Component{
+		"db":     component.Define("db", &fake{}),
+		"cache":  component.Define("cache", &fake{}).WithTags("storage"),
+		"orders": component.Define("orders", &fake{}, "db").WithTags("orders"),
+	})
+	if err := system.Start(); err != nil {
+		t.Fatalf("Failed to start system: %v", err)
+	}
+	defer system.Stop()
+
+	if err := system.Degrade("db", errors.New("replica lagging")); err != nil {
+		t.Fatalf("Failed to degrade db: %v", err)
+	}
+	if unavailable := Unavailable(system, "orders", "storage"); len(unavailable) != 1 || unavailable[0] != "orders" {
+		t.Errorf("Expected orders unavailable while db is degraded, got %v", unavailable)
+	}
+	if err := system.Recover("db"); err != nil {
+		t.Fatalf("Failed to recover db: %v", err)
+	}
+
+	db, _ := system.Component("db")
+	if err := db.Stop(component.Context{}); err != nil {
+		t.Fatalf("Failed to stop db: %v", err)
+	}
+	if unavailable := Unavailable(system, "orders"); len(unavailable) != 1 || unavailable[0] != "orders" {
+		t.Errorf("Expected orders unavailable while db is down, got %v", unavailable)
+	}
+}
@@ -0,0 +1,37 @@
+package componenthttp
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/leandroolgomes/golang-dependency-graph/component"
+)
+
+// Webhook returns a system hook calling url with method, for use with
+// component.WithPostStartHook and component.WithPreStopHook to register and
+// deregister the instance with a load balancer. Responses other than 2xx
+// fail the hook. A nil client uses http.DefaultClient
+func Webhook(client *http.Client, method, url string) component.HookFunc {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return func(ctx context.Context) error {
+		request, err := http.NewRequestWithContext(ctx, method, url, nil)
+		if err != nil {
+			return err
+		}
+		response, err := client.Do(request)
+		if err != nil {
+			return err
+		}
+		defer response.Body.Close()
+		io.Copy(io.Discard, response.Body)
+
+		if response.StatusCode < 200 || response.StatusCode > 299 {
+			return fmt.Errorf("%s %s: unexpected status %s", method, url, response.Status)
+		}
+		return nil
+	}
+}
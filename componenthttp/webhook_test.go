@@ -0,0 +1,29 @@
+package componenthttp
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestWebhook(t *testing.T) {
+	var calls []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls = append(calls, r.Method+" "+r.URL.Path)
+		if r.URL.Path == "/fail" {
+			w.WriteHeader(http.StatusBadGateway)
+		}
+	}))
+	defer server.Close()
+
+	if err := Webhook(nil, http.MethodPost, server.URL+"/register")(context.Background()); err != nil {
+		t.Errorf("Expected webhook to succeed, got %v", err)
+	}
+	if err := Webhook(server.Client(), http.MethodDelete, server.URL+"/fail")(context.Background()); err == nil {
+		t.Error("Expected a 502 response to fail the webhook")
+	}
+	if len(calls) != 2 || calls[0] != "POST /register" || calls[1] != "DELETE /fail" {
+		t.Errorf("Expected both requests, got %v", calls)
+	}
+}
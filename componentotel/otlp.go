@@ -0,0 +1,201 @@
+// Package componentotel exports the component topology in the OpenTelemetry
+// protocol, so APM tools can show the internal graph next to the trace map
+package componentotel
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/leandroolgomes/golang-dependency-graph/component"
+)
+
+// ScopeName identifies the exporter as the instrumentation scope of its spans
+const ScopeName = "github.com/leandroolgomes/golang-dependency-graph/componentotel"
+
+// spanKindInternal is SPAN_KIND_INTERNAL in the OTLP span kind enumeration
+const spanKindInternal = 1
+
+// The types below mirror the OTLP/JSON encoding of ExportTraceServiceRequest
+
+type traceRequest struct {
+	ResourceSpans []resourceSpans `json:"resourceSpans"`
+}
+
+type resourceSpans struct {
+	Resource   resource     `json:"resource"`
+	ScopeSpans []scopeSpans `json:"scopeSpans"`
+}
+
+type resource struct {
+	Attributes []attribute `json:"attributes"`
+}
+
+type scopeSpans struct {
+	Scope scope  `json:"scope"`
+	Spans []span `json:"spans"`
+}
+
+type scope struct {
+	Name string `json:"name"`
+}
+
+type span struct {
+	TraceID           string      `json:"traceId"`
+	SpanID            string      `json:"spanId"`
+	ParentSpanID      string      `json:"parentSpanId,omitempty"`
+	Name              string      `json:"name"`
+	Kind              int         `json:"kind"`
+	StartTimeUnixNano string      `json:"startTimeUnixNano"`
+	EndTimeUnixNano   string      `json:"endTimeUnixNano"`
+	Attributes        []attribute `json:"attributes,omitempty"`
+	Links             []link      `json:"links,omitempty"`
+	Status            *status     `json:"status,omitempty"`
+}
+
+// status is a span status; code 2 is STATUS_CODE_ERROR
+type status struct {
+	Code    int    `json:"code"`
+	Message string `json:"message,omitempty"`
+}
+
+type link struct {
+	TraceID    string      `json:"traceId"`
+	SpanID     string      `json:"spanId"`
+	Attributes []attribute `json:"attributes,omitempty"`
+}
+
+type attribute struct {
+	Key   string `json:"key"`
+	Value value  `json:"value"`
+}
+
+type value struct {
+	StringValue *string     `json:"stringValue,omitempty"`
+	ArrayValue  *arrayValue `json:"arrayValue,omitempty"`
+}
+
+type arrayValue struct {
+	Values []value `json:"values"`
+}
+
+func stringAttribute(key, s string) attribute {
+	return attribute{Key: key, Value: value{StringValue: &s}}
+}
+
+func stringsAttribute(key string, values []string) attribute {
+	array := &arrayValue{Values: []value{}}
+	for _, v := range values {
+		array.Values = append(array.Values, value{StringValue: &v})
+	}
+	return attribute{Key: key, Value: value{ArrayValue: array}}
+}
+
+// Encode writes the topology as an OTLP/JSON trace: a root span for the
+// service with one child span per component, whose span ID is the component
+// ID and whose links point at the spans of its dependencies
+func Encode(w io.Writer, topology component.Topology, serviceName string) error {
+	traceID, err := newTraceID()
+	if err != nil {
+		return err
+	}
+	now := strconv.FormatInt(time.Now().UnixNano(), 10)
+
+	ids := make(map[string]string, len(topology.Nodes))
+	for _, node := range topology.Nodes {
+		ids[node.Key] = node.ID
+	}
+
+	rootID := traceID[:16]
+	spans := []span{{
+		TraceID:           traceID,
+		SpanID:            rootID,
+		Name:              serviceName,
+		Kind:              spanKindInternal,
+		StartTimeUnixNano: now,
+		EndTimeUnixNano:   now,
+	}}
+	for _, node := range topology.Nodes {
+		s := span{
+			TraceID:           traceID,
+			SpanID:            node.ID,
+			ParentSpanID:      rootID,
+			Name:              node.Key,
+			Kind:              spanKindInternal,
+			StartTimeUnixNano: now,
+			EndTimeUnixNano:   now,
+			Attributes: []attribute{
+				stringAttribute("component.key", node.Key),
+				stringAttribute("component.id", node.ID),
+				stringAttribute("component.state", node.State.String()),
+			},
+		}
+		if len(node.Tags) > 0 {
+			s.Attributes = append(s.Attributes, stringsAttribute("component.tags", node.Tags))
+		}
+		if node.Metadata.Owner != "" {
+			s.Attributes = append(s.Attributes, stringAttribute("component.owner", node.Metadata.Owner))
+		}
+		for _, edge := range topology.Edges {
+			if edge.From == node.Key {
+				s.Links = append(s.Links, link{
+					TraceID:    traceID,
+					SpanID:     ids[edge.To],
+					Attributes: []attribute{stringAttribute("component.dependency", edge.To)},
+				})
+			}
+		}
+		spans = append(spans, s)
+	}
+
+	request := traceRequest{ResourceSpans: []resourceSpans{{
+		Resource:   resource{Attributes: []attribute{stringAttribute("service.name", serviceName)}},
+		ScopeSpans: []scopeSpans{{Scope: scope{Name: ScopeName}, Spans: spans}},
+	}}}
+	return json.NewEncoder(w).Encode(request)
+}
+
+// Export posts the topology of the system to an OTLP/HTTP traces endpoint,
+// such as http://localhost:4318/v1/traces on a collector
+func Export(ctx context.Context, client *http.Client, endpoint string, system *component.System, serviceName string) error {
+	var body bytes.Buffer
+	if err := Encode(&body, system.Topology(), serviceName); err != nil {
+		return err
+	}
+	return post(ctx, client, endpoint, &body)
+}
+
+// post sends an OTLP/JSON request body to endpoint
+func post(ctx context.Context, client *http.Client, endpoint string, body io.Reader) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("otlp export failed: %s", resp.Status)
+	}
+	return nil
+}
+
+// newTraceID returns a random 16-byte trace ID in hex
+func newTraceID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
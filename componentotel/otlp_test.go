@@ -0,0 +1,75 @@
+package componentotel
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/leandroolgomes/golang-dependency-graph/component"
+)
+
+type fake struct{}
+
+func (f *fake) Start(ctx component.Context) (component.Lifecycle, error) {
+	return f, nil
+}
+
+func (f *fake) Stop(ctx component.Context) error {
+	return nil
+}
+
+func TestExportTopology(t *testing.T) {
+	system := component.CreateSystem(map[string]*component.Component{
+		"db":  component.Define("db", &fake{}).WithTags("storage"),
+		"api": component.Define("api", &fake{}, "db"),
+	})
+
+	var request traceRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Content-Type") != "application/json" {
+			t.Errorf("Expected OTLP/JSON, got %s", r.Header.Get("Content-Type"))
+		}
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			t.Errorf("Failed to decode request: %v", err)
+		}
+	}))
+	defer server.Close()
+
+	if err := Export(context.Background(), server.Client(), server.URL, system, "shop"); err != nil {
+		t.Fatalf("Failed to export: %v", err)
+	}
+
+	spans := request.ResourceSpans[0].ScopeSpans[0].Spans
+	if len(spans) != 3 {
+		t.Fatalf("Expected a root span and two component spans, got %d", len(spans))
+	}
+	if *request.ResourceSpans[0].Resource.Attributes[0].Value.StringValue != "shop" {
+		t.Error("Expected the service name as a resource attribute")
+	}
+
+	byName := make(map[string]span)
+	for _, s := range spans {
+		byName[s.Name] = s
+	}
+	api, db := byName["api"], byName["db"]
+	if api.ParentSpanID != spans[0].SpanID || db.SpanID != system.ComponentsWithTag("storage")[0].ID() {
+		t.Errorf("Expected component spans under the root, identified by component ID")
+	}
+	if len(api.Links) != 1 || api.Links[0].SpanID != db.SpanID {
+		t.Errorf("Expected api to link to db, got %+v", api.Links)
+	}
+}
+
+func TestExportRejected(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	system := component.CreateSystem(map[string]*component.Component{})
+	if err := Export(context.Background(), server.Client(), server.URL, system, "shop"); err == nil {
+		t.Error("Expected a rejected export to fail")
+	}
+}
@@ -0,0 +1,149 @@
+package componentotel
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/leandroolgomes/golang-dependency-graph/component"
+)
+
+// statusCodeError is STATUS_CODE_ERROR in the OTLP status code enumeration
+const statusCodeError = 2
+
+// TracerProvider records the lifecycle spans of a system configured with
+// component.WithTracerProvider and exports them as OTLP/JSON traces
+type TracerProvider struct {
+	serviceName string
+	finished    map[string][]span
+	mu          sync.Mutex
+}
+
+// NewTracerProvider creates a provider reporting spans for serviceName
+func NewTracerProvider(serviceName string) *TracerProvider {
+	return &TracerProvider{serviceName: serviceName, finished: make(map[string][]span)}
+}
+
+// Tracer returns a tracer whose spans are reported under the scope name
+func (p *TracerProvider) Tracer(name string) component.Tracer {
+	return &tracer{provider: p, scope: name}
+}
+
+// Encode writes the spans finished since the last Flush as an OTLP/JSON
+// trace request, grouped by tracer scope
+func (p *TracerProvider) Encode(w io.Writer) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return json.NewEncoder(w).Encode(p.request())
+}
+
+// Flush posts the finished spans to an OTLP/HTTP traces endpoint, such as
+// http://localhost:4318/v1/traces on a collector, and forgets them once
+// accepted
+func (p *TracerProvider) Flush(ctx context.Context, client *http.Client, endpoint string) error {
+	p.mu.Lock()
+	var body bytes.Buffer
+	err := json.NewEncoder(&body).Encode(p.request())
+	sent := p.finished
+	p.finished = make(map[string][]span)
+	p.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	if err := post(ctx, client, endpoint, &body); err != nil {
+		// Keep the spans for the next attempt
+		p.mu.Lock()
+		for scope, spans := range sent {
+			p.finished[scope] = append(spans, p.finished[scope]...)
+		}
+		p.mu.Unlock()
+		return err
+	}
+	return nil
+}
+
+// request builds the trace request of the finished spans; the caller must
+// hold p.mu
+func (p *TracerProvider) request() traceRequest {
+	resourceSpan := resourceSpans{
+		Resource:   resource{Attributes: []attribute{stringAttribute("service.name", p.serviceName)}},
+		ScopeSpans: []scopeSpans{},
+	}
+	for scopeName, spans := range p.finished {
+		resourceSpan.ScopeSpans = append(resourceSpan.ScopeSpans, scopeSpans{Scope: scope{Name: scopeName}, Spans: spans})
+	}
+	return traceRequest{ResourceSpans: []resourceSpans{resourceSpan}}
+}
+
+// spanContextKey carries the identifiers of the current span in a context
+type spanContextKey struct{}
+
+type spanContext struct {
+	traceID string
+	spanID  string
+}
+
+type tracer struct {
+	provider *TracerProvider
+	scope    string
+}
+
+// Start begins a span, child of the span carried by ctx if any
+func (t *tracer) Start(ctx context.Context, name string, attributes ...component.SpanAttribute) (context.Context, component.Span) {
+	parent, hasParent := ctx.Value(spanContextKey{}).(spanContext)
+	current := spanContext{traceID: parent.traceID, spanID: randomHex(8)}
+	if !hasParent {
+		current.traceID = randomHex(16)
+	}
+
+	s := &recordingSpan{tracer: t, data: span{
+		TraceID:           current.traceID,
+		SpanID:            current.spanID,
+		ParentSpanID:      parent.spanID,
+		Name:              name,
+		Kind:              spanKindInternal,
+		StartTimeUnixNano: strconv.FormatInt(time.Now().UnixNano(), 10),
+	}}
+	for _, a := range attributes {
+		s.data.Attributes = append(s.data.Attributes, stringAttribute(a.Key, a.Value))
+	}
+	return context.WithValue(ctx, spanContextKey{}, current), s
+}
+
+// recordingSpan is reported to its provider when it ends
+type recordingSpan struct {
+	tracer *tracer
+	data   span
+	once   sync.Once
+}
+
+// RecordError marks the span as failed with err
+func (s *recordingSpan) RecordError(err error) {
+	s.data.Status = &status{Code: statusCodeError, Message: err.Error()}
+}
+
+// End finishes the span; later calls are ignored
+func (s *recordingSpan) End() {
+	s.once.Do(func() {
+		s.data.EndTimeUnixNano = strconv.FormatInt(time.Now().UnixNano(), 10)
+		p := s.tracer.provider
+		p.mu.Lock()
+		p.finished[s.tracer.scope] = append(p.finished[s.tracer.scope], s.data)
+		p.mu.Unlock()
+	})
+}
+
+// randomHex returns n random bytes in hex
+func randomHex(n int) string {
+	b := make([]byte, n)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,102 @@
+package componentotel
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/leandroolgomes/golang-dependency-graph/component"
+)
+
+type failing struct{}
+
+func (f *failing) Start(ctx component.Context) (component.Lifecycle, error) {
+	return nil, errors.New("connection refused")
+}
+
+func (f *failing) Stop(ctx component.Context) error {
+	return nil
+}
+
+func TestTracerProviderRecordsBootWaterfall(t *testing.T) {
+	provider := NewTracerProvider("checkout")
+	system := component.CreateSystem(map[string]*component.Component{
+		"db":    component.Define("db", &fake{}),
+		"api":   component.Define("api", &fake{}, "db"),
+		"queue": component.Define("queue", &failing{}, "api"),
+	}, component.WithTracerProvider(provider))
+	if err := system.Start(); err == nil {
+		t.Fatal("Expected queue to fail the start")
+	}
+
+	var body strings.Builder
+	if err := provider.Encode(&body); err != nil {
+		t.Fatal(err)
+	}
+	var request traceRequest
+	if err := json.Unmarshal([]byte(body.String()), &request); err != nil {
+		t.Fatalf("Failed to decode request: %v", err)
+	}
+	scopes := request.ResourceSpans[0].ScopeSpans
+	if len(scopes) != 1 || scopes[0].Scope.Name != component.TracerName {
+		t.Fatalf("Expected the system tracer scope, got %+v", scopes)
+	}
+
+	byName := make(map[string]span)
+	for _, s := range scopes[0].Spans {
+		byName[s.Name] = s
+	}
+	root, ok := byName["system start"]
+	if !ok || root.ParentSpanID != "" || root.Status == nil {
+		t.Fatalf("Expected a failed root span, got %+v", byName)
+	}
+	for _, name := range []string{"start db", "start api", "start queue"} {
+		child, ok := byName[name]
+		if !ok || child.ParentSpanID != root.SpanID || child.TraceID != root.TraceID {
+			t.Errorf("Expected %s under the system span, got %+v", name, child)
+		}
+	}
+	if status := byName["start queue"].Status; status == nil || status.Code != statusCodeError || status.Message == "" {
+		t.Errorf("Expected the queue span to be failed, got %+v", status)
+	}
+	if byName["start db"].Status != nil {
+		t.Error("Expected the db span to succeed")
+	}
+}
+
+func TestTracerProviderFlush(t *testing.T) {
+	var received []traceRequest
+	fail := true
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if fail {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		var request traceRequest
+		json.NewDecoder(r.Body).Decode(&request)
+		received = append(received, request)
+	}))
+	defer server.Close()
+
+	provider := NewTracerProvider("checkout")
+	_, span := provider.Tracer("test").Start(context.Background(), "work")
+	span.End()
+
+	if err := provider.Flush(context.Background(), server.Client(), server.URL); err == nil {
+		t.Fatal("Expected the rejected export to fail")
+	}
+	fail = false
+	if err := provider.Flush(context.Background(), server.Client(), server.URL); err != nil {
+		t.Fatalf("Expected the retry to succeed, got %v", err)
+	}
+	if len(received) != 1 || len(received[0].ResourceSpans[0].ScopeSpans[0].Spans) != 1 {
+		t.Fatalf("Expected the span kept for the retry, got %+v", received)
+	}
+	if err := provider.Flush(context.Background(), server.Client(), server.URL); err != nil || len(received[1].ResourceSpans[0].ScopeSpans) != 0 {
+		t.Error("Expected flushed spans to be forgotten")
+	}
+}
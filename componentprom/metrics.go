@@ -0,0 +1,259 @@
+// Package componentprom exposes component lifecycle metrics in the
+// Prometheus text exposition format. It has no dependency on the Prometheus
+// client library: a Collector serves its own scrape endpoint
+package componentprom
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/leandroolgomes/golang-dependency-graph/component"
+)
+
+// ContentType is the media type of the text exposition format
+const ContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+// DefaultBuckets are the upper bounds, in seconds, of the duration histograms
+var DefaultBuckets = []float64{0.001, 0.005, 0.01, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30}
+
+// states lists every State reported by the component_state gauge
+var states = []component.State{
+	component.StateNotStarted,
+	component.StateStarting,
+	component.StateStarted,
+	component.StateFailed,
+	component.StateStopping,
+	component.StateStopped,
+	component.StateDegraded,
+	component.StateCompleted,
+	component.StateQuarantined,
+}
+
+// histogram counts observations per bucket
+type histogram struct {
+	counts []uint64
+	count  uint64
+	sum    float64
+}
+
+func (h *histogram) observe(buckets []float64, seconds float64) {
+	if h.counts == nil {
+		h.counts = make([]uint64, len(buckets))
+	}
+	for i, bound := range buckets {
+		if seconds <= bound {
+			h.counts[i]++
+		}
+	}
+	h.count++
+	h.sum += seconds
+}
+
+// Collector gathers lifecycle metrics from the events of a system it is
+// attached to with System.Attach:
+//
+//	component_start_duration_seconds  histogram of successful starts
+//	component_stop_duration_seconds   histogram of successful stops
+//	component_state                   1 for the current state of each component
+//	component_restarts_total          starts after the first one
+//	component_failures_total          failed starts and stops, by op
+//	system_up                         1 while the system is started
+//	system_uptime_seconds             time since the system started
+//
+// A Collector is an http.Handler serving the metrics for scraping
+type Collector struct {
+	buckets []float64
+
+	view      component.SystemView
+	attached  bool
+	startedAt time.Time
+
+	startDurations map[string]*histogram
+	stopDurations  map[string]*histogram
+	starts         map[string]uint64
+	failures       map[string]map[string]uint64
+	mu             sync.Mutex
+}
+
+// NewCollector creates a collector using buckets for its duration
+// histograms, or DefaultBuckets when none are given
+func NewCollector(buckets ...float64) *Collector {
+	if len(buckets) == 0 {
+		buckets = DefaultBuckets
+	}
+	buckets = append([]float64(nil), buckets...)
+	sort.Float64s(buckets)
+	return &Collector{
+		buckets:        buckets,
+		startDurations: make(map[string]*histogram),
+		stopDurations:  make(map[string]*histogram),
+		starts:         make(map[string]uint64),
+		failures:       make(map[string]map[string]uint64),
+	}
+}
+
+// Attached records the system view. The uptime of a system already started
+// is counted from the attachment
+func (c *Collector) Attached(view component.SystemView) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.view = view
+	c.attached = true
+	if view.IsStarted() {
+		c.startedAt = time.Now()
+	}
+}
+
+// Observe updates the metrics from a lifecycle event
+func (c *Collector) Observe(event component.Event) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	switch event.Type {
+	case component.EventSystemStarted:
+		if event.Err == nil {
+			c.startedAt = event.Time
+		}
+	case component.EventSystemStopped:
+		c.startedAt = time.Time{}
+	case component.EventComponentStarted, component.EventComponentCompleted:
+		c.starts[event.Component]++
+		c.observe(c.startDurations, event.Component, event.Duration)
+	case component.EventComponentFailed:
+		c.fail(event.Component, component.OpStart)
+	case component.EventComponentStopped:
+		if event.Err != nil {
+			c.fail(event.Component, component.OpStop)
+			return
+		}
+		c.observe(c.stopDurations, event.Component, event.Duration)
+	}
+}
+
+func (c *Collector) observe(histograms map[string]*histogram, key string, duration time.Duration) {
+	h, ok := histograms[key]
+	if !ok {
+		h = &histogram{}
+		histograms[key] = h
+	}
+	h.observe(c.buckets, duration.Seconds())
+}
+
+func (c *Collector) fail(key, op string) {
+	if c.failures[key] == nil {
+		c.failures[key] = make(map[string]uint64)
+	}
+	c.failures[key][op]++
+}
+
+// ServeHTTP writes the metrics for a scrape
+func (c *Collector) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", ContentType)
+	c.Write(w)
+}
+
+// Write renders the metrics in the text exposition format
+func (c *Collector) Write(w io.Writer) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var keys []string
+	var current map[string]component.State
+	if c.attached {
+		keys = c.view.Keys()
+		current = c.view.States()
+	}
+
+	b := bufio.NewWriter(w)
+	header(b, "component_start_duration_seconds", "histogram", "Duration of successful component starts.")
+	for _, key := range keys {
+		c.writeHistogram(b, "component_start_duration_seconds", key, c.startDurations[key])
+	}
+	header(b, "component_stop_duration_seconds", "histogram", "Duration of successful component stops.")
+	for _, key := range keys {
+		c.writeHistogram(b, "component_stop_duration_seconds", key, c.stopDurations[key])
+	}
+
+	header(b, "component_state", "gauge", "Lifecycle state of the component, 1 for the current state.")
+	for _, key := range keys {
+		for _, state := range states {
+			value := 0.0
+			if current[key] == state {
+				value = 1
+			}
+			sample(b, "component_state", labels("component", key, "state", state.String()), value)
+		}
+	}
+
+	header(b, "component_restarts_total", "counter", "Starts of the component after its first one.")
+	for _, key := range keys {
+		sample(b, "component_restarts_total", labels("component", key), float64(max(c.starts[key], 1)-1))
+	}
+
+	header(b, "component_failures_total", "counter", "Failed starts and stops of the component.")
+	for _, key := range keys {
+		for _, op := range []string{component.OpStart, component.OpStop} {
+			sample(b, "component_failures_total", labels("component", key, "op", op), float64(c.failures[key][op]))
+		}
+	}
+
+	up, uptime := 0.0, 0.0
+	if !c.startedAt.IsZero() {
+		up, uptime = 1, time.Since(c.startedAt).Seconds()
+	}
+	header(b, "system_up", "gauge", "Whether the system is started.")
+	sample(b, "system_up", "", up)
+	header(b, "system_uptime_seconds", "gauge", "Time since the system started, 0 while stopped.")
+	sample(b, "system_uptime_seconds", "", uptime)
+	return b.Flush()
+}
+
+// writeHistogram renders the cumulative buckets, sum and count of a histogram
+func (c *Collector) writeHistogram(b *bufio.Writer, name, key string, h *histogram) {
+	if h == nil {
+		h = &histogram{counts: make([]uint64, len(c.buckets))}
+	}
+	for i, bound := range c.buckets {
+		sample(b, name+"_bucket", labels("component", key, "le", formatFloat(bound)), float64(h.counts[i]))
+	}
+	sample(b, name+"_bucket", labels("component", key, "le", "+Inf"), float64(h.count))
+	sample(b, name+"_sum", labels("component", key), h.sum)
+	sample(b, name+"_count", labels("component", key), float64(h.count))
+}
+
+func header(b *bufio.Writer, name, kind, help string) {
+	fmt.Fprintf(b, "# HELP %s %s\n# TYPE %s %s\n", name, help, name, kind)
+}
+
+func sample(b *bufio.Writer, name, labels string, value float64) {
+	fmt.Fprintf(b, "%s%s %s\n", name, labels, formatFloat(value))
+}
+
+// labels renders name/value pairs as a label set
+func labels(pairs ...string) string {
+	var parts []string
+	for i := 0; i+1 < len(pairs); i += 2 {
+		parts = append(parts, pairs[i]+`="`+escape(pairs[i+1])+`"`)
+	}
+	return "{" + strings.Join(parts, ",") + "}"
+}
+
+// escape escapes a label value
+func escape(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(value)
+}
+
+func formatFloat(value float64) string {
+	if math.IsInf(value, 1) {
+		return "+Inf"
+	}
+	return strconv.FormatFloat(value, 'g', -1, 64)
+}
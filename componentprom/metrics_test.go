@@ -0,0 +1,95 @@
+package componentprom
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/leandroolgomes/golang-dependency-graph/component"
+)
+
+type fake struct {
+	stopErr error
+}
+
+func (f *fake) Start(ctx component.Context) (component.Lifecycle, error) {
+	return f, nil
+}
+
+func (f *fake) Stop(ctx component.Context) error {
+	return f.stopErr
+}
+
+func scrape(t *testing.T, collector *Collector) string {
+	t.Helper()
+	recorder := httptest.NewRecorder()
+	collector.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	if got := recorder.Header().Get("Content-Type"); got != ContentType {
+		t.Errorf("Expected the text exposition format, got %s", got)
+	}
+	return recorder.Body.String()
+}
+
+func expectLines(t *testing.T, body string, lines ...string) {
+	t.Helper()
+	for _, line := range lines {
+		if !strings.Contains(body, line+"\n") {
+			t.Errorf("Expected line %q in:\n%s", line, body)
+		}
+	}
+}
+
+func TestCollector(t *testing.T) {
+	system := component.CreateSystem(map[string]*component.Component{
+		"db":  component.Define("db", &fake{}),
+		"api": component.Define("api", &fake{stopErr: errors.New("drain timeout")}, "db"),
+	})
+	collector := NewCollector(0.5, 0.1)
+	system.Attach(collector)
+
+	expectLines(t, scrape(t, collector),
+		`component_state{component="db",state="not_started"} 1`,
+		`system_up 0`,
+	)
+
+	if err := system.Start(); err != nil {
+		t.Fatalf("Failed to start system: %v", err)
+	}
+	expectLines(t, scrape(t, collector),
+		"# TYPE component_start_duration_seconds histogram",
+		`component_start_duration_seconds_bucket{component="db",le="0.1"} 1`,
+		`component_start_duration_seconds_bucket{component="db",le="+Inf"} 1`,
+		`component_start_duration_seconds_count{component="db"} 1`,
+		`component_state{component="api",state="started"} 1`,
+		`component_state{component="api",state="stopped"} 0`,
+		`component_restarts_total{component="db"} 0`,
+		`system_up 1`,
+	)
+
+	if err := system.Stop(); err == nil {
+		t.Fatal("Expected the api stop failure")
+	}
+	if err := system.Start(); err != nil {
+		t.Fatalf("Failed to start system again: %v", err)
+	}
+	expectLines(t, scrape(t, collector),
+		`component_restarts_total{component="db"} 1`,
+		`component_stop_duration_seconds_count{component="db"} 1`,
+		`component_failures_total{component="api",op="stop"} 1`,
+		`component_failures_total{component="api",op="start"} 0`,
+	)
+
+	system.Stop()
+	expectLines(t, scrape(t, collector),
+		`system_up 0`,
+		`system_uptime_seconds 0`,
+	)
+}
+
+func TestLabelsEscaped(t *testing.T) {
+	if got := labels("component", `a"b\c`); got != `{component="a\"b\\c"}` {
+		t.Errorf("Expected escaped label values, got %s", got)
+	}
+}
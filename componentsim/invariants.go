@@ -0,0 +1,107 @@
+package componentsim
+
+import (
+	"fmt"
+
+	"github.com/leandroolgomes/golang-dependency-graph/component"
+)
+
+// DefaultInvariants are the ordering guarantees of the lifecycle
+var DefaultInvariants = []Invariant{StartsAfterDependencies, StopsBeforeDependencies}
+
+// StartsAfterDependencies checks that a component only starts while every
+// dependency is running
+func StartsAfterDependencies(run Run) error {
+	running := make(map[string]bool)
+	for _, step := range run.Steps {
+		if step.Op == component.OpStart {
+			for _, dep := range dependenciesOf(run, step.Key) {
+				if !running[dep] {
+					return fmt.Errorf("%s started at %v while its dependency %s was not running", step.Key, step.Time, dep)
+				}
+			}
+		}
+		track(running, step)
+	}
+	return nil
+}
+
+// StopsBeforeDependencies checks that a component only stops once no
+// running component depends on it
+func StopsBeforeDependencies(run Run) error {
+	running := make(map[string]bool)
+	for _, step := range run.Steps {
+		if step.Op == component.OpStop {
+			for _, spec := range run.Specs {
+				if running[spec.Key] && spec.Key != step.Key && contains(spec.Dependencies, step.Key) {
+					return fmt.Errorf("%s stopped at %v while its dependent %s was running", step.Key, step.Time, spec.Key)
+				}
+			}
+		}
+		track(running, step)
+	}
+	return nil
+}
+
+// NothingRunningAfterStop checks that no component is left running once a
+// Stop action returned, even when the Start before it failed
+func NothingRunningAfterStop(run Run) error {
+	for i, action := range run.Actions {
+		if action.Op != Stop.Op {
+			continue
+		}
+		for key, state := range run.States[i] {
+			if state == component.StateStarted || state == component.StateDegraded {
+				return fmt.Errorf("%s still %s after %s", key, state, describeAction(i, action))
+			}
+		}
+	}
+	return nil
+}
+
+// ActionsSucceedWithoutFaults checks that every action succeeds when no
+// fault is injected
+func ActionsSucceedWithoutFaults(run Run) error {
+	if len(run.Faults) > 0 {
+		return nil
+	}
+	for i, err := range run.Errors {
+		if err != nil {
+			return fmt.Errorf("%s failed without faults: %w", describeAction(i, run.Actions[i]), err)
+		}
+	}
+	return nil
+}
+
+// track updates the running components after a step: a successful start
+// runs the component; any stop, failed or not, ends it
+func track(running map[string]bool, step Step) {
+	switch {
+	case step.Op == component.OpStart && step.Err == nil:
+		running[step.Key] = true
+	case step.Op == component.OpStop:
+		delete(running, step.Key)
+	}
+}
+
+func dependenciesOf(run Run, key string) []string {
+	for _, spec := range run.Specs {
+		if spec.Key == key {
+			return spec.Dependencies
+		}
+	}
+	return nil
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func describeAction(i int, action Action) string {
+	return fmt.Sprintf("action %d (%s)", i+1, action)
+}
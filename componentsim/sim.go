@@ -0,0 +1,280 @@
+// Package componentsim runs the lifecycle of small component graphs
+// deterministically, with virtual time and injected failures, so their
+// behaviour under every combination of faults can be checked exhaustively
+// as a lightweight form of model checking
+package componentsim
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/leandroolgomes/golang-dependency-graph/component"
+)
+
+// Spec is a simulated component: its Start and Stop do nothing but take
+// their cost in virtual time and fail where a Fault says so
+type Spec struct {
+	Key          string
+	Dependencies []string
+	StartCost    time.Duration
+	StopCost     time.Duration
+}
+
+// Fault makes the given attempt, counted from 1, of a component's start or
+// stop fail
+type Fault struct {
+	Key     string
+	Op      string // component.OpStart or component.OpStop
+	Attempt int
+}
+
+func (f Fault) String() string {
+	return fmt.Sprintf("%s %s #%d", f.Op, f.Key, f.Attempt)
+}
+
+// ErrInjected is returned by simulated components from injected faults
+var ErrInjected = errors.New("injected fault")
+
+// Action is a lifecycle call made on the simulated system
+type Action struct {
+	Op string
+
+	// Key is the component swapped by a Swap action
+	Key string
+}
+
+// Actions of a scenario
+var (
+	Start   = Action{Op: "start"}
+	Stop    = Action{Op: "stop"}
+	Restart = Action{Op: "restart"}
+)
+
+// Swap replaces a component with an identical definition through
+// System.Apply, restarting it and its dependents with rollback on failure
+func Swap(key string) Action {
+	return Action{Op: "swap", Key: key}
+}
+
+func (a Action) String() string {
+	if a.Key != "" {
+		return a.Op + " " + a.Key
+	}
+	return a.Op
+}
+
+// Step is a Start or Stop call of a simulated component
+type Step struct {
+	// Time is the virtual time the call began at
+	Time    time.Duration
+	Action  int
+	Key     string
+	Op      string
+	Attempt int
+	Err     error
+}
+
+func (s Step) String() string {
+	text := fmt.Sprintf("%v %s %s #%d", s.Time, s.Op, s.Key, s.Attempt)
+	if s.Err != nil {
+		text += " failed"
+	}
+	return text
+}
+
+// Run is the outcome of one simulated scenario
+type Run struct {
+	Specs   []Spec
+	Faults  []Fault
+	Actions []Action
+
+	// Steps are the component calls in the order they happened
+	Steps []Step
+
+	// Errors holds the error of every action
+	Errors []error
+
+	// States are the component states after each action
+	States []map[string]component.State
+
+	// Duration is the virtual time the scenario took
+	Duration time.Duration
+}
+
+func (r Run) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "faults %v, actions %v\n", r.Faults, r.Actions)
+	for _, step := range r.Steps {
+		fmt.Fprintf(&b, "  %s\n", step)
+	}
+	return b.String()
+}
+
+// Simulate runs the actions, Start then Stop by default, on a fresh system
+// of the specs with the faults injected. Components start sequentially, so
+// the run only depends on its inputs
+func Simulate(specs []Spec, faults []Fault, actions ...Action) Run {
+	if len(actions) == 0 {
+		actions = []Action{Start, Stop}
+	}
+	run := Run{Specs: specs, Faults: faults, Actions: actions}
+	sim := &simulation{run: &run, faults: make(map[Fault]bool), attempts: make(map[string]int)}
+	for _, fault := range faults {
+		sim.faults[fault] = true
+	}
+
+	components := make(map[string]*component.Component, len(specs))
+	instances := make(map[string]*simComponent, len(specs))
+	for _, spec := range specs {
+		instances[spec.Key] = &simComponent{spec: spec, sim: sim}
+		components[spec.Key] = component.Define(spec.Key, instances[spec.Key], spec.Dependencies...)
+	}
+	system := component.CreateSystem(components, component.WithLogger(component.NopLogger))
+
+	for i, action := range actions {
+		sim.action = i
+		var err error
+		switch action.Op {
+		case Start.Op:
+			err = system.Start()
+		case Stop.Op:
+			err = system.Stop()
+		case Restart.Op:
+			err = system.Restart()
+		case "swap":
+			c, exists := system.Component(action.Key)
+			if !exists {
+				err = fmt.Errorf("component %s not found", action.Key)
+				break
+			}
+			_, err = system.Apply(component.Changeset{Swap: []*component.Component{
+				component.Define(action.Key, instances[action.Key], c.GetDependencies()...),
+			}})
+		default:
+			err = fmt.Errorf("unknown action %s", action.Op)
+		}
+		run.Errors = append(run.Errors, err)
+		states := make(map[string]component.State, len(specs))
+		for key, c := range components {
+			if current, exists := system.Component(key); exists {
+				c = current
+			}
+			states[key] = c.State()
+		}
+		run.States = append(run.States, states)
+	}
+	run.Duration = sim.now
+	return run
+}
+
+// simulation is the shared state of the components of one run
+type simulation struct {
+	run      *Run
+	now      time.Duration
+	action   int
+	faults   map[Fault]bool
+	attempts map[string]int
+}
+
+// call records a component call, advancing virtual time by its cost
+func (s *simulation) call(key, op string, cost time.Duration) error {
+	s.attempts[op+" "+key]++
+	attempt := s.attempts[op+" "+key]
+	step := Step{Time: s.now, Action: s.action, Key: key, Op: op, Attempt: attempt}
+	s.now += cost
+	if s.faults[Fault{Key: key, Op: op, Attempt: attempt}] {
+		step.Err = ErrInjected
+	}
+	s.run.Steps = append(s.run.Steps, step)
+	return step.Err
+}
+
+// simComponent is the Lifecycle of a Spec
+type simComponent struct {
+	spec Spec
+	sim  *simulation
+}
+
+func (c *simComponent) Start(ctx component.Context) (component.Lifecycle, error) {
+	return c, c.sim.call(c.spec.Key, component.OpStart, c.spec.StartCost)
+}
+
+func (c *simComponent) Stop(ctx component.Context) error {
+	return c.sim.call(c.spec.Key, component.OpStop, c.spec.StopCost)
+}
+
+// Invariant checks a property of a run, returning why it does not hold
+type Invariant func(Run) error
+
+// Violation is a run breaking an invariant
+type Violation struct {
+	Run Run
+	Err error
+}
+
+func (v Violation) Error() string {
+	return fmt.Sprintf("%v\n%s", v.Err, v.Run)
+}
+
+// Explore simulates the actions under every combination of at most
+// maxFaults faults, each failing one of the first attempts of a
+// component's start or stop, and returns the runs breaking an invariant.
+// Faults are enumerated in a fixed order, so the exploration is reproducible
+func Explore(specs []Spec, maxFaults int, invariants []Invariant, actions ...Action) []Violation {
+	if len(actions) == 0 {
+		actions = []Action{Start, Stop}
+	}
+	candidates := candidateFaults(specs, actions)
+
+	var violations []Violation
+	var explore func(next int, faults []Fault)
+	explore = func(next int, faults []Fault) {
+		run := Simulate(specs, append([]Fault(nil), faults...), actions...)
+		for _, invariant := range invariants {
+			if err := invariant(run); err != nil {
+				violations = append(violations, Violation{Run: run, Err: err})
+			}
+		}
+		if len(faults) == maxFaults {
+			return
+		}
+		for i := next; i < len(candidates); i++ {
+			explore(i+1, append(faults, candidates[i]))
+		}
+	}
+	explore(0, nil)
+	return violations
+}
+
+// candidateFaults lists the faults worth injecting: a failure of every
+// attempt a scenario of the actions can make
+func candidateFaults(specs []Spec, actions []Action) []Fault {
+	attempts := map[string]int{}
+	for _, action := range actions {
+		if action.Op != Stop.Op {
+			attempts[component.OpStart]++
+		}
+		if action.Op != Start.Op {
+			attempts[component.OpStop]++
+		}
+	}
+
+	keys := make([]string, 0, len(specs))
+	for _, spec := range specs {
+		keys = append(keys, spec.Key)
+	}
+	sort.Strings(keys)
+
+	var faults []Fault
+	for _, key := range keys {
+		for _, op := range []string{component.OpStart, component.OpStop} {
+			for attempt := 1; attempt <= attempts[op]; attempt++ {
+				faults = append(faults, Fault{Key: key, Op: op, Attempt: attempt})
+			}
+		}
+	}
+	return faults
+}
@@ -0,0 +1,96 @@
+package componentsim
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/leandroolgomes/golang-dependency-graph/component"
+)
+
+var graph = []Spec{
+	{Key: "db", StartCost: 30 * time.Millisecond, StopCost: 5 * time.Millisecond},
+	{Key: "cache", StartCost: 10 * time.Millisecond},
+	{Key: "api", Dependencies: []string{"db", "cache"}, StartCost: 20 * time.Millisecond, StopCost: time.Millisecond},
+}
+
+func TestSimulate(t *testing.T) {
+	run := Simulate(graph, nil)
+
+	var steps []string
+	for _, step := range run.Steps {
+		steps = append(steps, step.String())
+	}
+	want := []string{
+		"0s start cache #1", "10ms start db #1", "40ms start api #1",
+		"60ms stop api #1", "61ms stop db #1", "66ms stop cache #1",
+	}
+	if !reflect.DeepEqual(steps, want) {
+		t.Errorf("Expected steps %v, got %v", want, steps)
+	}
+	if run.Duration != 66*time.Millisecond {
+		t.Errorf("Expected 66ms of virtual time, got %v", run.Duration)
+	}
+	if run.Errors[0] != nil || run.Errors[1] != nil || run.States[0]["api"] != component.StateStarted || run.States[1]["api"] != component.StateStopped {
+		t.Errorf("Expected a clean start and stop, got errors %v and states %v", run.Errors, run.States)
+	}
+	if again := Simulate(graph, nil); !reflect.DeepEqual(again.Steps, run.Steps) {
+		t.Errorf("Expected a deterministic run, got %v then %v", run.Steps, again.Steps)
+	}
+}
+
+func TestSimulateFault(t *testing.T) {
+	run := Simulate(graph, []Fault{{Key: "db", Op: component.OpStart, Attempt: 1}}, Start)
+	if !errors.Is(run.Errors[0], ErrInjected) {
+		t.Fatalf("Expected the injected fault to fail the start, got %v", run.Errors[0])
+	}
+	if last := run.Steps[len(run.Steps)-1]; last.Key != "db" || last.Err == nil {
+		t.Errorf("Expected nothing to start after db failed, got %v", run.Steps)
+	}
+}
+
+func TestExploreOrdering(t *testing.T) {
+	invariants := append([]Invariant{ActionsSucceedWithoutFaults}, DefaultInvariants...)
+	for _, actions := range [][]Action{{Start, Stop}, {Start, Restart, Stop}, {Start, Swap("db"), Stop}} {
+		if violations := Explore(graph, 2, invariants, actions...); len(violations) > 0 {
+			t.Errorf("Expected no violations for %v, got %d, first:\n%v", actions, len(violations), violations[0])
+		}
+	}
+}
+
+func TestExploreUnwindsFailedStart(t *testing.T) {
+	if violations := Explore(graph, 1, []Invariant{NothingRunningAfterStop}); len(violations) > 0 {
+		t.Errorf("Expected Stop to unwind a failed start, got %d, first:\n%v", len(violations), violations[0])
+	}
+}
+
+func TestExploreFindsViolations(t *testing.T) {
+	violations := Explore(graph, 1, []Invariant{func(run Run) error {
+		for i, err := range run.Errors {
+			if err != nil {
+				return fmt.Errorf("%s failed: %w", describeAction(i, run.Actions[i]), err)
+			}
+		}
+		return nil
+	}})
+	if len(violations) == 0 {
+		t.Fatal("Expected the injected faults to fail an action")
+	}
+	if !strings.Contains(violations[0].Error(), "action 1 (start) failed") {
+		t.Errorf("Expected the failed action reported, got %v", violations[0])
+	}
+}
+
+func TestCandidateFaults(t *testing.T) {
+	faults := candidateFaults([]Spec{{Key: "db"}}, []Action{Start, Restart, Stop})
+	want := []Fault{
+		{Key: "db", Op: component.OpStart, Attempt: 1}, {Key: "db", Op: component.OpStart, Attempt: 2},
+		{Key: "db", Op: component.OpStop, Attempt: 1}, {Key: "db", Op: component.OpStop, Attempt: 2},
+	}
+	if !reflect.DeepEqual(faults, want) {
+		t.Errorf("Expected %v, got %v", want, faults)
+	}
+}
@@ -0,0 +1,71 @@
+package componenttest
+
+import (
+	"fmt"
+
+	"github.com/leandroolgomes/golang-dependency-graph/component"
+)
+
+// Stub stands in for a dependency of an isolated component. It records
+// whether it was started and stopped and exposes nothing else, so components
+// that type-assert a dependency to an interface need a Fake instead
+type Stub struct {
+	Key     string
+	Started bool
+	Stopped bool
+}
+
+func (s *Stub) Start(ctx component.Context) (component.Lifecycle, error) {
+	s.Started = true
+	return s, nil
+}
+
+func (s *Stub) Stop(ctx component.Context) error {
+	s.Stopped = true
+	return nil
+}
+
+// Fake replaces the dependency registered under Key with Instance
+type Fake struct {
+	Key      string
+	Instance component.Lifecycle
+}
+
+// Isolate builds a system holding only the component under key, wired to a
+// fake or a Stub for each of its direct dependencies; every other component
+// is pruned. The target keeps its instance, params and tags, so isolate a
+// system that is not running
+func Isolate(system *component.System, key string, fakes []Fake, opts ...component.Option) (*component.System, error) {
+	target, exists := system.Component(key)
+	if !exists {
+		return nil, fmt.Errorf("component %s not found", key)
+	}
+
+	byKey := make(map[string]component.Lifecycle, len(fakes))
+	for _, fake := range fakes {
+		byKey[fake.Key] = fake.Instance
+	}
+
+	components := make(map[string]*component.Component)
+	for _, dep := range system.ContextKeysOf(key) {
+		instance, ok := byKey[dep]
+		if !ok {
+			instance = &Stub{Key: dep}
+		}
+		delete(byKey, dep)
+		components[dep] = component.Define(dep, instance)
+	}
+	for fakeKey := range byKey {
+		return nil, fmt.Errorf("fake %s is not a dependency of %s", fakeKey, key)
+	}
+
+	isolated := component.Define(key, target.Instance(), target.GetDependencies()...).
+		WithTags(target.GetTags()...).
+		WithMetadata(target.GetMetadata())
+	if params := target.GetParams(); params != nil {
+		isolated.WithParams(params)
+	}
+	components[key] = isolated
+
+	return component.CreateSystem(components, opts...), nil
+}
@@ -0,0 +1,88 @@
+package componenttest
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/leandroolgomes/golang-dependency-graph/component"
+)
+
+// Store is the interface the handler expects from its store dependency
+type Store interface {
+	Get(key string) string
+}
+
+type fakeStore struct{}
+
+func (f *fakeStore) Start(ctx component.Context) (component.Lifecycle, error) {
+	return f, nil
+}
+
+func (f *fakeStore) Stop(ctx component.Context) error {
+	return nil
+}
+
+func (f *fakeStore) Get(key string) string {
+	return "fake:" + key
+}
+
+// handler reads both of its dependencies during Start
+type handler struct {
+	noop
+	got string
+}
+
+func (h *handler) Start(ctx component.Context) (component.Lifecycle, error) {
+	store, ok := ctx["store"].(Store)
+	if !ok {
+		return nil, errors.New("store does not implement Store")
+	}
+	if ctx["cache"] == nil {
+		return nil, errors.New("cache missing")
+	}
+	h.got = store.Get("greeting")
+	return h, nil
+}
+
+func TestIsolate(t *testing.T) {
+	h := &handler{}
+	system := component.CreateSystem(map[string]*component.Component{
+		"db":      component.Define("db", &noop{}),
+		"store":   component.Define("store", &noop{}, "db"),
+		"cache":   component.Define("cache", &noop{}),
+		"handler": component.Define("handler", h, "store", "cache").WithTags("http"),
+	})
+
+	isolated, err := Isolate(system, "handler", []Fake{{Key: "store", Instance: &fakeStore{}}})
+	if err != nil {
+		t.Fatalf("Failed to isolate: %v", err)
+	}
+	if _, exists := isolated.Component("db"); exists {
+		t.Error("Expected transitive dependencies to be pruned")
+	}
+
+	if err := isolated.Start(); err != nil {
+		t.Fatalf("Failed to start isolated system: %v", err)
+	}
+	defer isolated.Stop()
+
+	if h.got != "fake:greeting" {
+		t.Errorf("Expected the handler to use the fake store, got %q", h.got)
+	}
+	cache, _ := isolated.Component("cache")
+	if stub, ok := cache.Instance().(*Stub); !ok || !stub.Started {
+		t.Error("Expected cache to be replaced by a started stub")
+	}
+}
+
+func TestIsolateRejectsUnknownFake(t *testing.T) {
+	system := component.CreateSystem(map[string]*component.Component{
+		"handler": component.Define("handler", &noop{}),
+	})
+	if _, err := Isolate(system, "handler", []Fake{{Key: "store", Instance: &noop{}}}); err == nil {
+		t.Error("Expected a fake for a key the component does not depend on to fail")
+	}
+	if _, err := Isolate(system, "missing", nil); err == nil {
+		t.Error("Expected isolating an unknown component to fail")
+	}
+}
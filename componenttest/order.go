@@ -0,0 +1,183 @@
+// Package componenttest provides helpers for testing component systems
+package componenttest
+
+import (
+	"fmt"
+	"sort"
+	"testing"
+
+	"github.com/leandroolgomes/golang-dependency-graph/component"
+)
+
+// Builder creates the system under test, applying the extra options the
+// helpers need to observe it
+type Builder func(opts ...component.Option) *component.System
+
+// CheckOrderContract starts and stops the system built by build and fails t
+// if the documented ordering contract is violated:
+//
+//   - every component starts after all of its dependencies
+//   - EffectiveOrder matches the order components were observed starting in
+//   - Stop stops the running components, skipping one-shot components that
+//     already completed, in the batches of ShutdownOrder: stage by stage
+//     WithShutdownStages, and within a stage the exact reverse of
+//     EffectiveOrder when stopping sequentially, level by level
+//     WithParallelStop
+//   - no component stops before the components depending on it
+//
+// Run it against a system configured with the same options as production
+func CheckOrderContract(t testing.TB, build Builder) {
+	t.Helper()
+
+	var started, stopped []string
+	completed := make(map[string]bool)
+	system := build(component.WithEventListener(func(e component.Event) {
+		switch e.Type {
+		case component.EventComponentStarted:
+			started = append(started, e.Component)
+		case component.EventComponentCompleted:
+			started = append(started, e.Component)
+			completed[e.Component] = true
+		case component.EventComponentStopped:
+			stopped = append(stopped, e.Component)
+		}
+	}))
+
+	if err := system.Start(); err != nil {
+		t.Fatalf("failed to start system: %v", err)
+	}
+	order := system.EffectiveOrder()
+	batches := system.ShutdownOrder()
+
+	if err := system.Stop(); err != nil {
+		t.Fatalf("failed to stop system: %v", err)
+	}
+
+	if err := VerifyStartOrder(order, system.DependenciesOf); err != nil {
+		t.Error(err)
+	}
+	if err := sameOrder("observed start order", started, order); err != nil {
+		t.Error(err)
+	}
+
+	// One-shot components finish during Start and are never stopped
+	var running []string
+	for _, key := range order {
+		if !completed[key] {
+			running = append(running, key)
+		}
+	}
+	var planned [][]string
+	var unwound []string
+	for _, batch := range batches {
+		var keys []string
+		for _, key := range batch {
+			if !completed[key] {
+				keys = append(keys, key)
+			}
+		}
+		if len(keys) > 0 {
+			planned = append(planned, keys)
+			unwound = append(unwound, keys...)
+		}
+	}
+	if err := sameSet("shutdown order", unwound, running); err != nil {
+		t.Error(err)
+	}
+	if err := VerifyStopDependencies(unwound, system.DependenciesOf); err != nil {
+		t.Error(err)
+	}
+	if err := VerifyShutdownOrder(planned, stopped); err != nil {
+		t.Error(err)
+	}
+}
+
+// VerifyStartOrder checks that every component in order appears after all
+// of its dependencies
+func VerifyStartOrder(order []string, dependencies func(key string) []string) error {
+	position := make(map[string]int, len(order))
+	for i, key := range order {
+		position[key] = i
+	}
+
+	for i, key := range order {
+		for _, dep := range dependencies(key) {
+			depPosition, ok := position[dep]
+			if !ok {
+				return fmt.Errorf("component %s started but its dependency %s did not", key, dep)
+			}
+			if depPosition > i {
+				return fmt.Errorf("component %s started before its dependency %s", key, dep)
+			}
+		}
+	}
+	return nil
+}
+
+// VerifyStopOrder checks that stopOrder is the exact reverse of startOrder
+func VerifyStopOrder(startOrder, stopOrder []string) error {
+	reversed := make([]string, len(startOrder))
+	for i, key := range startOrder {
+		reversed[len(startOrder)-1-i] = key
+	}
+	return sameOrder("stop order", stopOrder, reversed)
+}
+
+// VerifyStopDependencies checks that every component in stopOrder appears
+// after all of the components depending on it
+func VerifyStopDependencies(stopOrder []string, dependencies func(key string) []string) error {
+	position := make(map[string]int, len(stopOrder))
+	for i, key := range stopOrder {
+		position[key] = i
+	}
+
+	for i, key := range stopOrder {
+		for _, dep := range dependencies(key) {
+			if depPosition, ok := position[dep]; ok && depPosition < i {
+				return fmt.Errorf("component %s stopped after its dependency %s", key, dep)
+			}
+		}
+	}
+	return nil
+}
+
+// VerifyShutdownOrder checks that stopOrder stops the batches one after the
+// other, the components of each batch in any order
+func VerifyShutdownOrder(batches [][]string, stopOrder []string) error {
+	mismatch := fmt.Errorf("stop order %v does not match expected batches %v", stopOrder, batches)
+	rest := stopOrder
+	for _, batch := range batches {
+		if len(rest) < len(batch) || sameSet("stop order", rest[:len(batch)], batch) != nil {
+			return mismatch
+		}
+		rest = rest[len(batch):]
+	}
+	if len(rest) > 0 {
+		return mismatch
+	}
+	return nil
+}
+
+// sameSet checks that actual holds the keys of expected, in any order
+func sameSet(name string, actual, expected []string) error {
+	sortedActual := append([]string(nil), actual...)
+	sortedExpected := append([]string(nil), expected...)
+	sort.Strings(sortedActual)
+	sort.Strings(sortedExpected)
+	if sameOrder(name, sortedActual, sortedExpected) != nil {
+		return fmt.Errorf("%s %v does not match expected %v", name, actual, expected)
+	}
+	return nil
+}
+
+func sameOrder(name string, actual, expected []string) error {
+	if len(actual) != len(expected) {
+		return fmt.Errorf("%s %v does not match expected %v", name, actual, expected)
+	}
+	for i := range actual {
+		if actual[i] != expected[i] {
+			return fmt.Errorf("%s %v does not match expected %v", name, actual, expected)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,100 @@
+package componenttest
+
+import (
+	"testing"
+
+	"github.com/leandroolgomes/golang-dependency-graph/component"
+)
+
+type noop struct{}
+
+func (n *noop) Start(ctx component.Context) (component.Lifecycle, error) {
+	return n, nil
+}
+
+func (n *noop) Stop(ctx component.Context) error {
+	return nil
+}
+
+func TestCheckOrderContract(t *testing.T) {
+	CheckOrderContract(t, func(opts ...component.Option) *component.System {
+		return component.CreateSystem(map[string]*component.Component{
+			"config":      component.Define("config", &noop{}),
+			"db":          component.Define("db", &noop{}, "config"),
+			"migrations":  component.DefineOneShot("migrations", &noop{}, "db"),
+			"cache":       component.Define("cache", &noop{}, "config"),
+			"http_server": component.Define("http_server", &noop{}, "migrations", "cache"),
+		}, opts...)
+	})
+}
+
+func TestVerifyStartOrder(t *testing.T) {
+	deps := map[string][]string{"b": {"a"}}
+	lookup := func(key string) []string { return deps[key] }
+
+	if err := VerifyStartOrder([]string{"a", "b"}, lookup); err != nil {
+		t.Errorf("Expected valid order, got %v", err)
+	}
+	if err := VerifyStartOrder([]string{"b", "a"}, lookup); err == nil {
+		t.Error("Expected dependency started after dependent to be reported")
+	}
+}
+
+func TestVerifyStopOrder(t *testing.T) {
+	if err := VerifyStopOrder([]string{"a", "b", "c"}, []string{"c", "b", "a"}); err != nil {
+		t.Errorf("Expected exact reverse to pass, got %v", err)
+	}
+	if err := VerifyStopOrder([]string{"a", "b", "c"}, []string{"c", "a", "b"}); err == nil {
+		t.Error("Expected out of order stop to be reported")
+	}
+}
+
+func TestVerifyShutdownOrder(t *testing.T) {
+	batches := [][]string{{"c"}, {"a", "b"}}
+	if err := VerifyShutdownOrder(batches, []string{"c", "b", "a"}); err != nil {
+		t.Errorf("Expected a batch stopped in any order to pass, got %v", err)
+	}
+	if err := VerifyShutdownOrder(batches, []string{"b", "c", "a"}); err == nil {
+		t.Error("Expected a stop ahead of its batch to be reported")
+	}
+
+	deps := map[string][]string{"c": {"a", "b"}}
+	if err := VerifyStopDependencies([]string{"a", "c", "b"}, func(key string) []string { return deps[key] }); err == nil {
+		t.Error("Expected a dependency stopped before its dependent to be reported")
+	}
+}
+
+func TestCheckOrderContractParallelStart(t *testing.T) {
+	CheckOrderContract(t, func(opts ...component.Option) *component.System {
+		return component.CreateSystem(map[string]*component.Component{
+			"config":      component.Define("config", &noop{}),
+			"db":          component.Define("db", &noop{}, "config"),
+			"cache":       component.Define("cache", &noop{}, "config"),
+			"queue":       component.Define("queue", &noop{}, "config"),
+			"http_server": component.Define("http_server", &noop{}, "db", "cache", "queue"),
+		}, append(opts, component.WithParallelStart(3))...)
+	})
+}
+
+func TestCheckOrderContractParallelStop(t *testing.T) {
+	CheckOrderContract(t, func(opts ...component.Option) *component.System {
+		return component.CreateSystem(map[string]*component.Component{
+			"config":      component.Define("config", &noop{}),
+			"db":          component.Define("db", &noop{}, "config"),
+			"cache":       component.Define("cache", &noop{}, "config"),
+			"queue":       component.Define("queue", &noop{}, "config"),
+			"http_server": component.Define("http_server", &noop{}, "db", "cache", "queue"),
+		}, append(opts, component.WithParallelStop(3))...)
+	})
+}
+
+func TestCheckOrderContractShutdownStages(t *testing.T) {
+	CheckOrderContract(t, func(opts ...component.Option) *component.System {
+		return component.CreateSystem(map[string]*component.Component{
+			"config": component.Define("config", &noop{}),
+			"api":    component.Define("api", &noop{}, "config").WithTags("ingress"),
+			"db":     component.Define("db", &noop{}, "config"),
+			"worker": component.Define("worker", &noop{}, "db").WithTags("worker"),
+		}, append(opts, component.WithTwoStageShutdown(0))...)
+	})
+}
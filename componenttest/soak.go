@@ -0,0 +1,114 @@
+package componenttest
+
+import (
+	"fmt"
+	"runtime"
+	"runtime/pprof"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/leandroolgomes/golang-dependency-graph/component"
+)
+
+const (
+	// SoakHeapGrowth is the heap growth between the first and the last
+	// cycle of Soak above which components are reported as leaking memory
+	SoakHeapGrowth = 8 << 20
+
+	// SoakSettleTimeout is how long Soak waits for goroutines of stopped
+	// systems to exit before reporting them as leaked
+	SoakSettleTimeout = time.Second
+)
+
+// Soak builds, starts and stops a system n times and fails t when cycles
+// leak or do not reset:
+//
+//   - a cycle fails to start or stop
+//   - a component is not stopped, or completed for one-shots, after Stop
+//   - a cycle starts in a different effective order or ends with different
+//     states than the first cycle
+//   - goroutines outlive their system, compared to the count after the
+//     first cycle, which absorbs one-time initialization
+//   - the heap grows by more than SoakHeapGrowth between the first and the
+//     last cycle
+//
+// build must create fresh components on every call; reusing instances on
+// purpose checks that they fully reset on Stop
+func Soak(t testing.TB, build Builder, n int) {
+	t.Helper()
+
+	var firstOrder []string
+	var firstStates map[string]component.State
+	var baseGoroutines int
+	var baseHeap uint64
+	for cycle := 1; cycle <= n; cycle++ {
+		system := build()
+		if err := system.Start(); err != nil {
+			t.Fatalf("cycle %d: failed to start system: %v", cycle, err)
+		}
+		order := system.EffectiveOrder()
+		if err := system.Stop(); err != nil {
+			t.Fatalf("cycle %d: failed to stop system: %v", cycle, err)
+		}
+
+		states := make(map[string]component.State)
+		for _, c := range system.GraphExport().Components {
+			states[c.Key] = c.State
+			if c.State != component.StateStopped && c.State != component.StateCompleted {
+				t.Errorf("cycle %d: component %s is %s after Stop", cycle, c.Key, c.State)
+			}
+		}
+
+		if cycle == 1 {
+			firstOrder, firstStates = order, states
+			baseGoroutines = runtime.NumGoroutine()
+			baseHeap = heapInUse()
+			continue
+		}
+		if err := sameOrder(fmt.Sprintf("cycle %d: effective order", cycle), order, firstOrder); err != nil {
+			t.Error(err)
+		}
+		for key, state := range states {
+			if state != firstStates[key] {
+				t.Errorf("cycle %d: component %s ended %s, was %s on the first cycle", cycle, key, state, firstStates[key])
+			}
+		}
+		if t.Failed() {
+			return
+		}
+	}
+	if n < 2 {
+		return
+	}
+
+	if goroutines := settledGoroutines(baseGoroutines); goroutines > baseGoroutines {
+		var dump strings.Builder
+		pprof.Lookup("goroutine").WriteTo(&dump, 1)
+		t.Errorf("goroutines grew from %d to %d over %d cycles:\n%s", baseGoroutines, goroutines, n, dump.String())
+	}
+	if heap := heapInUse(); heap > baseHeap+SoakHeapGrowth {
+		t.Errorf("heap grew from %d to %d bytes over %d cycles", baseHeap, heap, n)
+	}
+}
+
+// settledGoroutines returns the goroutine count once it dropped to target,
+// or the last count after SoakSettleTimeout
+func settledGoroutines(target int) int {
+	deadline := time.Now().Add(SoakSettleTimeout)
+	for {
+		count := runtime.NumGoroutine()
+		if count <= target || time.Now().After(deadline) {
+			return count
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+// heapInUse returns the live heap after a collection
+func heapInUse() uint64 {
+	runtime.GC()
+	var stats runtime.MemStats
+	runtime.ReadMemStats(&stats)
+	return stats.HeapInuse
+}
@@ -0,0 +1,119 @@
+package componenttest
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/leandroolgomes/golang-dependency-graph/component"
+)
+
+// recorder collects the failures of a helper under test
+type recorder struct {
+	testing.TB
+	errors []string
+	mu     sync.Mutex
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Errorf(format string, args ...interface{}) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+func (r *recorder) Fatalf(format string, args ...interface{}) {
+	r.Errorf(format, args...)
+	runtime.Goexit()
+}
+
+func (r *recorder) Failed() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.errors) > 0
+}
+
+// run calls fn with the recorder, returning once it finished or failed fatally
+func (r *recorder) run(fn func(t testing.TB)) []string {
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		fn(r)
+	}()
+	<-done
+	return r.errors
+}
+
+// leaky starts a goroutine that never exits
+type leaky struct {
+	block chan struct{}
+}
+
+func (l *leaky) Start(ctx component.Context) (component.Lifecycle, error) {
+	go func() { <-l.block }()
+	return l, nil
+}
+
+func (l *leaky) Stop(ctx component.Context) error {
+	return nil
+}
+
+// latch can only be started once, like a component that does not reset
+type latch struct {
+	used bool
+}
+
+func (l *latch) Start(ctx component.Context) (component.Lifecycle, error) {
+	if l.used {
+		return nil, fmt.Errorf("already used")
+	}
+	l.used = true
+	return l, nil
+}
+
+func (l *latch) Stop(ctx component.Context) error {
+	return nil
+}
+
+func TestSoak(t *testing.T) {
+	Soak(t, func(opts ...component.Option) *component.System {
+		return component.CreateSystem(map[string]*component.Component{
+			"config":     component.Define("config", &noop{}),
+			"db":         component.Define("db", &noop{}, "config"),
+			"migrations": component.DefineOneShot("migrations", &noop{}, "db"),
+		}, opts...)
+	}, 20)
+}
+
+func TestSoakDetectsGoroutineLeak(t *testing.T) {
+	block := make(chan struct{})
+	defer close(block)
+
+	errors := (&recorder{}).run(func(r testing.TB) {
+		Soak(r, func(opts ...component.Option) *component.System {
+			return component.CreateSystem(map[string]*component.Component{
+				"worker": component.Define("worker", &leaky{block: block}),
+			}, opts...)
+		}, 5)
+	})
+	if len(errors) != 1 || !strings.Contains(errors[0], "goroutines grew") {
+		t.Errorf("Expected the leaked goroutines to be reported, got %v", errors)
+	}
+}
+
+func TestSoakDetectsReusedState(t *testing.T) {
+	reused := &latch{}
+	errors := (&recorder{}).run(func(r testing.TB) {
+		Soak(r, func(opts ...component.Option) *component.System {
+			return component.CreateSystem(map[string]*component.Component{
+				"latch": component.Define("latch", reused),
+			}, opts...)
+		}, 3)
+	})
+	if len(errors) != 1 || !strings.Contains(errors[0], "cycle 2: failed to start") {
+		t.Errorf("Expected the second cycle to fail, got %v", errors)
+	}
+}
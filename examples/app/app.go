@@ -0,0 +1,82 @@
+// Package app is a small but complete service wired with the component
+// system: configuration, storage with migrations and a cache, HTTP and RPC
+// edges, a background worker, metrics and an admin server. It serves as
+// executable documentation and as an integration test bed
+package app
+
+import (
+	"github.com/leandroolgomes/golang-dependency-graph/component"
+	"github.com/leandroolgomes/golang-dependency-graph/componenthttp"
+	"github.com/leandroolgomes/golang-dependency-graph/componentprom"
+)
+
+// SchemaVersion is the schema version the migrations bring the database to
+const SchemaVersion = 3
+
+// App is the wired application
+type App struct {
+	System  *component.System
+	DB      *Database
+	Cache   *Cache
+	HTTP    *HTTPServer
+	RPC     *RPCServer
+	Worker  *Worker
+	Metrics *MetricsServer
+	Admin   *componenthttp.Admin
+}
+
+// New wires the application for a profile. Extra options are applied after
+// the profile's own
+func New(profile Profile, opts ...component.Option) (*App, error) {
+	settings := Defaults(profile)
+	app := &App{
+		DB:      new(Database),
+		Cache:   new(Cache),
+		HTTP:    new(HTTPServer),
+		RPC:     new(RPCServer),
+		Worker:  new(Worker),
+		Metrics: &MetricsServer{Collector: componentprom.NewCollector()},
+		Admin:   componenthttp.NewAdmin(settings.AdminAddr),
+	}
+
+	options := []component.Option{
+		component.WithLogger(logger(profile)),
+		component.WithParallelStart(4),
+		component.WithHealthInterval(settings.HealthInterval),
+		component.WithHealthPolicy(component.HealthPolicy{FailureThreshold: 3, MaxRestarts: 5, HoldUntilDependenciesHealthy: true}),
+		component.WithStopDeadline(settings.StopDeadline),
+	}
+
+	system, err := component.NewBuilder(append(options, opts...)...).
+		Add(component.Define("config", new(Config)).WithParams(settings)).
+		Add(component.Define("db", app.DB).WithTags("storage")).
+		Add(component.DefineOneShot("migrations", &Migrations{Version: SchemaVersion}, "db").WithTags("setup")).
+		Add(component.Define("cache", app.Cache, "db", "migrations").WithTags("storage")).
+		Add(component.Define("http_server", app.HTTP, "config", "cache").WithTags("edge")).
+		Add(component.Define("rpc_server", app.RPC, "config", "cache").WithTags("edge")).
+		Add(component.Define("worker", app.Worker, "config", "db", "migrations").WithTags("background")).
+		Add(component.Define("metrics", app.Metrics, "config").WithTags("ops")).
+		Add(component.Define("admin", app.Admin).WithTags("ops")).
+		Build()
+	if err != nil {
+		return nil, err
+	}
+
+	system.Attach(app.Metrics.Collector)
+	app.HTTP.Bind(system)
+	app.Admin.Bind(system)
+	app.System = system
+	return app, nil
+}
+
+// logger returns the logger of a profile
+func logger(profile Profile) component.Logger {
+	switch profile {
+	case Test:
+		return component.NopLogger
+	case Dev:
+		return component.NewTextLogger(nil, true)
+	default:
+		return component.DefaultLogger
+	}
+}
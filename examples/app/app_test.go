@@ -0,0 +1,170 @@
+package app
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/rpc"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/leandroolgomes/golang-dependency-graph/component"
+)
+
+func startTestApp(t *testing.T) *App {
+	t.Helper()
+	app, err := New(Test)
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	if err := app.System.Start(); err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+	t.Cleanup(func() { app.System.Stop() })
+	return app
+}
+
+func get(t *testing.T, url string) (int, string) {
+	t.Helper()
+	resp, err := http.Get(url)
+	if err != nil {
+		t.Fatalf("GET %s failed: %v", url, err)
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+	return resp.StatusCode, string(body)
+}
+
+func put(t *testing.T, url, body string) int {
+	t.Helper()
+	req, _ := http.NewRequest(http.MethodPut, url, strings.NewReader(body))
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("PUT %s failed: %v", url, err)
+	}
+	resp.Body.Close()
+	return resp.StatusCode
+}
+
+func TestAppServesItems(t *testing.T) {
+	app := startTestApp(t)
+	base := "http://" + app.HTTP.Addr() + "/items/"
+
+	if status, _ := get(t, base+"greeting"); status != http.StatusNotFound {
+		t.Errorf("Expected 404 for a missing item, got %d", status)
+	}
+	if status := put(t, base+"greeting", "hello"); status != http.StatusNoContent {
+		t.Fatalf("Expected 204 for PUT, got %d", status)
+	}
+	if status, body := get(t, base+"greeting"); status != http.StatusOK || body != "hello" {
+		t.Errorf("Expected 200 hello, got %d %q", status, body)
+	}
+
+	client, err := rpc.Dial("tcp", app.RPC.Addr())
+	if err != nil {
+		t.Fatalf("Dial RPC failed: %v", err)
+	}
+	defer client.Close()
+	var value string
+	if err := client.Call("Items.Get", "greeting", &value); err != nil || value != "hello" {
+		t.Errorf("Expected RPC to return hello, got %q, %v", value, err)
+	}
+	if app.Cache.Hits() == 0 {
+		t.Error("Expected reads to be served from the cache")
+	}
+}
+
+func TestAppRunsMigrationsBeforeServing(t *testing.T) {
+	app := startTestApp(t)
+	if app.DB.Schema() != SchemaVersion {
+		t.Errorf("Expected schema %d, got %d", SchemaVersion, app.DB.Schema())
+	}
+	if state := app.System.OneShots()["migrations"]; state != component.StateCompleted {
+		t.Errorf("Expected migrations to be completed, got %s", state)
+	}
+}
+
+func TestAppReportsHealthAndMetrics(t *testing.T) {
+	app := startTestApp(t)
+
+	if status, body := get(t, "http://"+app.Admin.Addr()+"/components"); status != http.StatusOK || !strings.Contains(body, "http_server") {
+		t.Errorf("Expected admin to list components, got %d %q", status, body)
+	}
+	if status, _ := get(t, "http://"+app.Admin.Addr()+"/health"); status != http.StatusOK {
+		t.Errorf("Expected healthy system, got %d", status)
+	}
+	if _, body := get(t, "http://"+app.Metrics.Addr()+"/metrics"); !strings.Contains(body, "http_server") {
+		t.Errorf("Expected metrics for http_server, got %q", body)
+	}
+}
+
+func TestAppGatesRequestsOnStorage(t *testing.T) {
+	app := startTestApp(t)
+	url := "http://" + app.HTTP.Addr() + "/items/greeting"
+	put(t, url, "hello")
+
+	if err := app.System.Degrade("db", errors.New("replica lag")); err != nil {
+		t.Fatalf("Degrade failed: %v", err)
+	}
+	if status, _ := get(t, url); status != http.StatusServiceUnavailable {
+		t.Errorf("Expected 503 while the database is degraded, got %d", status)
+	}
+	if err := app.System.Recover("db"); err != nil {
+		t.Fatalf("Recover failed: %v", err)
+	}
+	if status, _ := get(t, url); status != http.StatusOK {
+		t.Errorf("Expected 200 once the database recovered, got %d", status)
+	}
+}
+
+func TestAppRestartsUnhealthyDatabase(t *testing.T) {
+	restarted := make(chan struct{}, 1)
+	app, err := New(Test, component.WithEventListener(func(event component.Event) {
+		if event.Type == component.EventHealthRestart && event.Component == "db" {
+			select {
+			case restarted <- struct{}{}:
+			default:
+			}
+		}
+	}))
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	if err := app.System.Start(); err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+	defer app.System.Stop()
+
+	app.Cache.Put("greeting", "hello")
+	app.DB.SetHealthy(false)
+	select {
+	case <-restarted:
+	case <-time.After(2 * time.Second):
+		t.Fatal("Expected the unhealthy database to be restarted")
+	}
+	if value, err := app.DB.Get("greeting"); err != nil || value != "hello" {
+		t.Errorf("Expected the restart to keep the items, got %q, %v", value, err)
+	}
+}
+
+func TestAppStopsGracefully(t *testing.T) {
+	app, err := New(Test)
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	if err := app.System.Start(); err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+	addr := app.HTTP.Addr()
+	time.Sleep(30 * time.Millisecond)
+	if err := app.System.Stop(); err != nil {
+		t.Fatalf("Stop failed: %v", err)
+	}
+	if app.Worker.Runs() == 0 {
+		t.Error("Expected the worker to run while the system was up")
+	}
+	if _, err := http.Get("http://" + addr + "/items/greeting"); err == nil {
+		t.Error("Expected the HTTP server to be closed after Stop")
+	}
+}
@@ -0,0 +1,88 @@
+package app
+
+import (
+	"time"
+
+	"github.com/leandroolgomes/golang-dependency-graph/component"
+)
+
+// Profile selects the defaults of the application settings
+type Profile string
+
+const (
+	// Dev listens on fixed local ports and logs debug lines
+	Dev Profile = "dev"
+
+	// Test listens on ephemeral ports, polls health quickly and logs nothing
+	Test Profile = "test"
+
+	// Prod listens on all interfaces
+	Prod Profile = "prod"
+)
+
+// Settings are the application settings
+type Settings struct {
+	HTTPAddr       string
+	RPCAddr        string
+	MetricsAddr    string
+	AdminAddr      string
+	WorkerInterval time.Duration
+	HealthInterval time.Duration
+	StopDeadline   time.Duration
+}
+
+// Defaults returns the settings of a profile
+func Defaults(profile Profile) Settings {
+	settings := Settings{
+		HTTPAddr:       "127.0.0.1:8080",
+		RPCAddr:        "127.0.0.1:8081",
+		MetricsAddr:    "127.0.0.1:9100",
+		AdminAddr:      "127.0.0.1:9090",
+		WorkerInterval: time.Minute,
+		HealthInterval: 5 * time.Second,
+		StopDeadline:   10 * time.Second,
+	}
+	switch profile {
+	case Test:
+		settings.HTTPAddr = "127.0.0.1:0"
+		settings.RPCAddr = "127.0.0.1:0"
+		settings.MetricsAddr = "127.0.0.1:0"
+		settings.AdminAddr = "127.0.0.1:0"
+		settings.WorkerInterval = 10 * time.Millisecond
+		settings.HealthInterval = 20 * time.Millisecond
+		settings.StopDeadline = 2 * time.Second
+	case Prod:
+		settings.HTTPAddr = ":8080"
+		settings.RPCAddr = ":8081"
+		settings.MetricsAddr = ":9100"
+	}
+	return settings
+}
+
+// Config resolves the settings of the running application: the profile
+// defaults given as params, overridden by CONFIG__* environment variables,
+// e.g. CONFIG__HTTP_ADDR
+type Config struct {
+	Settings
+}
+
+func (c *Config) Start(ctx component.Context) (component.Lifecycle, error) {
+	defaults, _ := component.ParamsAs[Settings](ctx)
+	env := ctx.Env()
+
+	c.Settings = defaults
+	c.HTTPAddr = env.String("HTTP_ADDR", defaults.HTTPAddr)
+	c.RPCAddr = env.String("RPC_ADDR", defaults.RPCAddr)
+	c.MetricsAddr = env.String("METRICS_ADDR", defaults.MetricsAddr)
+
+	var err error
+	if c.WorkerInterval, err = env.Duration("WORKER_INTERVAL", defaults.WorkerInterval); err != nil {
+		return nil, err
+	}
+	ctx.Logger().Debug("Configuration resolved", "http_addr", c.HTTPAddr, "rpc_addr", c.RPCAddr)
+	return c, nil
+}
+
+func (c *Config) Stop(ctx component.Context) error {
+	return nil
+}
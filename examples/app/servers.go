@@ -0,0 +1,230 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/rpc"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/leandroolgomes/golang-dependency-graph/component"
+	"github.com/leandroolgomes/golang-dependency-graph/componenthttp"
+	"github.com/leandroolgomes/golang-dependency-graph/componentprom"
+)
+
+// server owns a listener and the address it resolved to
+type server struct {
+	listener net.Listener
+	mu       sync.Mutex
+}
+
+// listen opens the listener of a server
+func (s *server) listen(addr string) (net.Listener, error) {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.listener = listener
+	return listener, nil
+}
+
+// Addr returns the address the server listens on once started
+func (s *server) Addr() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.listener == nil {
+		return ""
+	}
+	return s.listener.Addr().String()
+}
+
+// shutdown gracefully stops an http.Server within the stop deadline
+func shutdown(ctx component.Context, srv *http.Server) error {
+	deadline, ok := ctx.StopDeadline()
+	if !ok {
+		deadline = time.Now().Add(5 * time.Second)
+	}
+	shutdownCtx, cancel := context.WithDeadline(context.Background(), deadline)
+	defer cancel()
+	return srv.Shutdown(shutdownCtx)
+}
+
+// HTTPServer serves the item API:
+//
+//	GET /items/{key}
+//	PUT /items/{key}
+//
+// Requests are rejected with 503 while the storage components are down
+type HTTPServer struct {
+	server
+	system *component.System
+	http   *http.Server
+}
+
+// Bind sets the system whose storage availability gates requests
+func (h *HTTPServer) Bind(system *component.System) *HTTPServer {
+	h.system = system
+	return h
+}
+
+func (h *HTTPServer) Start(ctx component.Context) (component.Lifecycle, error) {
+	config, ok := component.DependencyAs[*Config](ctx, "config")
+	if !ok {
+		return nil, errors.New("config dependency missing")
+	}
+	cache, ok := component.DependencyAs[*Cache](ctx, "cache")
+	if !ok {
+		return nil, errors.New("cache dependency missing")
+	}
+
+	listener, err := h.listen(config.HTTPAddr)
+	if err != nil {
+		return nil, err
+	}
+	var handler http.Handler = itemsHandler(cache, ctx.Logger())
+	if h.system != nil {
+		handler = componenthttp.RequireTags(h.system, "storage")(handler)
+	}
+	mux := http.NewServeMux()
+	mux.Handle("/items/", handler)
+	h.http = &http.Server{Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+	go h.http.Serve(listener)
+	return h, nil
+}
+
+func (h *HTTPServer) Stop(ctx component.Context) error {
+	return shutdown(ctx, h.http)
+}
+
+// itemsHandler reads and writes items through the cache
+func itemsHandler(cache *Cache, logger component.Logger) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key := strings.TrimPrefix(r.URL.Path, "/items/")
+		if key == "" {
+			http.Error(w, "missing item key", http.StatusBadRequest)
+			return
+		}
+
+		switch r.Method {
+		case http.MethodGet:
+			value, err := cache.Get(key)
+			if errors.Is(err, ErrNotFound) {
+				http.Error(w, err.Error(), http.StatusNotFound)
+				return
+			}
+			io.WriteString(w, value)
+		case http.MethodPut:
+			body, err := io.ReadAll(io.LimitReader(r.Body, 1<<20))
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			cache.Put(key, string(body))
+			logger.Debug("Stored item", "item", key)
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.Header().Set("Allow", "GET, PUT")
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+}
+
+// Items is the RPC service of the item API
+type Items struct {
+	cache *Cache
+}
+
+// Get returns the item under key
+func (i *Items) Get(key string, value *string) error {
+	found, err := i.cache.Get(key)
+	if err != nil {
+		return err
+	}
+	*value = found
+	return nil
+}
+
+// RPCServer serves the Items service over net/rpc. It stands in for a gRPC
+// server: the lifecycle is the same, listen on Start and drain on Stop
+type RPCServer struct {
+	server
+	conns sync.WaitGroup
+}
+
+func (s *RPCServer) Start(ctx component.Context) (component.Lifecycle, error) {
+	config, ok := component.DependencyAs[*Config](ctx, "config")
+	if !ok {
+		return nil, errors.New("config dependency missing")
+	}
+	cache, ok := component.DependencyAs[*Cache](ctx, "cache")
+	if !ok {
+		return nil, errors.New("cache dependency missing")
+	}
+
+	rpcServer := rpc.NewServer()
+	if err := rpcServer.Register(&Items{cache: cache}); err != nil {
+		return nil, err
+	}
+	listener, err := s.listen(config.RPCAddr)
+	if err != nil {
+		return nil, err
+	}
+	go s.accept(listener, rpcServer)
+	return s, nil
+}
+
+// accept serves each connection until the listener is closed
+func (s *RPCServer) accept(listener net.Listener, rpcServer *rpc.Server) {
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		s.conns.Add(1)
+		go func() {
+			defer s.conns.Done()
+			rpcServer.ServeConn(conn)
+		}()
+	}
+}
+
+func (s *RPCServer) Stop(ctx component.Context) error {
+	s.mu.Lock()
+	listener := s.listener
+	s.mu.Unlock()
+	return listener.Close()
+}
+
+// MetricsServer exposes the lifecycle metrics of the system in the
+// Prometheus text format at /metrics
+type MetricsServer struct {
+	server
+	Collector *componentprom.Collector
+	http      *http.Server
+}
+
+func (m *MetricsServer) Start(ctx component.Context) (component.Lifecycle, error) {
+	config, ok := component.DependencyAs[*Config](ctx, "config")
+	if !ok {
+		return nil, errors.New("config dependency missing")
+	}
+	listener, err := m.listen(config.MetricsAddr)
+	if err != nil {
+		return nil, err
+	}
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", m.Collector)
+	m.http = &http.Server{Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+	go m.http.Serve(listener)
+	return m, nil
+}
+
+func (m *MetricsServer) Stop(ctx component.Context) error {
+	return shutdown(ctx, m.http)
+}
@@ -0,0 +1,168 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/leandroolgomes/golang-dependency-graph/component"
+)
+
+// ErrNotFound is returned for keys that hold no item
+var ErrNotFound = errors.New("item not found")
+
+// Database is an in-memory item store standing in for a real database. A
+// restart reconnects to it and keeps the items
+type Database struct {
+	items   map[string]string
+	schema  int
+	healthy bool
+	mu      sync.RWMutex
+}
+
+func (d *Database) Start(ctx component.Context) (component.Lifecycle, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.items == nil {
+		d.items = make(map[string]string)
+	}
+	d.healthy = true
+	return d, nil
+}
+
+func (d *Database) Stop(ctx component.Context) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.healthy = false
+	return nil
+}
+
+// Health pings the database
+func (d *Database) Health(ctx context.Context) error {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	if !d.healthy {
+		return errors.New("database unreachable")
+	}
+	return nil
+}
+
+// SetHealthy simulates the database going down or coming back
+func (d *Database) SetHealthy(healthy bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.healthy = healthy
+}
+
+// Get returns the item under key
+func (d *Database) Get(key string) (string, error) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	value, ok := d.items[key]
+	if !ok {
+		return "", ErrNotFound
+	}
+	return value, nil
+}
+
+// Put stores an item
+func (d *Database) Put(key, value string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.items[key] = value
+}
+
+// Len returns the number of items
+func (d *Database) Len() int {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return len(d.items)
+}
+
+// Schema returns the version of the applied migrations
+func (d *Database) Schema() int {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return d.schema
+}
+
+// Migrations is a one-shot job bringing the database schema up to date
+type Migrations struct {
+	Version int
+}
+
+func (m *Migrations) Start(ctx component.Context) (component.Lifecycle, error) {
+	db, ok := component.DependencyAs[*Database](ctx, "db")
+	if !ok {
+		return nil, errors.New("db dependency missing")
+	}
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	for db.schema < m.Version {
+		db.schema++
+		ctx.Logger().Info("Applied migration", "version", db.schema)
+	}
+	return m, nil
+}
+
+func (m *Migrations) Stop(ctx component.Context) error {
+	return nil
+}
+
+// Cache fronts the database with an in-memory read-through cache
+type Cache struct {
+	db      *Database
+	entries map[string]string
+	hits    int
+	mu      sync.Mutex
+}
+
+func (c *Cache) Start(ctx component.Context) (component.Lifecycle, error) {
+	db, ok := component.DependencyAs[*Database](ctx, "db")
+	if !ok {
+		return nil, errors.New("db dependency missing")
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.db = db
+	c.entries = make(map[string]string)
+	c.hits = 0
+	return c, nil
+}
+
+func (c *Cache) Stop(ctx component.Context) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries = nil
+	return nil
+}
+
+// Get returns an item, reading the database on a miss
+func (c *Cache) Get(key string) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if value, ok := c.entries[key]; ok {
+		c.hits++
+		return value, nil
+	}
+	value, err := c.db.Get(key)
+	if err == nil {
+		c.entries[key] = value
+	}
+	return value, err
+}
+
+// Put writes an item through to the database
+func (c *Cache) Put(key, value string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.db.Put(key, value)
+	c.entries[key] = value
+}
+
+// Hits returns the number of reads served from the cache
+func (c *Cache) Hits() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.hits
+}
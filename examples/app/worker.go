@@ -0,0 +1,65 @@
+package app
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/leandroolgomes/golang-dependency-graph/component"
+)
+
+// Worker periodically reports the size of the database in the background
+type Worker struct {
+	runs int
+	stop chan struct{}
+	done chan struct{}
+	mu   sync.Mutex
+}
+
+func (w *Worker) Start(ctx component.Context) (component.Lifecycle, error) {
+	config, ok := component.DependencyAs[*Config](ctx, "config")
+	if !ok {
+		return nil, errors.New("config dependency missing")
+	}
+	db, ok := component.DependencyAs[*Database](ctx, "db")
+	if !ok {
+		return nil, errors.New("db dependency missing")
+	}
+
+	w.stop = make(chan struct{})
+	w.done = make(chan struct{})
+	go w.run(config.WorkerInterval, db, ctx.Logger())
+	return w, nil
+}
+
+// run ticks until the worker is stopped
+func (w *Worker) run(interval time.Duration, db *Database, logger component.Logger) {
+	defer close(w.done)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-w.stop:
+			return
+		case <-ticker.C:
+			w.mu.Lock()
+			w.runs++
+			w.mu.Unlock()
+			logger.Debug("Worker run", "items", db.Len())
+		}
+	}
+}
+
+// Stop waits for the current run to finish
+func (w *Worker) Stop(ctx component.Context) error {
+	close(w.stop)
+	<-w.done
+	return nil
+}
+
+// Runs returns the number of completed runs
+func (w *Worker) Runs() int {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.runs
+}
@@ -14,7 +14,11 @@ type Config struct{
 
 func (c *Config) Start(ctx component.Context) (component.Lifecycle, error) {
 
-	c.Port = 3000
+	port, err := ctx.Env().Int("PORT", 3000)
+	if err != nil {
+		return nil, err
+	}
+	c.Port = port
 	return c, nil
 }
 
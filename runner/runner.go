@@ -0,0 +1,240 @@
+package runner
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/leandroolgomes/golang-dependency-graph/component"
+)
+
+// Outcome describes how a run of the system terminated
+type Outcome int
+
+const (
+	// CleanStop means the system started and stopped without errors
+	CleanStop Outcome = iota
+
+	// StartFailure means the system failed to start
+	StartFailure
+
+	// StopFailure means one or more components failed to stop
+	StopFailure
+
+	// StopTimeout means the system did not stop within the stop timeout
+	StopTimeout
+
+	// SupervisorEscalation means a supervisor escalated to a full shutdown
+	SupervisorEscalation
+
+	// FatalError means a component reported an unrecoverable error
+	FatalError
+)
+
+func (o Outcome) String() string {
+	switch o {
+	case CleanStop:
+		return "clean stop"
+	case StartFailure:
+		return "start failure"
+	case StopFailure:
+		return "stop failure"
+	case StopTimeout:
+		return "stop timeout"
+	case SupervisorEscalation:
+		return "supervisor escalation"
+	case FatalError:
+		return "fatal error"
+	default:
+		return fmt.Sprintf("outcome(%d)", int(o))
+	}
+}
+
+// ExitCodes maps run outcomes to process exit codes
+type ExitCodes map[Outcome]int
+
+// DefaultExitCodes is the exit code policy used unless overridden
+var DefaultExitCodes = ExitCodes{
+	CleanStop:            0,
+	StartFailure:         1,
+	StopFailure:          1,
+	StopTimeout:          2,
+	SupervisorEscalation: 3,
+	FatalError:           3,
+}
+
+// Runner starts a system, waits for a shutdown trigger and stops it
+type Runner struct {
+	system      *component.System
+	signals     []os.Signal
+	stopTimeout time.Duration
+	exitCodes   ExitCodes
+	shutdown    chan component.ShutdownReason
+	catalog     component.Catalog
+}
+
+// Option configures a Runner
+type Option func(*Runner)
+
+// WithSignals sets the OS signals that trigger a shutdown
+func WithSignals(signals ...os.Signal) Option {
+	return func(r *Runner) {
+		r.signals = signals
+	}
+}
+
+// WithStopTimeout bounds how long the runner waits for the system to stop
+func WithStopTimeout(timeout time.Duration) Option {
+	return func(r *Runner) {
+		r.stopTimeout = timeout
+	}
+}
+
+// WithExitCodes overrides exit codes for specific outcomes
+func WithExitCodes(codes ExitCodes) Option {
+	return func(r *Runner) {
+		for outcome, code := range codes {
+			r.exitCodes[outcome] = code
+		}
+	}
+}
+
+// New creates a runner for the system
+func New(system *component.System, opts ...Option) *Runner {
+	r := &Runner{
+		system:    system,
+		signals:   []os.Signal{syscall.SIGINT, syscall.SIGTERM},
+		exitCodes: make(ExitCodes, len(DefaultExitCodes)),
+		shutdown:  make(chan component.ShutdownReason, 1),
+	}
+	for outcome, code := range DefaultExitCodes {
+		r.exitCodes[outcome] = code
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	if r.catalog == nil {
+		r.catalog = system.Catalog()
+	}
+	return r
+}
+
+// WithCatalog sets the catalog used for runner messages, defaulting to the
+// catalog of the system
+func WithCatalog(catalog component.Catalog) Option {
+	return func(r *Runner) {
+		r.catalog = catalog
+	}
+}
+
+// Run starts the system with a new runner and returns the process exit code
+func Run(system *component.System, opts ...Option) int {
+	return New(system, opts...).Run()
+}
+
+// Shutdown asks a running runner to stop the system for the given reason
+func (r *Runner) Shutdown(reason component.ShutdownReason) {
+	select {
+	case r.shutdown <- reason:
+	default:
+	}
+}
+
+// Run starts the system, blocks until a signal or Shutdown call and stops
+// the system, returning the exit code for the outcome
+func (r *Runner) Run() int {
+	outcome := r.run()
+	return r.ExitCode(outcome)
+}
+
+// ExitCode returns the exit code configured for an outcome
+func (r *Runner) ExitCode(outcome Outcome) int {
+	if code, ok := r.exitCodes[outcome]; ok {
+		return code
+	}
+	return 1
+}
+
+func (r *Runner) run() Outcome {
+	// Take over shutdown requests so the system does not stop itself
+	requests := r.system.ShutdownRequests()
+
+	r.print(component.MsgRunnerStarting)
+	if err := r.system.Start(); err != nil {
+		r.print(component.MsgRunnerStartFailed, r.system.FormatError(err))
+		// Stop what the failed start left running before the process exits
+		r.halt(component.ShutdownReason{Cause: component.ShutdownFatalError, Detail: "start failed"})
+		return StartFailure
+	}
+	r.print(component.MsgRunnerStarted)
+
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, r.signals...)
+	defer signal.Stop(sigChan)
+
+	var reason component.ShutdownReason
+	select {
+	case sig := <-sigChan:
+		r.print(component.MsgRunnerSignal, sig)
+		reason = component.SignalReason(sig)
+	case reason = <-r.shutdown:
+		r.print(component.MsgRunnerShutdownRequested, reason)
+	case reason = <-requests:
+		r.print(component.MsgRunnerShutdownRequested, reason)
+	}
+
+	return r.stop(reason)
+}
+
+// stop stops the system within the stop timeout and classifies the outcome
+func (r *Runner) stop(reason component.ShutdownReason) Outcome {
+	if outcome := r.halt(reason); outcome != CleanStop {
+		return outcome
+	}
+
+	r.print(component.MsgRunnerStopped)
+
+	switch reason.Cause {
+	case component.ShutdownSupervisor:
+		return SupervisorEscalation
+	case component.ShutdownFatalError:
+		return FatalError
+	default:
+		return CleanStop
+	}
+}
+
+// halt stops the system within the stop timeout, returning StopFailure or
+// StopTimeout when it did not stop cleanly and CleanStop otherwise
+func (r *Runner) halt(reason component.ShutdownReason) Outcome {
+	done := make(chan error, 1)
+	go func() {
+		done <- r.system.StopWithReason(reason)
+	}()
+
+	var timeout <-chan time.Time
+	if r.stopTimeout > 0 {
+		timer := time.NewTimer(r.stopTimeout)
+		defer timer.Stop()
+		timeout = timer.C
+	}
+
+	select {
+	case err := <-done:
+		if err != nil {
+			r.print(component.MsgRunnerStopFailed, err)
+			return StopFailure
+		}
+	case <-timeout:
+		r.print(component.MsgRunnerStopTimeout, r.stopTimeout)
+		return StopTimeout
+	}
+	return CleanStop
+}
+
+// print writes a catalog message to stdout
+func (r *Runner) print(id component.MessageID, args ...interface{}) {
+	fmt.Println(r.catalog.Message(id, args...))
+}
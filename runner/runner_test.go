@@ -0,0 +1,99 @@
+package runner
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/leandroolgomes/golang-dependency-graph/component"
+)
+
+type fakeComponent struct {
+	startErr error
+	stopErr  error
+	stopWait time.Duration
+	stopped  bool
+}
+
+func (f *fakeComponent) Start(ctx component.Context) (component.Lifecycle, error) {
+	return f, f.startErr
+}
+
+func (f *fakeComponent) Stop(ctx component.Context) error {
+	time.Sleep(f.stopWait)
+	f.stopped = true
+	return f.stopErr
+}
+
+func newSystem(f *fakeComponent) *component.System {
+	return component.CreateSystem(map[string]*component.Component{
+		"fake": component.Define("fake", f),
+	})
+}
+
+func runWith(t *testing.T, f *fakeComponent, reason component.ShutdownReason, opts ...Option) int {
+	t.Helper()
+	r := New(newSystem(f), opts...)
+	r.Shutdown(reason)
+	return r.Run()
+}
+
+func TestExitCodes(t *testing.T) {
+	api := component.ShutdownReason{Cause: component.ShutdownAPI}
+	tests := []struct {
+		name      string
+		component *fakeComponent
+		reason    component.ShutdownReason
+		opts      []Option
+		expected  int
+	}{
+		{"clean stop", &fakeComponent{}, api, nil, 0},
+		{"start failure", &fakeComponent{startErr: errors.New("boom")}, api, nil, 1},
+		{"stop failure", &fakeComponent{stopErr: errors.New("boom")}, api, nil, 1},
+		{"stop timeout", &fakeComponent{stopWait: time.Second}, api, []Option{WithStopTimeout(10 * time.Millisecond)}, 2},
+		{"supervisor escalation", &fakeComponent{}, component.ShutdownReason{Cause: component.ShutdownSupervisor}, nil, 3},
+		{"custom code", &fakeComponent{}, api, []Option{WithExitCodes(ExitCodes{CleanStop: 10})}, 10},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if code := runWith(t, tt.component, tt.reason, tt.opts...); code != tt.expected {
+				t.Errorf("Expected exit code %d, got %d", tt.expected, code)
+			}
+		})
+	}
+}
+
+func TestRunGroupFailureEscalates(t *testing.T) {
+	system := newSystem(&fakeComponent{})
+	r := New(system)
+
+	go func() {
+		for !system.IsStarted() {
+			time.Sleep(time.Millisecond)
+		}
+		system.RunGroup().Go(func(ctx context.Context) error {
+			return errors.New("worker crashed")
+		})
+	}()
+
+	if code := r.Run(); code != 3 {
+		t.Errorf("Expected supervisor escalation exit code 3, got %d", code)
+	}
+}
+
+func TestStartFailureStopsStartedComponents(t *testing.T) {
+	db := &fakeComponent{}
+	system := component.CreateSystem(map[string]*component.Component{
+		"db":  component.Define("db", db),
+		"api": component.Define("api", &fakeComponent{startErr: errors.New("boom")}, "db"),
+	})
+
+	if code := New(system).Run(); code != 1 {
+		t.Errorf("Expected start failure exit code 1, got %d", code)
+	}
+	if !db.stopped {
+		t.Error("Expected the failed start to stop db before the runner returns")
+	}
+}